@@ -0,0 +1,17 @@
+package config
+
+type AuthConfig struct {
+	Enabled  bool
+	JWKSFile string
+	Issuer   string
+	Audience string
+}
+
+func (c *Config) loadAuthConfig() {
+	c.Auth = AuthConfig{
+		Enabled:  c.getBool("EMLIB_AUTH_ENABLED", true),
+		JWKSFile: c.getEnv("EMLIB_AUTH_JWKS_FILE", ""),
+		Issuer:   c.getEnv("EMLIB_AUTH_JWT_ISSUER", ""),
+		Audience: c.getEnv("EMLIB_AUTH_JWT_AUDIENCE", ""),
+	}
+}
@@ -0,0 +1,22 @@
+package config
+
+type ChangesConfig struct {
+	// Как часто в миллисекундах проверять новые события, пока запрос ленты изменений ждёт их
+	PollInterval int
+	// Как часто в секундах отправлять ping в поток событий, чтобы прокси не закрывали соединение
+	StreamHeartbeat int
+	// Сколько событий может ждать отправки подписчику потока, прежде чем он будет отключён
+	StreamBuffer int
+}
+
+func (c *Config) loadChangesConfig() {
+	c.Changes = ChangesConfig{
+		PollInterval:    c.getInt("EMLIB_CHANGES_POLL_INTERVAL", 1000),
+		StreamHeartbeat: c.getInt("EMLIB_CHANGES_STREAM_HEARTBEAT", 15),
+		StreamBuffer:    c.getInt("EMLIB_CHANGES_STREAM_BUFFER", 256),
+	}
+
+	c.checkMin("EMLIB_CHANGES_POLL_INTERVAL", c.Changes.PollInterval, 10)
+	c.checkMin("EMLIB_CHANGES_STREAM_HEARTBEAT", c.Changes.StreamHeartbeat, 1)
+	c.checkMin("EMLIB_CHANGES_STREAM_BUFFER", c.Changes.StreamBuffer, 1)
+}
@@ -2,54 +2,263 @@ package config
 
 import (
 	"em-library/pkg/logging"
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Logger   Logger
-	Server   ServerConfig
-	DB       DBConfig
-	Services ServicesConfig
+	Logger        Logger
+	Log           LogConfig
+	Server        ServerConfig
+	GRPC          GRPCConfig
+	DB            DBConfig
+	Services      ServicesConfig
+	ContentFilter ContentFilterConfig
+	Refresh       RefreshConfig
+	Duplicates    DuplicatesConfig
+	Auth          AuthConfig
+	RateLimit     RateLimitConfig
+	Metrics       MetricsConfig
+	Tracing       TracingConfig
+	Health        HealthConfig
+	Webhooks      WebhooksConfig
+	Changes       ChangesConfig
+	Idempotency   IdempotencyConfig
+
+	// путь к файлу конфигурации, если он задан
+	File string
+
+	// значения из файла конфигурации, разложенные по именам переменных окружения
+	file     *configFile
+	settings map[string]Setting
+	errs     []error
+}
+
+type LogConfig struct {
+	Level  string
+	Format string
+}
+
+// Итоговое значение параметра и откуда оно взято: default, file или env
+type Setting struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// Загружает конфигурацию из значений по умолчанию, файла конфигурации и переменных
+// окружения (в порядке возрастания приоритета). Путь к файлу берётся из аргумента
+// или EMLIB_CONFIG_FILE. Возвращает все найденные ошибки сразу, а не только первую.
+func Load(file string) (*Config, error) {
+	config := &Config{
+		settings: make(map[string]Setting),
+	}
+	config.load(file)
+
+	return config, errors.Join(config.errs...)
 }
 
-func Load() *Config {
-	config := &Config{}
-	config.load()
+// Заново читает файл конфигурации и переменные окружения. Логгер остаётся прежним,
+// новый уровень логирования применяет тот, кто вызвал перезагрузку.
+func (c *Config) Reload() (*Config, error) {
+	config := &Config{
+		Logger:   c.Logger,
+		settings: make(map[string]Setting),
+	}
+	config.load(c.File)
 
-	return config
+	return config, errors.Join(config.errs...)
 }
 
-func (c *Config) load() {
+func (c *Config) load(file string) {
 	err := godotenv.Load()
 	var envFileFound bool = true
 	if err != nil {
 		envFileFound = false
 	}
 
-	logLevel := os.Getenv("EMLIB_LOG_LEVEL")
-	switch logLevel {
-	case "debug", "info", "warning", "error":
-		c.Logger = logging.NewLogger(logLevel)
-	default:
-		c.Logger = logging.NewLogger("info")
+	if file == "" {
+		file = os.Getenv("EMLIB_CONFIG_FILE")
+	}
+	c.File = file
+	if file != "" {
+		c.file, err = readConfigFile(file)
+		if err != nil {
+			c.errs = append(c.errs, err)
+		}
 	}
 
-	if envFileFound {
+	c.loadLogConfig()
+
+	if !envFileFound {
 		c.Logger.Debug(".env file not found, only ENV variables are used")
 	}
 
 	c.loadDBConfig()
 	c.loadServerConfig()
+	c.loadGRPCConfig()
 	c.loadServicesConfig()
+	c.loadContentFilterConfig()
+	c.loadRefreshConfig()
+	c.loadDuplicatesConfig()
+	c.loadAuthConfig()
+	c.loadRateLimitConfig()
+	c.loadMetricsConfig()
+	c.loadTracingConfig()
+	c.loadHealthConfig()
+	c.loadWebhooksConfig()
+	c.loadChangesConfig()
+	c.loadIdempotencyConfig()
+
+	if unknown := c.file.unknownKeys(c.settings); len(unknown) > 0 {
+		c.errs = append(c.errs, fmt.Errorf("config file %s: unknown keys %s", file, strings.Join(unknown, ", ")))
+	}
+}
+
+// При первой загрузке логгер ещё не создан, поэтому уровень и формат читаются без отладочных сообщений
+func (c *Config) loadLogConfig() {
+	c.Log = LogConfig{
+		Level:  c.getOneOf("EMLIB_LOG_LEVEL", "info", "debug", "info", "warning", "error"),
+		Format: c.getOneOf("EMLIB_LOG_FORMAT", "text", "text", "json"),
+	}
+	if c.Logger == nil {
+		c.Logger = logging.NewLogger(c.Log.Level, c.Log.Format)
+	}
+}
+
+// Все параметры с итоговыми значениями, отсортированные по имени. Пароли и секреты скрыты.
+func (c *Config) Settings() []Setting {
+	settings := make([]Setting, 0, len(c.settings))
+	for _, s := range c.settings {
+		if isSecretKey(s.Key) {
+			s.Value = redactedValue(s.Value)
+		}
+		settings = append(settings, s)
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+
+	return settings
+}
+
+// Параметры, значения которых в other отличаются, отсортированные по имени
+func (c *Config) Diff(other *Config) []string {
+	var keys []string
+	for key, setting := range other.settings {
+		if c.settings[key].Value != setting.Value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range c.settings {
+		if _, ok := other.settings[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
 }
 
 func (c *Config) getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
+	setting := Setting{Key: key, Value: defaultValue, Source: "default"}
+
+	if value := os.Getenv(key); value != "" {
+		setting.Value, setting.Source = value, "env"
+	} else if value, ok := c.file.get(key); ok {
+		setting.Value, setting.Source = value, "file"
+	} else if c.Logger != nil {
 		c.Logger.Debug("Environment variable not set. Fallback to default value", "key", key)
+	}
+
+	c.settings[key] = setting
+	return setting.Value
+}
+
+func (c *Config) getInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(c.getEnv(key, strconv.Itoa(defaultValue)))
+	if err != nil {
+		c.addError(key, "must be an integer")
 		return defaultValue
 	}
 	return value
 }
+
+func (c *Config) getFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(c.getEnv(key, strconv.FormatFloat(defaultValue, 'f', -1, 64)), 64)
+	if err != nil {
+		c.addError(key, "must be a number")
+		return defaultValue
+	}
+	return value
+}
+
+// Принимает 1/0 и true/false
+func (c *Config) getBool(key string, defaultValue bool) bool {
+	defaultStr := "0"
+	if defaultValue {
+		defaultStr = "1"
+	}
+
+	value, err := strconv.ParseBool(c.getEnv(key, defaultStr))
+	if err != nil {
+		c.addError(key, "must be 1 or 0")
+		return defaultValue
+	}
+	return value
+}
+
+func (c *Config) getOneOf(key, defaultValue string, allowed ...string) string {
+	value := c.getEnv(key, defaultValue)
+	if !slices.Contains(allowed, value) {
+		c.addError(key, "must be one of "+strings.Join(allowed, ", "))
+		return defaultValue
+	}
+	return value
+}
+
+func (c *Config) getURL(key, defaultValue string) string {
+	value := c.getEnv(key, defaultValue)
+	u, err := url.ParseRequestURI(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		c.addError(key, "must be an http or https URL")
+	}
+	return value
+}
+
+func (c *Config) checkRange(key string, value, min, max float64) {
+	if value < min || value > max {
+		c.addError(key, fmt.Sprintf("must be between %g and %g", min, max))
+	}
+}
+
+func (c *Config) checkMin(key string, value, min int) {
+	if value < min {
+		c.addError(key, fmt.Sprintf("must be at least %d", min))
+	}
+}
+
+func (c *Config) addError(key, msg string) {
+	value := c.settings[key].Value
+	if isSecretKey(key) {
+		value = redactedValue(value)
+	}
+	c.errs = append(c.errs, fmt.Errorf("%s: %s (got %q)", key, msg, value))
+}
+
+func isSecretKey(key string) bool {
+	for _, part := range []string{"PASSWORD", "SECRET", "TOKEN"} {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package config
+
+import "strings"
+
+type ContentFilterConfig struct {
+	WordListsDir string
+	Languages    []string
+}
+
+func (c *Config) loadContentFilterConfig() {
+	var languages []string
+	for _, lang := range strings.Split(c.getEnv("EMLIB_CONTENT_FILTER_LANGUAGES", ""), ",") {
+		lang = strings.TrimSpace(lang)
+		if lang != "" {
+			languages = append(languages, lang)
+		}
+	}
+
+	c.ContentFilter = ContentFilterConfig{
+		WordListsDir: c.getEnv("EMLIB_CONTENT_FILTER_DIR", "wordlists"),
+		Languages:    languages,
+	}
+}
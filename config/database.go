@@ -2,7 +2,7 @@ package config
 
 import (
 	"fmt"
-	"strconv"
+	"log/slog"
 )
 
 type DBConfig struct {
@@ -12,25 +12,35 @@ type DBConfig struct {
 	Password      string
 	DBName        string
 	RunMigrations bool
+	// Размер пула соединений и время жизни соединения в секундах
+	MaxConns        int
+	MinConns        int
+	MaxConnLifetime int
+	MaxConnIdleTime int
 }
 
 func (c *Config) loadDBConfig() {
-	port, err := strconv.Atoi(c.getEnv("EMLIB_DB_PORT", "5432"))
-	if err != nil {
-		c.Logger.Error("Error: EMLIB_DB_PORT must be an integer")
-	}
-
-	runMigrationsStr := c.getEnv("EMLIB_RUN_MIGRATIONS", "1")
-
 	c.DB = DBConfig{
-		Host:          c.getEnv("EMLIB_DB_HOST", "localhost"),
-		Port:          port,
-		User:          c.getEnv("EMLIB_DB_USER", "postgres"),
-		Password:      c.getEnv("EMLIB_DB_PASSWORD", ""),
-		DBName:        c.getEnv("EMLIB_DB_NAME", "postgres"),
-		RunMigrations: runMigrationsStr == "1",
+		Host:            c.getEnv("EMLIB_DB_HOST", "localhost"),
+		Port:            c.getInt("EMLIB_DB_PORT", 5432),
+		User:            c.getEnv("EMLIB_DB_USER", "postgres"),
+		Password:        c.getEnv("EMLIB_DB_PASSWORD", ""),
+		DBName:          c.getEnv("EMLIB_DB_NAME", "postgres"),
+		RunMigrations:   c.getBool("EMLIB_RUN_MIGRATIONS", false),
+		MaxConns:        c.getInt("EMLIB_DB_MAX_CONNS", 50),
+		MinConns:        c.getInt("EMLIB_DB_MIN_CONNS", 10),
+		MaxConnLifetime: c.getInt("EMLIB_DB_MAX_CONN_LIFETIME", 300),
+		MaxConnIdleTime: c.getInt("EMLIB_DB_MAX_CONN_IDLE_TIME", 300),
 	}
 
+	if c.DB.Host == "" {
+		c.addError("EMLIB_DB_HOST", "must not be empty")
+	}
+	c.checkRange("EMLIB_DB_PORT", float64(c.DB.Port), 1, 65535)
+	c.checkMin("EMLIB_DB_MAX_CONNS", c.DB.MaxConns, 1)
+	c.checkRange("EMLIB_DB_MIN_CONNS", float64(c.DB.MinConns), 0, float64(c.DB.MaxConns))
+	c.checkMin("EMLIB_DB_MAX_CONN_LIFETIME", c.DB.MaxConnLifetime, 0)
+	c.checkMin("EMLIB_DB_MAX_CONN_IDLE_TIME", c.DB.MaxConnIdleTime, 0)
 }
 
 func (config DBConfig) GetConnectionString() string {
@@ -43,3 +53,38 @@ func (config DBConfig) GetConnectionString() string {
 		config.DBName,
 	)
 }
+
+// Пароль не выводится ни в лог, ни при печати конфига
+func (config DBConfig) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("host", config.Host),
+		slog.Int("port", config.Port),
+		slog.String("user", config.User),
+		slog.String("password", redactedValue(config.Password)),
+		slog.String("db_name", config.DBName),
+		slog.Bool("run_migrations", config.RunMigrations),
+		slog.Int("max_conns", config.MaxConns),
+		slog.Int("min_conns", config.MinConns),
+	)
+}
+
+func (config DBConfig) String() string {
+	return fmt.Sprintf(
+		"{Host:%s Port:%d User:%s Password:%s DBName:%s RunMigrations:%t MaxConns:%d MinConns:%d}",
+		config.Host,
+		config.Port,
+		config.User,
+		redactedValue(config.Password),
+		config.DBName,
+		config.RunMigrations,
+		config.MaxConns,
+		config.MinConns,
+	)
+}
+
+func redactedValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
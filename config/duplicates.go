@@ -0,0 +1,16 @@
+package config
+
+type DuplicatesConfig struct {
+	Interval int
+	MinScore float64
+}
+
+func (c *Config) loadDuplicatesConfig() {
+	c.Duplicates = DuplicatesConfig{
+		Interval: c.getInt("EMLIB_DUPLICATES_INTERVAL", 60),
+		MinScore: c.getFloat("EMLIB_DUPLICATES_MIN_SCORE", 0.85),
+	}
+
+	c.checkMin("EMLIB_DUPLICATES_INTERVAL", c.Duplicates.Interval, 0)
+	c.checkRange("EMLIB_DUPLICATES_MIN_SCORE", c.Duplicates.MinScore, 0, 1)
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Значения файла конфигурации, разложенные по именам переменных окружения,
+// и исходные ключи файла, чтобы в ошибках называть их так, как они записаны в файле
+type configFile struct {
+	values map[string]string
+	keys   map[string]string
+}
+
+// Читает YAML или TOML файл конфигурации и раскладывает его в ключи переменных окружения:
+// секция db с полем port превращается в EMLIB_DB_PORT, rate_limit.read.burst — в
+// EMLIB_RATE_LIMIT_READ_BURST. Так у файла и переменных окружения одни и те же имена и проверки.
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var tree map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	file := &configFile{
+		values: make(map[string]string),
+		keys:   make(map[string]string),
+	}
+	if err := file.flatten("EMLIB", "", tree); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Значение параметра из файла. Если файл не задан, значений в нём нет.
+func (f *configFile) get(name string) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	value, ok := f.values[name]
+	return value, ok
+}
+
+// Ключи файла, которые не соответствуют ни одному параметру из known, отсортированные по имени.
+// Обычно это опечатки, из-за которых значение молча не применилось бы.
+func (f *configFile) unknownKeys(known map[string]Setting) []string {
+	if f == nil {
+		return nil
+	}
+
+	var unknown []string
+	for name, key := range f.keys {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+func (f *configFile) flatten(prefix, path string, tree map[string]any) error {
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := prefix + "_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		switch value := tree[key].(type) {
+		case map[string]any:
+			if err := f.flatten(name, keyPath, value); err != nil {
+				return err
+			}
+			continue
+		case []any:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			f.values[name] = strings.Join(items, ",")
+		case bool:
+			f.values[name] = strconv.FormatBool(value)
+		case nil:
+			f.values[name] = ""
+		case string, int, int64, uint64, float64:
+			f.values[name] = fmt.Sprint(value)
+		default:
+			return fmt.Errorf("%s: unsupported value type %T", name, value)
+		}
+		f.keys[name] = keyPath
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package config
+
+import "strconv"
+
+type GRPCConfig struct {
+	Enabled bool
+	Port    string
+}
+
+func (c *Config) loadGRPCConfig() {
+	port := c.getInt("EMLIB_GRPC_PORT", 9090)
+	c.checkRange("EMLIB_GRPC_PORT", float64(port), 1, 65535)
+
+	c.GRPC = GRPCConfig{
+		Enabled: c.getBool("EMLIB_GRPC_ENABLED", true),
+		Port:    strconv.Itoa(port),
+	}
+}
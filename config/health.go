@@ -0,0 +1,22 @@
+package config
+
+type HealthConfig struct {
+	// Таймаут проверки одной зависимости в миллисекундах
+	Timeout int
+	// Проверять ли сервис с данными песен. Он некритичный: без него сервис работает с ограничениями
+	CheckInfoService bool
+	// Сколько секунд отвечать 503 на readiness перед остановкой сервера,
+	// чтобы балансировщик успел убрать под из ротации
+	ShutdownDelay int
+}
+
+func (c *Config) loadHealthConfig() {
+	c.Health = HealthConfig{
+		Timeout:          c.getInt("EMLIB_HEALTH_TIMEOUT", 2000),
+		CheckInfoService: c.getBool("EMLIB_HEALTH_CHECK_INFOSERVICE", false),
+		ShutdownDelay:    c.getInt("EMLIB_HEALTH_SHUTDOWN_DELAY", 0),
+	}
+
+	c.checkMin("EMLIB_HEALTH_TIMEOUT", c.Health.Timeout, 1)
+	c.checkMin("EMLIB_HEALTH_SHUTDOWN_DELAY", c.Health.ShutdownDelay, 0)
+}
@@ -0,0 +1,16 @@
+package config
+
+type IdempotencyConfig struct {
+	Enabled bool
+	// Сколько в секундах хранить ответы на запросы с Idempotency-Key
+	TTL int
+}
+
+func (c *Config) loadIdempotencyConfig() {
+	c.Idempotency = IdempotencyConfig{
+		Enabled: c.getBool("EMLIB_IDEMPOTENCY_ENABLED", true),
+		TTL:     c.getInt("EMLIB_IDEMPOTENCY_TTL", 86400),
+	}
+
+	c.checkMin("EMLIB_IDEMPOTENCY_TTL", c.Idempotency.TTL, 1)
+}
@@ -0,0 +1,11 @@
+package config
+
+type MetricsConfig struct {
+	Enabled bool
+}
+
+func (c *Config) loadMetricsConfig() {
+	c.Metrics = MetricsConfig{
+		Enabled: c.getBool("EMLIB_METRICS_ENABLED", true),
+	}
+}
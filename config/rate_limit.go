@@ -0,0 +1,39 @@
+package config
+
+type RateLimitRuleConfig struct {
+	PerMinute int
+	Burst     int
+}
+
+type RateLimitConfig struct {
+	Enabled  bool
+	Backend  string
+	Read     RateLimitRuleConfig
+	Write    RateLimitRuleConfig
+	Upstream RateLimitRuleConfig
+}
+
+func (c *Config) loadRateLimitConfig() {
+	c.RateLimit = RateLimitConfig{
+		Enabled:  c.getBool("EMLIB_RATE_LIMIT_ENABLED", true),
+		Backend:  c.getOneOf("EMLIB_RATE_LIMIT_BACKEND", "memory", "memory", "postgres"),
+		Read:     c.loadRateLimitRule("READ", 600, 100),
+		Write:    c.loadRateLimitRule("WRITE", 120, 30),
+		Upstream: c.loadRateLimitRule("UPSTREAM", 30, 10),
+	}
+}
+
+func (c *Config) loadRateLimitRule(group string, defaultPerMinute, defaultBurst int) RateLimitRuleConfig {
+	perMinuteKey := "EMLIB_RATE_LIMIT_" + group + "_PER_MINUTE"
+	burstKey := "EMLIB_RATE_LIMIT_" + group + "_BURST"
+
+	rule := RateLimitRuleConfig{
+		PerMinute: c.getInt(perMinuteKey, defaultPerMinute),
+		Burst:     c.getInt(burstKey, defaultBurst),
+	}
+
+	c.checkMin(perMinuteKey, rule.PerMinute, 0)
+	c.checkMin(burstKey, rule.Burst, 0)
+
+	return rule
+}
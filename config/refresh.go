@@ -0,0 +1,21 @@
+package config
+
+type RefreshConfig struct {
+	Policy    string
+	Interval  int
+	StaleDays int
+	BatchSize int
+}
+
+func (c *Config) loadRefreshConfig() {
+	c.Refresh = RefreshConfig{
+		Policy:    c.getOneOf("EMLIB_REFRESH_POLICY", "fill_empty", "overwrite", "fill_empty", "draft"),
+		Interval:  c.getInt("EMLIB_REFRESH_INTERVAL", 0),
+		StaleDays: c.getInt("EMLIB_REFRESH_STALE_DAYS", 30),
+		BatchSize: c.getInt("EMLIB_REFRESH_BATCH_SIZE", 50),
+	}
+
+	c.checkMin("EMLIB_REFRESH_INTERVAL", c.Refresh.Interval, 0)
+	c.checkMin("EMLIB_REFRESH_STALE_DAYS", c.Refresh.StaleDays, 0)
+	c.checkMin("EMLIB_REFRESH_BATCH_SIZE", c.Refresh.BatchSize, 1)
+}
@@ -1,22 +1,31 @@
 package config
 
+import "strconv"
+
 type ServerConfig struct {
 	Port           string
 	ProductionMode bool
+	// Таймауты HTTP-сервера в секундах, 0 — без ограничения
+	ReadTimeout  int
+	WriteTimeout int
+	IdleTimeout  int
 }
 
 func (c *Config) loadServerConfig() {
-	var productionMode bool
-	mode := c.getEnv("EMLIB_SERVER_MODE", "debug")
-	if mode != "debug" && mode != "production" {
-		c.Logger.Error("Error: EMLIB_SERVER_MODE must be \"debug\" or \"production\". Setting debug level.")
-	}
-	if mode == "production" {
-		productionMode = true
-	}
+	port := c.getInt("EMLIB_SERVER_PORT", 8080)
+	c.checkRange("EMLIB_SERVER_PORT", float64(port), 1, 65535)
+
+	mode := c.getOneOf("EMLIB_SERVER_MODE", "debug", "debug", "production")
 
 	c.Server = ServerConfig{
-		Port:           c.getEnv("EMLIB_SERVER_PORT", ":8080"),
-		ProductionMode: productionMode,
+		Port:           strconv.Itoa(port),
+		ProductionMode: mode == "production",
+		ReadTimeout:    c.getInt("EMLIB_SERVER_READ_TIMEOUT", 10),
+		WriteTimeout:   c.getInt("EMLIB_SERVER_WRITE_TIMEOUT", 30),
+		IdleTimeout:    c.getInt("EMLIB_SERVER_IDLE_TIMEOUT", 120),
 	}
+
+	c.checkMin("EMLIB_SERVER_READ_TIMEOUT", c.Server.ReadTimeout, 0)
+	c.checkMin("EMLIB_SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout, 0)
+	c.checkMin("EMLIB_SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout, 0)
 }
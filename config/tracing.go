@@ -0,0 +1,21 @@
+package config
+
+type TracingConfig struct {
+	Exporter     string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	File         string
+	SampleRatio  float64
+}
+
+func (c *Config) loadTracingConfig() {
+	c.Tracing = TracingConfig{
+		Exporter:     c.getOneOf("EMLIB_TRACING_EXPORTER", "none", "none", "otlp", "stdout"),
+		OTLPEndpoint: c.getEnv("EMLIB_TRACING_OTLP_ENDPOINT", "localhost:4318"),
+		OTLPInsecure: c.getBool("EMLIB_TRACING_OTLP_INSECURE", true),
+		File:         c.getEnv("EMLIB_TRACING_FILE", ""),
+		SampleRatio:  c.getFloat("EMLIB_TRACING_SAMPLE_RATIO", 1),
+	}
+
+	c.checkRange("EMLIB_TRACING_SAMPLE_RATIO", c.Tracing.SampleRatio, 0, 1)
+}
@@ -0,0 +1,31 @@
+package config
+
+type WebhooksConfig struct {
+	// Как часто в секундах проверять очередь доставок, 0 отключает рассылку
+	Interval  int
+	BatchSize int
+	// Таймаут запроса к подписчику в миллисекундах
+	Timeout     int
+	MaxAttempts int
+	// Задержка перед первым повтором и максимальная задержка в секундах
+	Backoff    int
+	MaxBackoff int
+}
+
+func (c *Config) loadWebhooksConfig() {
+	c.Webhooks = WebhooksConfig{
+		Interval:    c.getInt("EMLIB_WEBHOOKS_INTERVAL", 5),
+		BatchSize:   c.getInt("EMLIB_WEBHOOKS_BATCH_SIZE", 50),
+		Timeout:     c.getInt("EMLIB_WEBHOOKS_TIMEOUT", 5000),
+		MaxAttempts: c.getInt("EMLIB_WEBHOOKS_MAX_ATTEMPTS", 8),
+		Backoff:     c.getInt("EMLIB_WEBHOOKS_BACKOFF", 30),
+		MaxBackoff:  c.getInt("EMLIB_WEBHOOKS_MAX_BACKOFF", 3600),
+	}
+
+	c.checkMin("EMLIB_WEBHOOKS_INTERVAL", c.Webhooks.Interval, 0)
+	c.checkMin("EMLIB_WEBHOOKS_BATCH_SIZE", c.Webhooks.BatchSize, 1)
+	c.checkMin("EMLIB_WEBHOOKS_TIMEOUT", c.Webhooks.Timeout, 1)
+	c.checkMin("EMLIB_WEBHOOKS_MAX_ATTEMPTS", c.Webhooks.MaxAttempts, 1)
+	c.checkMin("EMLIB_WEBHOOKS_BACKOFF", c.Webhooks.Backoff, 1)
+	c.checkMin("EMLIB_WEBHOOKS_MAX_BACKOFF", c.Webhooks.MaxBackoff, c.Webhooks.Backoff)
+}
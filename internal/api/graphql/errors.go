@@ -0,0 +1,53 @@
+package graphql
+
+import (
+	"em-library/config"
+	"em-library/internal/errs"
+	"net/http"
+)
+
+// Ошибка резолвера с кодом в extensions.code, по которому клиент отличает виды ошибок
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// Коды GraphQL для HTTP-статусов ошибок юзкейсов из errs
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          "BAD_USER_INPUT",
+	http.StatusUnauthorized:        "UNAUTHENTICATED",
+	http.StatusForbidden:           "FORBIDDEN",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusConflict:            "CONFLICT",
+	http.StatusUnprocessableEntity: "UNPROCESSABLE",
+	http.StatusBadGateway:          "BAD_GATEWAY",
+}
+
+// Переводит ошибку юзкейса в ошибку GraphQL. Подробности внутренних ошибок только пишутся в лог.
+func toResolverError(l config.Logger, err error) error {
+	public, ok := errs.PublicOf(err)
+	code, known := statusCodes[public.Status]
+	if !ok || !known {
+		l.Error("GraphQL resolver failed", "error", err)
+		return &resolverError{message: "server error", code: "INTERNAL_SERVER_ERROR"}
+	}
+
+	switch public.Status {
+	case http.StatusUnauthorized:
+		l.Debug("Request is not authenticated", "error", err)
+	case http.StatusForbidden:
+		l.Debug("Permission denied", "error", err)
+	case http.StatusBadGateway:
+		l.Error("External Service fail", "error", err)
+	}
+
+	return &resolverError{message: public.Message, code: code}
+}
@@ -0,0 +1,87 @@
+package graphql
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// Текст песни с аргументами поля lyrics. Песни с одинаковыми аргументами загружаются одним запросом.
+type lyricsKey struct {
+	songID int
+	args   lyricsArgs
+}
+
+// Аргументы поля lyrics без указателей, чтобы их можно было сравнивать
+type lyricsArgs struct {
+	offset int32
+	limit  int32 // -1, если не задан
+	censor bool
+}
+
+func (a lyricsArgs) filter() entities.LyricsFilterData {
+	offset := int(a.offset)
+	filter := entities.LyricsFilterData{Offset: &offset, Censor: a.censor}
+	if a.limit >= 0 {
+		limit := int(a.limit)
+		filter.Limit = &limit
+	}
+	return filter
+}
+
+type loaders struct {
+	lyrics *dataloader.Loader[lyricsKey, []entities.LyricsVerseData]
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, u usecase.UseCases) context.Context {
+	l := &loaders{
+		lyrics: dataloader.NewBatchedLoader(
+			lyricsBatch(u),
+			dataloader.WithWait[lyricsKey, []entities.LyricsVerseData](2*time.Millisecond),
+			dataloader.WithBatchCapacity[lyricsKey, []entities.LyricsVerseData](maxParallelism),
+		),
+	}
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// Загружает тексты пачки песен: один вызов юзкейса на каждый набор аргументов.
+// Для песни без текста возвращается nil.
+func lyricsBatch(u usecase.UseCases) dataloader.BatchFunc[lyricsKey, []entities.LyricsVerseData] {
+	return func(ctx context.Context, keys []lyricsKey) []*dataloader.Result[[]entities.LyricsVerseData] {
+		songIDs := make(map[lyricsArgs][]int)
+		for _, key := range keys {
+			songIDs[key.args] = append(songIDs[key.args], key.songID)
+		}
+
+		verses := make(map[lyricsKey][]entities.LyricsVerseData, len(keys))
+		failed := make(map[lyricsArgs]error)
+		for args, ids := range songIDs {
+			lyrics, err := u.GetSongsLyrics.Execute(ctx, ids, args.filter())
+			if err != nil {
+				failed[args] = err
+				continue
+			}
+			for id, v := range lyrics {
+				verses[lyricsKey{songID: id, args: args}] = v
+			}
+		}
+
+		results := make([]*dataloader.Result[[]entities.LyricsVerseData], len(keys))
+		for i, key := range keys {
+			results[i] = &dataloader.Result[[]entities.LyricsVerseData]{
+				Data:  verses[key],
+				Error: failed[key.args],
+			}
+		}
+		return results
+	}
+}
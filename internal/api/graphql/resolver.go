@@ -0,0 +1,358 @@
+package graphql
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+const dateFormat = "2006-01-02"
+
+type resolver struct {
+	logger   config.Logger
+	usecases usecase.UseCases
+}
+
+type songFilterInput struct {
+	Group           *string
+	Song            *string
+	ReleaseDateFrom *string
+	ReleaseDateTo   *string
+	Explicit        *bool
+}
+
+type pageArgs struct {
+	Offset *int32
+	Limit  *int32
+}
+
+func (r *resolver) Songs(ctx context.Context, args struct {
+	Filter *songFilterInput
+	pageArgs
+}) ([]*songResolver, error) {
+	filter := entities.SongFilterData{}
+
+	if f := args.Filter; f != nil {
+		filter.Band = f.Group
+		filter.Song = f.Song
+		filter.Explicit = f.Explicit
+
+		var err error
+		if filter.ReleaseDateFrom, err = parseDate("releaseDateFrom", f.ReleaseDateFrom); err != nil {
+			return nil, toResolverError(r.logger, err)
+		}
+		if filter.ReleaseDateTo, err = parseDate("releaseDateTo", f.ReleaseDateTo); err != nil {
+			return nil, toResolverError(r.logger, err)
+		}
+	}
+
+	return r.songs(ctx, filter, args.pageArgs)
+}
+
+func (r *resolver) Song(ctx context.Context, args struct{ ID graphqlgo.ID }) (*songResolver, error) {
+	songID, err := parseID(args.ID)
+	if err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	songs, err := r.usecases.GetSongList.Execute(ctx, entities.SongFilterData{ID: &songID})
+	if err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	if len(songs) == 0 {
+		return nil, nil
+	}
+
+	return &songResolver{r: r, song: songs[0]}, nil
+}
+
+func (r *resolver) Artist(args struct{ Name string }) *artistResolver {
+	return &artistResolver{r: r, name: args.Name}
+}
+
+type newSongInput struct {
+	Group       string
+	Song        string
+	ReleaseDate *string
+	Link        *string
+	Lyrics      *string
+	Enrich      string
+}
+
+func (r *resolver) CreateSong(ctx context.Context, args struct{ Input newSongInput }) (*songResolver, error) {
+	in := args.Input
+
+	if err := checkNotEmpty(map[string]*string{
+		"group":  &in.Group,
+		"song":   &in.Song,
+		"link":   in.Link,
+		"lyrics": in.Lyrics,
+	}); err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	data := entities.NewSongData{
+		Band:   in.Group,
+		Song:   in.Song,
+		Enrich: entities.EnrichMode(strings.ToLower(in.Enrich)),
+	}
+
+	releaseDate, err := parseDate("releaseDate", in.ReleaseDate)
+	if err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+	if releaseDate != nil {
+		data.ReleaseDate = *releaseDate
+	}
+	if in.Link != nil {
+		data.Link = *in.Link
+	}
+	if in.Lyrics != nil {
+		data.Lyrics = *in.Lyrics
+	}
+
+	song, err := r.usecases.CreateSong.Execute(ctx, data)
+	if err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	r.logger.Info("Song created successfully", "id", song.ID)
+	return &songResolver{r: r, song: *song}, nil
+}
+
+type songUpdateInput struct {
+	Group       *string
+	Song        *string
+	ReleaseDate *string
+	Link        *string
+	Lyrics      *string
+}
+
+func (r *resolver) UpdateSong(ctx context.Context, args struct {
+	ID    graphqlgo.ID
+	Input songUpdateInput
+}) (*songResolver, error) {
+	songID, err := parseID(args.ID)
+	if err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	in := args.Input
+
+	if err := checkNotEmpty(map[string]*string{
+		"group":  in.Group,
+		"song":   in.Song,
+		"link":   in.Link,
+		"lyrics": in.Lyrics,
+	}); err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	releaseDate, err := parseDate("releaseDate", in.ReleaseDate)
+	if err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	err = r.usecases.UpdateSong.Execute(ctx, songID, entities.UpdateSongData{
+		Band:        in.Group,
+		Song:        in.Song,
+		ReleaseDate: releaseDate,
+		Link:        in.Link,
+		Lyrics:      in.Lyrics,
+	})
+	if err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	r.logger.Info("Song updated successfully", "ID", songID)
+
+	song, err := r.Song(ctx, struct{ ID graphqlgo.ID }{ID: args.ID})
+	if err != nil {
+		return nil, err
+	}
+	if song == nil {
+		return nil, toResolverError(r.logger, errs.ErrNotFound)
+	}
+
+	return song, nil
+}
+
+func (r *resolver) DeleteSong(ctx context.Context, args struct{ ID graphqlgo.ID }) (bool, error) {
+	songID, err := parseID(args.ID)
+	if err != nil {
+		return false, toResolverError(r.logger, err)
+	}
+
+	if err := r.usecases.DeleteSong.Execute(ctx, songID); err != nil {
+		return false, toResolverError(r.logger, err)
+	}
+
+	r.logger.Info("Song deleted successfully", "ID", songID)
+	return true, nil
+}
+
+func (r *resolver) songs(ctx context.Context, filter entities.SongFilterData, page pageArgs) ([]*songResolver, error) {
+	if page.Offset != nil {
+		if *page.Offset < 0 {
+			return nil, toResolverError(r.logger, fmt.Errorf("%w offset must not be negative", errs.ErrInvalidInput))
+		}
+		offset := int(*page.Offset)
+		filter.Offset = &offset
+	}
+
+	if page.Limit != nil {
+		if *page.Limit < 1 {
+			return nil, toResolverError(r.logger, fmt.Errorf("%w limit must be positive", errs.ErrInvalidInput))
+		}
+		limit := int(*page.Limit)
+		filter.Limit = &limit
+	}
+
+	songs, err := r.usecases.GetSongList.Execute(ctx, filter)
+	if err != nil {
+		return nil, toResolverError(r.logger, err)
+	}
+
+	result := make([]*songResolver, len(songs))
+	for i, song := range songs {
+		result[i] = &songResolver{r: r, song: song}
+	}
+	return result, nil
+}
+
+type songResolver struct {
+	r    *resolver
+	song entities.SongData
+}
+
+func (s *songResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(strconv.Itoa(s.song.ID))
+}
+
+func (s *songResolver) Group() string {
+	return s.song.Band
+}
+
+func (s *songResolver) Song() string {
+	return s.song.Song
+}
+
+func (s *songResolver) ReleaseDate() *string {
+	if s.song.ReleaseDate.IsZero() {
+		return nil
+	}
+	date := s.song.ReleaseDate.Format(dateFormat)
+	return &date
+}
+
+func (s *songResolver) Link() string {
+	return s.song.Link
+}
+
+func (s *songResolver) Explicit() *bool {
+	return s.song.Explicit
+}
+
+func (s *songResolver) Artist() *artistResolver {
+	return &artistResolver{r: s.r, name: s.song.Band}
+}
+
+func (s *songResolver) Lyrics(ctx context.Context, args struct {
+	pageArgs
+	Censor bool
+}) (*[]*verseResolver, error) {
+	key := lyricsKey{songID: s.song.ID, args: lyricsArgs{limit: -1, censor: args.Censor}}
+
+	if args.Offset != nil {
+		if *args.Offset < 0 {
+			return nil, toResolverError(s.r.logger, fmt.Errorf("%w offset must not be negative", errs.ErrInvalidInput))
+		}
+		key.args.offset = *args.Offset
+	}
+
+	if args.Limit != nil {
+		if *args.Limit < 0 {
+			return nil, toResolverError(s.r.logger, fmt.Errorf("%w limit must not be negative", errs.ErrInvalidInput))
+		}
+		key.args.limit = *args.Limit
+	}
+
+	verses, err := loadersFromContext(ctx).lyrics.Load(ctx, key)()
+	if err != nil {
+		return nil, toResolverError(s.r.logger, err)
+	}
+
+	if verses == nil {
+		return nil, nil
+	}
+
+	result := make([]*verseResolver, len(verses))
+	for i, verse := range verses {
+		result[i] = &verseResolver{verse: verse}
+	}
+	return &result, nil
+}
+
+type verseResolver struct {
+	verse entities.LyricsVerseData
+}
+
+func (v *verseResolver) Index() int32 {
+	return int32(v.verse.Index)
+}
+
+func (v *verseResolver) Content() string {
+	return v.verse.Content
+}
+
+type artistResolver struct {
+	r    *resolver
+	name string
+}
+
+func (a *artistResolver) Name() string {
+	return a.name
+}
+
+func (a *artistResolver) Songs(ctx context.Context, args pageArgs) ([]*songResolver, error) {
+	return a.r.songs(ctx, entities.SongFilterData{Band: &a.name}, args)
+}
+
+func parseID(id graphqlgo.ID) (int, error) {
+	songID, err := strconv.Atoi(string(id))
+	if err != nil || songID <= 0 {
+		return 0, fmt.Errorf("%w invalid song ID %q", errs.ErrInvalidInput, id)
+	}
+	return songID, nil
+}
+
+func parseDate(field string, value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	date, err := time.Parse(dateFormat, *value)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s must be in %s format", errs.ErrInvalidInput, field, dateFormat)
+	}
+	return &date, nil
+}
+
+// Заданные строковые поля не должны быть пустыми, как и в REST API
+func checkNotEmpty(fields map[string]*string) error {
+	for name, value := range fields {
+		if value != nil && *value == "" {
+			return fmt.Errorf("%w %s must not be empty", errs.ErrInvalidInput, name)
+		}
+	}
+	return nil
+}
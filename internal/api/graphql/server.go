@@ -0,0 +1,51 @@
+package graphql
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/usecase"
+
+	_ "embed"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// запрос глубже почти наверняка зацикливается через song.artist.songs
+	maxDepth = 8
+	// столько резолверов выполняется параллельно, столько же текстов загружается одним запросом
+	maxParallelism = 50
+)
+
+// GraphQL API библиотеки. Резолверы вызывают те же юзкейсы, что и REST API.
+type Server struct {
+	schema   *graphqlgo.Schema
+	usecases usecase.UseCases
+}
+
+func NewServer(l config.Logger, u usecase.UseCases) *Server {
+	return &Server{
+		schema: graphqlgo.MustParseSchema(
+			schema,
+			&resolver{logger: l, usecases: u},
+			graphqlgo.UseStringDescriptions(),
+			graphqlgo.MaxDepth(maxDepth),
+			graphqlgo.MaxParallelism(maxParallelism),
+		),
+		usecases: u,
+	}
+}
+
+// Выполняет запрос. Загрузчики создаются на каждый запрос, чтобы данные не кешировались между ними.
+func (s *Server) Exec(ctx context.Context, query, operationName string, variables map[string]any) *graphqlgo.Response {
+	ctx = withLoaders(ctx, s.usecases)
+	return s.schema.Exec(ctx, query, operationName, variables)
+}
+
+// Схема в SDL для генерации клиентов
+func (s *Server) Schema() string {
+	return schema
+}
@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/errs"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Коды gRPC для HTTP-статусов ошибок юзкейсов из errs
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusBadGateway:          codes.Unavailable,
+}
+
+// Переводит ошибку юзкейса в статус gRPC. Подробности внутренних ошибок только пишутся в лог.
+func toStatus(l config.Logger, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	public, ok := errs.PublicOf(err)
+	code, known := statusCodes[public.Status]
+	if !ok || !known {
+		l.Error("gRPC request failed", "error", err)
+		return status.Error(codes.Internal, "server error")
+	}
+
+	switch public.Status {
+	case http.StatusUnauthorized:
+		l.Debug("Request is not authenticated", "error", err)
+	case http.StatusForbidden:
+		l.Debug("Permission denied", "error", err)
+	case http.StatusBadGateway:
+		l.Error("External Service fail", "error", err)
+	}
+
+	return status.Error(code, public.Message)
+}
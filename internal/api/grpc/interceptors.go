@@ -0,0 +1,232 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"em-library/pkg/logging"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDKey = "x-request-id"
+
+// Методы, которым не нужны аутентификация и лимиты: проверка здоровья и описание сервисов
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// Лимиты методов SongLibrary, те же, что у соответствующих маршрутов REST API
+var methodRateLimits = map[string]entities.RateLimitGroup{
+	"/songlibrary.v1.SongLibrary/CreateSong": entities.RateLimitUpstream,
+	"/songlibrary.v1.SongLibrary/GetSong":    entities.RateLimitRead,
+	"/songlibrary.v1.SongLibrary/ListSongs":  entities.RateLimitRead,
+	"/songlibrary.v1.SongLibrary/UpdateSong": entities.RateLimitWrite,
+	"/songlibrary.v1.SongLibrary/DeleteSong": entities.RateLimitWrite,
+	"/songlibrary.v1.SongLibrary/GetLyrics":  entities.RateLimitRead,
+}
+
+type interceptors struct {
+	logger      config.Logger
+	usecases    usecase.UseCases
+	authEnabled bool
+}
+
+func (i *interceptors) unary(ctx context.Context, req any, info *grpcgo.UnaryServerInfo, handler grpcgo.UnaryHandler) (any, error) {
+	var resp any
+	err := i.call(ctx, info.FullMethod, func(ctx context.Context) error {
+		var err error
+		resp, err = handler(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (i *interceptors) stream(srv any, ss grpcgo.ServerStream, info *grpcgo.StreamServerInfo, handler grpcgo.StreamHandler) error {
+	return i.call(ss.Context(), info.FullMethod, func(ctx context.Context) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	})
+}
+
+// Общая часть unary и stream вызовов: ID запроса, аутентификация, лимит и запись в лог
+func (i *interceptors) call(ctx context.Context, method string, handler func(ctx context.Context) error) error {
+	start := time.Now()
+	ctx = withRequestID(ctx, method)
+
+	if slices.ContainsFunc(publicServices, func(prefix string) bool { return strings.HasPrefix(method, prefix) }) {
+		return handler(ctx)
+	}
+
+	authCtx, err := authenticate(ctx, i.logger, i.usecases, i.authEnabled)
+	if err == nil {
+		ctx = authCtx
+		err = checkRateLimit(ctx, i.logger, i.usecases, method)
+	}
+	if err == nil {
+		err = handler(ctx)
+	}
+
+	logCall(ctx, i.logger, method, start, err)
+	return err
+}
+
+// Поток с подменённым контекстом
+type serverStream struct {
+	grpcgo.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// Берёт ID запроса из метаданных x-request-id или генерирует новый и возвращает его в заголовке ответа,
+// как это делает HTTP-сервер.
+func withRequestID(ctx context.Context, method string) context.Context {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" || len(requestID) > 64 {
+		requestID = newRequestID()
+	}
+
+	_ = grpcgo.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+
+	ctx = entities.ContextWithRequestID(ctx, requestID)
+	return logging.ContextWithAttrs(ctx,
+		slog.String("request_id", requestID),
+		slog.String("route", method),
+	)
+}
+
+// Аутентифицирует клиента по метаданным x-api-key или authorization: Bearer, как HTTP-сервер.
+// Когда аутентификация выключена, подставляет анонимного клиента.
+func authenticate(ctx context.Context, l config.Logger, u usecase.UseCases, enabled bool) (context.Context, error) {
+	if !enabled {
+		return entities.ContextWithPrincipal(ctx, entities.AnonymousPrincipal), nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var credentials entities.Credentials
+	if values := md.Get("x-api-key"); len(values) > 0 {
+		credentials.APIKey = values[0]
+	}
+
+	if values := md.Get("authorization"); len(values) > 0 {
+		scheme, token, found := strings.Cut(values[0], " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			l.Debug("Unsupported authorization scheme", "scheme", scheme)
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+		credentials.Bearer = strings.TrimSpace(token)
+	}
+
+	principal, err := u.Authenticate.Execute(ctx, credentials)
+	if err != nil {
+		if errors.Is(err, errs.ErrUnauthorized) {
+			l.Debug("Authentication failed", "error", err)
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+
+		l.Error("Authentication error", "error", err)
+		return nil, status.Error(codes.Internal, "server error")
+	}
+
+	l.Debug("Request authenticated", "subject", principal.Subject, "method", principal.Method)
+	return entities.ContextWithPrincipal(ctx, principal), nil
+}
+
+// Списывает вызов из лимита клиента. Состояние лимита возвращается в заголовках ratelimit-*,
+// при превышении — RESOURCE_EXHAUSTED с retry-after.
+func checkRateLimit(ctx context.Context, l config.Logger, u usecase.UseCases, method string) error {
+	group, ok := methodRateLimits[method]
+	if !ok {
+		return nil
+	}
+
+	result, err := u.CheckRateLimit.Execute(ctx, group, clientIP(ctx))
+	if err != nil {
+		// недоступное хранилище лимитов не должно останавливать сервис
+		l.Error("Rate limit check failed", "error", err, "group", group)
+		return nil
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	header := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(result.Limit),
+		"ratelimit-remaining", strconv.Itoa(result.Remaining),
+		"ratelimit-reset", strconv.Itoa(ceilSeconds(result.Reset)),
+	)
+
+	if !result.Allowed {
+		retryAfter := max(ceilSeconds(result.RetryAfter), 1)
+		l.Debug("Rate limit exceeded", "group", group, "method", method, "retry_after", retryAfter)
+		header.Append("retry-after", strconv.Itoa(retryAfter))
+		_ = grpcgo.SetHeader(ctx, header)
+		return status.Error(codes.ResourceExhausted, "too many requests")
+	}
+
+	_ = grpcgo.SetHeader(ctx, header)
+	return nil
+}
+
+func logCall(ctx context.Context, l config.Logger, method string, start time.Time, err error) {
+	var subject string
+	if principal, ok := entities.PrincipalFromContext(ctx); ok {
+		subject = principal.Subject
+	}
+
+	l.InfoContext(ctx, "gRPC Request",
+		"method", method,
+		"code", status.Code(err).String(),
+		"latency", time.Since(start).String(),
+		"client_ip", clientIP(ctx),
+		"principal", subject,
+	)
+}
+
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
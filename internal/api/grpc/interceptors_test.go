@@ -0,0 +1,112 @@
+package grpc_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	songlibraryv1 "em-library/pkg/api/songlibrary/v1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Ключ из метаданных x-api-key проверяется до вызова метода, клиент попадает в контекст юзкейса
+func TestInterceptors_Auth_APIKey(t *testing.T) {
+	mockAuthenticate := new(MockAuthenticateUseCase)
+	mockGetSongList := new(MockGetSongListUseCase)
+
+	principal := &entities.Principal{Subject: "apikey:7", Role: entities.RoleViewer}
+	mockAuthenticate.On("Execute", mock.Anything, entities.Credentials{APIKey: "emlib_test"}).Return(principal, nil)
+
+	songID := 1
+	withPrincipal := mock.MatchedBy(func(ctx context.Context) bool {
+		p, ok := entities.PrincipalFromContext(ctx)
+		return ok && p.Subject == "apikey:7"
+	})
+	mockGetSongList.On("Execute", withPrincipal, entities.SongFilterData{ID: &songID}).
+		Return([]entities.SongData{{ID: 1, Band: "Muse", Song: "Uprising"}}, nil)
+
+	client := setupSongLibraryClient(t, usecase.UseCases{Authenticate: mockAuthenticate, GetSongList: mockGetSongList}, true)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "emlib_test")
+	resp, err := client.GetSong(ctx, &songlibraryv1.GetSongRequest{Id: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Uprising", resp.Song.Song)
+	mockAuthenticate.AssertExpectations(t)
+	mockGetSongList.AssertExpectations(t)
+}
+
+// Без ключа и токена метод не вызывается
+func TestInterceptors_Auth_MissingCredentials(t *testing.T) {
+	mockAuthenticate := new(MockAuthenticateUseCase)
+	mockGetSongList := new(MockGetSongListUseCase)
+
+	mockAuthenticate.On("Execute", mock.Anything, entities.Credentials{}).Return(nil, errs.ErrUnauthorized)
+
+	client := setupSongLibraryClient(t, usecase.UseCases{Authenticate: mockAuthenticate, GetSongList: mockGetSongList}, true)
+
+	_, err := client.GetSong(context.Background(), &songlibraryv1.GetSongRequest{Id: 1})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	mockGetSongList.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+// Поддерживается только схема Bearer
+func TestInterceptors_Auth_UnsupportedScheme(t *testing.T) {
+	mockAuthenticate := new(MockAuthenticateUseCase)
+
+	client := setupSongLibraryClient(t, usecase.UseCases{Authenticate: mockAuthenticate}, true)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic dXNlcjpwYXNz")
+	_, err := client.GetSong(ctx, &songlibraryv1.GetSongRequest{Id: 1})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	mockAuthenticate.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+// Роль проверяется в юзкейсе, как и в REST API: viewer не может удалить песню
+func TestInterceptors_Role_ViewerCannotDelete(t *testing.T) {
+	mockAuthenticate := new(MockAuthenticateUseCase)
+
+	principal := &entities.Principal{Subject: "apikey:7", Role: entities.RoleViewer}
+	mockAuthenticate.On("Execute", mock.Anything, entities.Credentials{Bearer: "token"}).Return(principal, nil)
+
+	// до репозиториев дело не доходит, поэтому они не нужны
+	deleteSong := usecase.NewDeleteSongUseCase(nil, nil, nil, nil, nil, nil)
+
+	client := setupSongLibraryClient(t, usecase.UseCases{Authenticate: mockAuthenticate, DeleteSong: deleteSong}, true)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+	_, err := client.DeleteSong(ctx, &songlibraryv1.DeleteSongRequest{Id: 1})
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	mockAuthenticate.AssertExpectations(t)
+}
+
+// С выключенной аутентификацией клиент анонимный с правами admin
+func TestInterceptors_Auth_Disabled(t *testing.T) {
+	mockAuthenticate := new(MockAuthenticateUseCase)
+	mockGetSongList := new(MockGetSongListUseCase)
+
+	songID := 1
+	anonymous := mock.MatchedBy(func(ctx context.Context) bool {
+		p, ok := entities.PrincipalFromContext(ctx)
+		return ok && p == entities.AnonymousPrincipal
+	})
+	mockGetSongList.On("Execute", anonymous, entities.SongFilterData{ID: &songID}).
+		Return([]entities.SongData{{ID: 1}}, nil)
+
+	client := setupSongLibraryClient(t, usecase.UseCases{Authenticate: mockAuthenticate, GetSongList: mockGetSongList}, false)
+
+	_, err := client.GetSong(context.Background(), &songlibraryv1.GetSongRequest{Id: 1})
+
+	assert.NoError(t, err)
+	mockAuthenticate.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	mockGetSongList.AssertExpectations(t)
+}
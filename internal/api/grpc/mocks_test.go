@@ -0,0 +1,92 @@
+package grpc_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLogger struct {
+	mock.Mock
+}
+
+func (m *MockLogger) Debug(msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) Info(msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) Error(msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) Warn(msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) DebugContext(_ context.Context, msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) InfoContext(_ context.Context, msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) ErrorContext(_ context.Context, msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) WarnContext(_ context.Context, msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+type MockAuthenticateUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAuthenticateUseCase) Execute(ctx context.Context, credentials entities.Credentials) (*entities.Principal, error) {
+	args := m.Called(ctx, credentials)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Principal), args.Error(1)
+}
+
+type MockCheckRateLimitUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCheckRateLimitUseCase) Execute(ctx context.Context, group entities.RateLimitGroup, clientIP string) (*entities.RateLimitResult, error) {
+	args := m.Called(ctx, group, clientIP)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RateLimitResult), args.Error(1)
+}
+
+type MockGetSongListUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetSongListUseCase) Execute(ctx context.Context, filter entities.SongFilterData) ([]entities.SongData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.SongData), args.Error(1)
+}
+
+type MockGetSongLyricsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetSongLyricsUseCase) Execute(ctx context.Context, songID int, filter entities.LyricsFilterData) ([]entities.LyricsVerseData, error) {
+	args := m.Called(ctx, songID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.LyricsVerseData), args.Error(1)
+}
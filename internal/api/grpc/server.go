@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/usecase"
+	songlibraryv1 "em-library/pkg/api/songlibrary/v1"
+	"fmt"
+	"net"
+	"time"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// gRPC API библиотеки для внутренних сервисов на отдельном порту. Кроме SongLibrary
+// на нём доступны стандартные сервисы проверки здоровья и reflection.
+type Server struct {
+	server *grpcgo.Server
+	health *health.Server
+	logger config.Logger
+	port   string
+}
+
+func NewServer(cfg *config.Config, u usecase.UseCases) *Server {
+	i := &interceptors{
+		logger:      cfg.Logger,
+		usecases:    u,
+		authEnabled: cfg.Auth.Enabled,
+	}
+
+	server := grpcgo.NewServer(
+		grpcgo.ChainUnaryInterceptor(i.unary),
+		grpcgo.ChainStreamInterceptor(i.stream),
+	)
+
+	songlibraryv1.RegisterSongLibraryServer(server, newSongLibraryServer(cfg.Logger, u))
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return &Server{
+		server: server,
+		health: healthServer,
+		logger: cfg.Logger,
+		port:   cfg.GRPC.Port,
+	}
+}
+
+// Занимает порт сервера. Ошибка возвращается сразу, чтобы сервис с занятым портом не запускался без gRPC.
+func (s *Server) Listen() (net.Listener, error) {
+	listener, err := net.Listen("tcp", ":"+s.port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on port %s: %w", s.port, err)
+	}
+	return listener, nil
+}
+
+// Принимает соединения, пока сервер не остановлен
+func (s *Server) Serve(listener net.Listener) error {
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus(songlibraryv1.SongLibrary_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	s.logger.Info("launched gRPC server", "port", s.port)
+	return s.server.Serve(listener)
+}
+
+// Сначала проверка здоровья начинает отвечать NOT_SERVING, затем сервер ждёт завершения
+// текущих вызовов. Потоки ListSongs, которые не успели закончиться за timeout, обрываются.
+func (s *Server) Shutdown(timeout time.Duration) {
+	s.health.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("gRPC server shutdown done.")
+	case <-ctx.Done():
+		s.logger.Warn("gRPC server shutdown timed out, closing connections")
+		s.server.Stop()
+	}
+}
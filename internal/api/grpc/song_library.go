@@ -0,0 +1,293 @@
+package grpc
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	songlibraryv1 "em-library/pkg/api/songlibrary/v1"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	dateFormat = "2006-01-02"
+	// столько песен ListSongs читает из базы за раз, если page_size не задан
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// Сервис SongLibrary. Методы вызывают те же юзкейсы, что и REST API.
+type songLibraryServer struct {
+	songlibraryv1.UnimplementedSongLibraryServer
+
+	logger   config.Logger
+	usecases usecase.UseCases
+}
+
+func newSongLibraryServer(l config.Logger, u usecase.UseCases) *songLibraryServer {
+	return &songLibraryServer{
+		logger:   l,
+		usecases: u,
+	}
+}
+
+var enrichModes = map[songlibraryv1.Enrich]entities.EnrichMode{
+	songlibraryv1.Enrich_ENRICH_UNSPECIFIED:  "",
+	songlibraryv1.Enrich_ENRICH_AUTO:         entities.EnrichAuto,
+	songlibraryv1.Enrich_ENRICH_NEVER:        entities.EnrichNever,
+	songlibraryv1.Enrich_ENRICH_FILL_MISSING: entities.EnrichFillMissing,
+}
+
+func (s *songLibraryServer) CreateSong(ctx context.Context, req *songlibraryv1.CreateSongRequest) (*songlibraryv1.CreateSongResponse, error) {
+	if err := checkNotEmpty(map[string]*string{"group": &req.Group, "song": &req.Song}); err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	enrich, ok := enrichModes[req.Enrich]
+	if !ok {
+		return nil, toStatus(s.logger, fmt.Errorf("%w unknown enrich mode %d", errs.ErrInvalidInput, req.Enrich))
+	}
+
+	data := entities.NewSongData{
+		Band:   req.Group,
+		Song:   req.Song,
+		Link:   req.Link,
+		Lyrics: req.Lyrics,
+		Enrich: enrich,
+	}
+
+	if req.ReleaseDate != "" {
+		releaseDate, err := parseDate("release_date", &req.ReleaseDate)
+		if err != nil {
+			return nil, toStatus(s.logger, err)
+		}
+		data.ReleaseDate = *releaseDate
+	}
+
+	song, err := s.usecases.CreateSong.Execute(ctx, data)
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	s.logger.Info("Song created successfully", "id", song.ID)
+	return &songlibraryv1.CreateSongResponse{Song: toSong(*song)}, nil
+}
+
+func (s *songLibraryServer) GetSong(ctx context.Context, req *songlibraryv1.GetSongRequest) (*songlibraryv1.GetSongResponse, error) {
+	song, err := s.getSong(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	return &songlibraryv1.GetSongResponse{Song: toSong(*song)}, nil
+}
+
+func (s *songLibraryServer) ListSongs(req *songlibraryv1.ListSongsRequest, stream songlibraryv1.SongLibrary_ListSongsServer) error {
+	filter := entities.SongFilterData{
+		Band:     req.Group,
+		Song:     req.Song,
+		Explicit: req.Explicit,
+	}
+
+	if err := checkNotEmpty(map[string]*string{"group": req.Group, "song": req.Song}); err != nil {
+		return toStatus(s.logger, err)
+	}
+
+	var err error
+	if filter.ReleaseDateFrom, err = parseDate("release_date_from", req.ReleaseDateFrom); err != nil {
+		return toStatus(s.logger, err)
+	}
+	if filter.ReleaseDateTo, err = parseDate("release_date_to", req.ReleaseDateTo); err != nil {
+		return toStatus(s.logger, err)
+	}
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0 || pageSize > maxPageSize:
+		return toStatus(s.logger, fmt.Errorf("%w page_size must be between 1 and %d", errs.ErrInvalidInput, maxPageSize))
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	}
+
+	// страницы читаются от последней отправленной песни, а не по смещению,
+	// поэтому песни, добавленные или удалённые во время чтения, не сдвигают следующие страницы
+	filter.Limit = &pageSize
+
+	for {
+		songs, err := s.usecases.GetSongList.Execute(stream.Context(), filter)
+		// пустая страница означает, что песни закончились, пустой поток не ошибка
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return toStatus(s.logger, err)
+		}
+
+		for _, song := range songs {
+			if err := stream.Send(toSong(song)); err != nil {
+				return err
+			}
+		}
+
+		if len(songs) < pageSize {
+			return nil
+		}
+		last := songs[len(songs)-1]
+		filter.After = &entities.SongCursor{ReleaseDate: last.ReleaseDate, ID: last.ID}
+	}
+}
+
+func (s *songLibraryServer) UpdateSong(ctx context.Context, req *songlibraryv1.UpdateSongRequest) (*songlibraryv1.UpdateSongResponse, error) {
+	songID, err := parseID(req.Id)
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	if err := checkNotEmpty(map[string]*string{
+		"group":  req.Group,
+		"song":   req.Song,
+		"link":   req.Link,
+		"lyrics": req.Lyrics,
+	}); err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	releaseDate, err := parseDate("release_date", req.ReleaseDate)
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	err = s.usecases.UpdateSong.Execute(ctx, songID, entities.UpdateSongData{
+		Band:        req.Group,
+		Song:        req.Song,
+		ReleaseDate: releaseDate,
+		Link:        req.Link,
+		Lyrics:      req.Lyrics,
+	})
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	s.logger.Info("Song updated successfully", "ID", songID)
+
+	song, err := s.getSong(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	return &songlibraryv1.UpdateSongResponse{Song: toSong(*song)}, nil
+}
+
+func (s *songLibraryServer) DeleteSong(ctx context.Context, req *songlibraryv1.DeleteSongRequest) (*songlibraryv1.DeleteSongResponse, error) {
+	songID, err := parseID(req.Id)
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	if err := s.usecases.DeleteSong.Execute(ctx, songID); err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	s.logger.Info("Song deleted successfully", "ID", songID)
+	return &songlibraryv1.DeleteSongResponse{}, nil
+}
+
+func (s *songLibraryServer) GetLyrics(ctx context.Context, req *songlibraryv1.GetLyricsRequest) (*songlibraryv1.GetLyricsResponse, error) {
+	songID, err := parseID(req.SongId)
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	filter := entities.LyricsFilterData{Censor: req.Censor}
+
+	if req.Offset != nil {
+		if *req.Offset < 0 {
+			return nil, toStatus(s.logger, fmt.Errorf("%w offset must not be negative", errs.ErrInvalidInput))
+		}
+		offset := int(*req.Offset)
+		filter.Offset = &offset
+	}
+
+	if req.Limit != nil {
+		if *req.Limit < 1 {
+			return nil, toStatus(s.logger, fmt.Errorf("%w limit must be positive", errs.ErrInvalidInput))
+		}
+		limit := int(*req.Limit)
+		filter.Limit = &limit
+	}
+
+	verses, err := s.usecases.GetSongLyrics.Execute(ctx, songID, filter)
+	if err != nil {
+		return nil, toStatus(s.logger, err)
+	}
+
+	result := &songlibraryv1.GetLyricsResponse{Verses: make([]*songlibraryv1.Verse, len(verses))}
+	for i, verse := range verses {
+		result.Verses[i] = &songlibraryv1.Verse{Index: int32(verse.Index), Content: verse.Content}
+	}
+	return result, nil
+}
+
+func (s *songLibraryServer) getSong(ctx context.Context, id int64) (*entities.SongData, error) {
+	songID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	songs, err := s.usecases.GetSongList.Execute(ctx, entities.SongFilterData{ID: &songID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(songs) == 0 {
+		return nil, fmt.Errorf("%w song %d", errs.ErrNotFound, songID)
+	}
+
+	return &songs[0], nil
+}
+
+func toSong(song entities.SongData) *songlibraryv1.Song {
+	result := &songlibraryv1.Song{
+		Id:       int64(song.ID),
+		Group:    song.Band,
+		Song:     song.Song,
+		Link:     song.Link,
+		Explicit: song.Explicit,
+	}
+	if !song.ReleaseDate.IsZero() {
+		result.ReleaseDate = song.ReleaseDate.Format(dateFormat)
+	}
+	return result
+}
+
+func parseID(id int64) (int, error) {
+	if id <= 0 {
+		return 0, fmt.Errorf("%w invalid song ID %d", errs.ErrInvalidInput, id)
+	}
+	return int(id), nil
+}
+
+func parseDate(field string, value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	date, err := time.Parse(dateFormat, *value)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s must be in %s format", errs.ErrInvalidInput, field, dateFormat)
+	}
+	return &date, nil
+}
+
+// Заданные строковые поля не должны быть пустыми, как и в REST API
+func checkNotEmpty(fields map[string]*string) error {
+	for name, value := range fields {
+		if value != nil && *value == "" {
+			return fmt.Errorf("%w %s must not be empty", errs.ErrInvalidInput, name)
+		}
+	}
+	return nil
+}
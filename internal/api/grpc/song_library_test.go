@@ -0,0 +1,184 @@
+package grpc_test
+
+import (
+	"context"
+	"em-library/config"
+	grpcapi "em-library/internal/api/grpc"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	songlibraryv1 "em-library/pkg/api/songlibrary/v1"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Запускает сервер в памяти и возвращает клиент к нему. Лимиты запросов в тестах не ограничивают.
+func setupSongLibraryClient(t *testing.T, useCases usecase.UseCases, authEnabled bool) songlibraryv1.SongLibraryClient {
+	t.Helper()
+
+	mockLogger := new(MockLogger)
+	for _, method := range []string{"Debug", "Info", "Warn", "Error", "DebugContext", "InfoContext", "WarnContext", "ErrorContext"} {
+		mockLogger.On(method, mock.Anything, mock.Anything).Maybe()
+	}
+
+	if useCases.CheckRateLimit == nil {
+		mockRateLimit := new(MockCheckRateLimitUseCase)
+		mockRateLimit.On("Execute", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		useCases.CheckRateLimit = mockRateLimit
+	}
+
+	cfg := &config.Config{
+		Logger: mockLogger,
+		Auth:   config.AuthConfig{Enabled: authEnabled},
+	}
+
+	server := grpcapi.NewServer(cfg, useCases)
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() { server.Shutdown(time.Second) })
+
+	conn, err := grpcgo.NewClient("passthrough:///bufconn",
+		grpcgo.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpcgo.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return songlibraryv1.NewSongLibraryClient(conn)
+}
+
+// Ошибки юзкейсов переводятся в коды gRPC, подробности внутренних ошибок клиенту не отдаются
+func TestSongLibraryServer_GetSong_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"not found", fmt.Errorf("%w song 1", errs.ErrNotFound), codes.NotFound, "not found"},
+		{"already exists", errs.ErrAlreadyExists, codes.AlreadyExists, "already exists"},
+		{"invalid input", fmt.Errorf("%w bad filter", errs.ErrInvalidInput), codes.InvalidArgument, "invalid input bad filter"},
+		{"unauthorized", errs.ErrUnauthorized, codes.Unauthenticated, "unauthorized"},
+		{"forbidden", errs.ErrForbidden, codes.PermissionDenied, "forbidden"},
+		{"service problem", errs.ErrServiceProblem{Err: errors.New("timeout")}, codes.Unavailable, "external service error"},
+		{"internal", errors.New("connection refused"), codes.Internal, "server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGetSongList := new(MockGetSongListUseCase)
+			songID := 1
+			mockGetSongList.On("Execute", mock.Anything, entities.SongFilterData{ID: &songID}).Return(nil, tt.err)
+
+			client := setupSongLibraryClient(t, usecase.UseCases{GetSongList: mockGetSongList}, false)
+
+			_, err := client.GetSong(context.Background(), &songlibraryv1.GetSongRequest{Id: 1})
+
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.message, status.Convert(err).Message())
+		})
+	}
+}
+
+// Каждая ошибка из errs.Known получает свой код, а не INTERNAL
+func TestSongLibraryServer_GetSong_KnownErrors(t *testing.T) {
+	for _, knownErr := range errs.Known() {
+		t.Run(knownErr.Error(), func(t *testing.T) {
+			mockGetSongList := new(MockGetSongListUseCase)
+			songID := 1
+			mockGetSongList.On("Execute", mock.Anything, entities.SongFilterData{ID: &songID}).Return(nil, knownErr)
+
+			client := setupSongLibraryClient(t, usecase.UseCases{GetSongList: mockGetSongList}, false)
+
+			_, err := client.GetSong(context.Background(), &songlibraryv1.GetSongRequest{Id: 1})
+
+			public, _ := errs.PublicOf(knownErr)
+			assert.NotEqual(t, codes.Internal, status.Code(err))
+			assert.Equal(t, public.Message, status.Convert(err).Message())
+		})
+	}
+}
+
+// Поток заканчивается, когда очередная страница оказывается пустой.
+// Следующая страница читается после последней отправленной песни.
+func TestSongLibraryServer_ListSongs_EndOfStream(t *testing.T) {
+	mockGetSongList := new(MockGetSongListUseCase)
+
+	releaseDate := time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	pageSize := 2
+	page := []entities.SongData{
+		{ID: 1, Band: "Muse", Song: "Knights of Cydonia", ReleaseDate: releaseDate},
+		{ID: 2, Band: "Muse", Song: "Supermassive Black Hole", ReleaseDate: releaseDate},
+	}
+
+	mockGetSongList.On("Execute", mock.Anything, entities.SongFilterData{Limit: &pageSize}).Return(page, nil).Once()
+	mockGetSongList.On("Execute", mock.Anything, entities.SongFilterData{
+		After: &entities.SongCursor{ReleaseDate: releaseDate, ID: 2},
+		Limit: &pageSize,
+	}).Return(nil, fmt.Errorf("%w songs not found", errs.ErrNotFound)).Once()
+
+	client := setupSongLibraryClient(t, usecase.UseCases{GetSongList: mockGetSongList}, false)
+
+	stream, err := client.ListSongs(context.Background(), &songlibraryv1.ListSongsRequest{PageSize: int32(pageSize)})
+	assert.NoError(t, err)
+
+	var ids []int64
+	for {
+		song, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+		ids = append(ids, song.Id)
+	}
+
+	assert.Equal(t, []int64{1, 2}, ids)
+	mockGetSongList.AssertExpectations(t)
+}
+
+func TestSongLibraryServer_ListSongs_InvalidPageSize(t *testing.T) {
+	mockGetSongList := new(MockGetSongListUseCase)
+
+	client := setupSongLibraryClient(t, usecase.UseCases{GetSongList: mockGetSongList}, false)
+
+	stream, err := client.ListSongs(context.Background(), &songlibraryv1.ListSongsRequest{PageSize: -1})
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockGetSongList.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestSongLibraryServer_GetLyrics_NegativeOffset(t *testing.T) {
+	mockGetLyrics := new(MockGetSongLyricsUseCase)
+
+	client := setupSongLibraryClient(t, usecase.UseCases{GetSongLyrics: mockGetLyrics}, false)
+
+	offset := int32(-1)
+	_, err := client.GetLyrics(context.Background(), &songlibraryv1.GetLyricsRequest{SongId: 1, Offset: &offset})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "invalid input offset must not be negative", status.Convert(err).Message())
+	mockGetLyrics.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	logger   config.Logger
+	usecases usecase.UseCases
+}
+
+func NewAdminHandler(l config.Logger, u usecase.UseCases) *AdminHandler {
+	return &AdminHandler{
+		logger:   l,
+		usecases: u,
+	}
+}
+
+// GetConfig godoc
+// @Summary Текущая конфигурация
+// @Description Возвращает параметры, с которыми сейчас работает сервис, с указанием источника значения
+// @Description (default, file или env), и итог последней перезагрузки конфигурации по SIGHUP или изменению файла.
+// @Description Пароли и секреты скрыты.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} entities.ConfigState "Конфигурация"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /admin/config [get]
+func (h *AdminHandler) GetConfig(c *gin.Context) {
+	state, err := h.usecases.GetConfig.Execute(c.Request.Context())
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		h.logger.Error("Getting config failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
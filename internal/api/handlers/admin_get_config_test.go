@@ -0,0 +1,79 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupGetConfigRouter(mockLogger *MockLogger, mockUseCase *MockGetConfigUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		GetConfig: mockUseCase,
+	}
+
+	handler := handlers.NewAdminHandler(mockLogger, useCases)
+	r.GET("/admin/config", handler.GetConfig)
+	return r
+}
+
+func TestAdminHandler_GetConfig_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetConfigUseCase)
+
+	mockUseCase.On("Execute", mock.Anything).Return(&entities.ConfigState{
+		File: "config.yaml",
+		Settings: []entities.ConfigSetting{
+			{Key: "EMLIB_DB_PASSWORD", Value: "[REDACTED]", Source: "env"},
+			{Key: "EMLIB_LOG_LEVEL", Value: "debug", Source: "file"},
+		},
+		LastReload: &entities.ConfigReloadResult{
+			Trigger: "file",
+			Errors:  []string{`EMLIB_DB_PORT: must be an integer (got "abc")`},
+		},
+	}, nil)
+
+	router := setupGetConfigRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/config", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var state entities.ConfigState
+	err := json.Unmarshal(recorder.Body.Bytes(), &state)
+	assert.NoError(t, err)
+	assert.Len(t, state.Settings, 2)
+	assert.False(t, state.LastReload.Success)
+	assert.Len(t, state.LastReload.Errors, 1)
+}
+
+func TestAdminHandler_GetConfig_Forbidden(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetConfigUseCase)
+
+	mockLogger.On("Debug", "Permission denied", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything).Return(nil, fmt.Errorf("%w admin role required", errs.ErrForbidden))
+
+	router := setupGetConfigRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/config", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	mockUseCase.AssertExpectations(t)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	logger   config.Logger
+	usecases usecase.UseCases
+}
+
+func NewAuditHandler(l config.Logger, u usecase.UseCases) *AuditHandler {
+	return &AuditHandler{
+		logger:   l,
+		usecases: u,
+	}
+}
+
+type GetAuditLogParams struct {
+	Entity *string    `form:"entity" binding:"omitempty,oneof=song"`
+	ID     *int       `form:"id" binding:"omitempty,gt=0"`
+	Actor  *string    `form:"actor" binding:"omitempty,min=1"`
+	Action *string    `form:"action" binding:"omitempty,oneof=create update delete merge refresh"`
+	From   *time.Time `form:"from" binding:"omitempty" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     *time.Time `form:"to" binding:"omitempty" time_format:"2006-01-02T15:04:05Z07:00"`
+	Offset *int       `form:"offset" binding:"omitempty,min=0"`
+	Limit  *int       `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// GetAuditLog godoc
+// @Summary Журнал аудита
+// @Description Возвращает записи о создании, изменении, слиянии и удалении песен, от новых к старым.
+// @Description В каждой записи указаны клиент, действие, состояние до и после изменения и ID запроса.
+// @Tags audit
+// @Produce json
+// @Param entity query string false "Тип сущности" Enums(song)
+// @Param id query int false "ID сущности"
+// @Param actor query string false "Клиент, например api_key:1 или subject из JWT"
+// @Param action query string false "Действие" Enums(create, update, delete, merge, refresh)
+// @Param from query string false "Записи не раньше (формат: 2006-01-02T15:04:05Z07:00)"
+// @Param to query string false "Записи раньше (формат: 2006-01-02T15:04:05Z07:00)"
+// @Param offset query int false "С какой записи выводить"
+// @Param limit query int false "Сколько записей выводить, не больше 500"
+// @Success 200 {array} entities.AuditRecordData "Записи журнала"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Записи не найдены"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	var params GetAuditLogParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	filter := entities.AuditFilterData{
+		Entity:   params.Entity,
+		EntityID: params.ID,
+		Actor:    params.Actor,
+		From:     params.From,
+		To:       params.To,
+		Offset:   params.Offset,
+		Limit:    params.Limit,
+	}
+	if params.Action != nil {
+		action := entities.AuditAction(*params.Action)
+		filter.Action = &action
+	}
+
+	records, err := h.usecases.GetAuditLog.Execute(c.Request.Context(), filter)
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("No audit records found", "error", err)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
+		h.logger.Error("Getting audit log failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Audit log retrieved successfully", "count", len(records))
+	c.JSON(http.StatusOK, records)
+}
@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupGetAuditLogRouter(mockLogger *MockLogger, mockUseCase *MockGetAuditLogUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		GetAuditLog: mockUseCase,
+	}
+
+	handler := handlers.NewAuditHandler(mockLogger, useCases)
+	r.GET("/audit", handler.GetAuditLog)
+	return r
+}
+
+// Фильтры из query передаются в юзкейс
+func TestAuditHandler_GetAuditLog_Filters(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetAuditLogUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	expected := []entities.AuditRecordData{
+		{
+			ID:        7,
+			Actor:     "api_key:1",
+			Action:    entities.AuditDelete,
+			Entity:    entities.AuditEntitySong,
+			EntityID:  5,
+			Before:    json.RawMessage(`{"id":5}`),
+			RequestID: "req-1",
+		},
+	}
+	mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(filter entities.AuditFilterData) bool {
+		return *filter.EntityID == 5 &&
+			*filter.Action == entities.AuditDelete &&
+			filter.From.Equal(from) &&
+			*filter.Limit == 10 &&
+			filter.Actor == nil
+	})).Return(expected, nil)
+
+	router := setupGetAuditLogRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/audit?entity=song&id=5&action=delete&from=2025-03-01T00:00:00Z&limit=10", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response []map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "delete", response[0]["action"])
+	assert.Equal(t, map[string]any{"id": float64(5)}, response[0]["before"])
+	assert.Nil(t, response[0]["after"])
+
+	mockUseCase.AssertExpectations(t)
+}
+
+// Неизвестное действие
+func TestAuditHandler_GetAuditLog_InvalidAction(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetAuditLogUseCase)
+
+	mockLogger.On("Debug", "Failed parsing request params", mock.Anything).Once()
+
+	router := setupGetAuditLogRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/audit?action=rename", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockUseCase.AssertNotCalled(t, "Execute")
+}
+
+// Журнал доступен только admin
+func TestAuditHandler_GetAuditLog_Forbidden(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetAuditLogUseCase)
+
+	mockLogger.On("Debug", "Permission denied", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w admin role required", errs.ErrForbidden))
+
+	router := setupGetAuditLogRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/audit", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	mockUseCase.AssertExpectations(t)
+}
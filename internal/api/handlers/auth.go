@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var UnauthorizedResponse = ErrorResponse{Error: "unauthorized"}
+var ForbiddenResponse = ErrorResponse{Error: "forbidden"}
+
+// Пропускает дальше только запросы с действующим API-ключом (X-API-Key или
+// Authorization: Bearer emlib_...) или JWT (Authorization: Bearer).
+// Аутентифицированный клиент кладётся в контекст запроса.
+func NewAuthMiddleware(l config.Logger, u usecase.UseCases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credentials := entities.Credentials{
+			APIKey: c.GetHeader("X-API-Key"),
+		}
+
+		if header := c.GetHeader("Authorization"); header != "" {
+			scheme, token, found := strings.Cut(header, " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") {
+				l.Debug("Unsupported authorization scheme", "scheme", scheme)
+				unauthorized(c)
+				return
+			}
+			credentials.Bearer = strings.TrimSpace(token)
+		}
+
+		principal, err := u.Authenticate.Execute(c.Request.Context(), credentials)
+		if err != nil {
+			if errors.Is(err, errs.ErrUnauthorized) {
+				l.Debug("Authentication failed", "error", err, "path", c.Request.URL.Path)
+				unauthorized(c)
+				return
+			}
+
+			l.Error("Authentication error", "error", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ServerErrorResponse)
+			return
+		}
+
+		l.Debug("Request authenticated", "subject", principal.Subject, "method", principal.Method)
+		c.Request = c.Request.WithContext(entities.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+func unauthorized(c *gin.Context) {
+	c.Header("WWW-Authenticate", `Bearer realm="em-library"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, UnauthorizedResponse)
+}
+
+// Подставляет анонимного клиента, когда аутентификация выключена,
+// чтобы проверки прав в юзкейсах проходили.
+func NewAnonymousMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(entities.ContextWithPrincipal(c.Request.Context(), entities.AnonymousPrincipal))
+		c.Next()
+	}
+}
+
+// Отвечает 401 или 403, если юзкейс отказал клиенту в доступе.
+// Для остальных ошибок возвращает false, и их обрабатывает сам хэндлер.
+// Ошибка юзкейса прикладывается к запросу, по ней строится ответ /api/v2.
+func respondAccessDenied(c *gin.Context, l config.Logger, err error) bool {
+	_ = c.Error(err)
+
+	switch {
+	case errors.Is(err, errs.ErrUnauthorized):
+		l.Debug("Request is not authenticated", "error", err)
+		unauthorized(c)
+	case errors.Is(err, errs.ErrForbidden):
+		l.Debug("Permission denied", "error", err)
+		c.AbortWithStatusJSON(http.StatusForbidden, ForbiddenResponse)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAuthRouter(mockLogger *MockLogger, mockUseCase *MockAuthenticateUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		Authenticate: mockUseCase,
+	}
+
+	r.Use(handlers.NewAuthMiddleware(mockLogger, useCases))
+	r.GET("/whoami", func(c *gin.Context) {
+		principal, ok := entities.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.String(http.StatusOK, principal.Subject)
+	})
+	return r
+}
+
+// Ключ из X-API-Key, клиент доступен обработчику через контекст
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockAuthenticateUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockUseCase.On("Execute", mock.Anything, entities.Credentials{APIKey: "emlib_key"}).
+		Return(&entities.Principal{Subject: "api_key:1", Method: entities.AuthAPIKey}, nil)
+
+	router := setupAuthRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/whoami", nil)
+	req.Header.Set("X-API-Key", "emlib_key")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "api_key:1", recorder.Body.String())
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestAuthMiddleware_Bearer(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockAuthenticateUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockUseCase.On("Execute", mock.Anything, entities.Credentials{Bearer: "a.b.c"}).
+		Return(&entities.Principal{Subject: "user-1", Method: entities.AuthJWT}, nil)
+
+	router := setupAuthRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer a.b.c")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "user-1", recorder.Body.String())
+}
+
+// Без учётных данных запрос не доходит до обработчика
+func TestAuthMiddleware_Unauthorized(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockAuthenticateUseCase)
+
+	mockLogger.On("Debug", "Authentication failed", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, entities.Credentials{}).Return(nil, errs.ErrUnauthorized)
+
+	router := setupAuthRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/whoami", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("WWW-Authenticate"), "Bearer")
+	assert.JSONEq(t, `{"errors":"unauthorized"}`, recorder.Body.String())
+}
+
+// Схемы кроме Bearer не поддерживаются
+func TestAuthMiddleware_UnsupportedScheme(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockAuthenticateUseCase)
+
+	mockLogger.On("Debug", "Unsupported authorization scheme", mock.Anything).Once()
+
+	router := setupAuthRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/whoami", nil)
+	req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	mockUseCase.AssertNotCalled(t, "Execute")
+}
+
+// Ошибка хранилища ключей — это не отказ в доступе
+func TestAuthMiddleware_InternalError(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockAuthenticateUseCase)
+
+	mockLogger.On("Error", "Authentication error", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, entities.Credentials{APIKey: "emlib_key"}).Return(nil, errors.New("db down"))
+
+	router := setupAuthRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/whoami", nil)
+	req.Header.Set("X-API-Key", "emlib_key")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChangesHandler struct {
+	logger   config.Logger
+	usecases usecase.UseCases
+}
+
+func NewChangesHandler(l config.Logger, u usecase.UseCases) *ChangesHandler {
+	return &ChangesHandler{
+		logger:   l,
+		usecases: u,
+	}
+}
+
+type GetChangesParams struct {
+	Since int64 `form:"since" binding:"omitempty,min=0"`
+	Limit *int  `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Wait  int   `form:"wait" binding:"omitempty,min=0,max=60"`
+}
+
+const defaultChangesLimit = 100
+
+// GetChanges godoc
+// @Summary Лента изменений
+// @Description Возвращает события об изменениях песен с номером больше since в порядке номеров: song.created, song.updated,
+// @Description song.deleted и lyrics.updated. Номер события растёт монотонно, и события видны строго в порядке номеров,
+// @Description поэтому, передавая next из ответа в since следующего запроса, клиент получит все изменения без пропусков и повторов.
+// @Description Удаление приходит tombstone-событием song.deleted с deleted=true и последним состоянием песни.
+// @Description С параметром wait запрос работает как long-poll: если новых событий нет, ответ ждёт их до wait секунд
+// @Description и возвращает пустой список с прежним next, если они так и не появились.
+// @Tags changes
+// @Produce json
+// @Param since query int false "Номер последнего полученного события, по умолчанию 0 — с начала"
+// @Param limit query int false "Сколько событий вернуть, не больше 1000 (по умолчанию 100)"
+// @Param wait query int false "Сколько секунд ждать новых событий, не больше 60 (по умолчанию 0 — не ждать)"
+// @Success 200 {object} entities.ChangesData "События и номер для следующего запроса"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль viewer"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /changes [get]
+func (h *ChangesHandler) GetChanges(c *gin.Context) {
+	var params GetChangesParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	limit := defaultChangesLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	wait := time.Duration(params.Wait) * time.Second
+
+	// ожидание может быть дольше таймаута записи ответа сервера
+	if wait > 0 {
+		if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(wait + 10*time.Second)); err != nil {
+			h.logger.Warn("Failed extending write deadline, long poll may be cut off", "wait", wait, "error", err)
+		}
+	}
+
+	changes, err := h.usecases.GetChanges.Execute(c.Request.Context(), entities.ChangesFilterData{
+		Since: params.Since,
+		Limit: limit,
+		Wait:  wait,
+	})
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		h.logger.Error("Getting changes failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Changes retrieved successfully", "since", params.Since, "count", len(changes.Changes))
+	c.JSON(http.StatusOK, changes)
+}
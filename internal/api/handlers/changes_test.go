@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupGetChangesRouter(mockLogger *MockLogger, mockUseCase *MockGetChangesUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		GetChanges: mockUseCase,
+	}
+
+	handler := handlers.NewChangesHandler(mockLogger, useCases)
+	r.GET("/changes", handler.GetChanges)
+	return r
+}
+
+// Без limit берётся лимит по умолчанию, wait передаётся в секундах.
+// ResponseRecorder не умеет продлевать таймаут записи, и это попадает в лог.
+func TestChangesHandler_GetChanges_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetChangesUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", "Failed extending write deadline, long poll may be cut off", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, entities.ChangesFilterData{Since: 41, Limit: 100, Wait: 30 * time.Second}).
+		Return(&entities.ChangesData{
+			Changes: []entities.EventData{
+				{ID: 42, Type: entities.EventSongDeleted, SongID: 7, Deleted: true, Data: json.RawMessage(`{"id":7}`)},
+			},
+			Next: 42,
+		}, nil)
+
+	router := setupGetChangesRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/changes?since=41&wait=30", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response struct {
+		Changes []map[string]any `json:"changes"`
+		Next    int64            `json:"next"`
+	}
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), response.Next)
+	assert.Len(t, response.Changes, 1)
+	assert.Equal(t, true, response.Changes[0]["deleted"])
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestChangesHandler_GetChanges_WaitTooLong(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetChangesUseCase)
+
+	mockLogger.On("Debug", "Failed parsing request params", mock.Anything).Once()
+
+	router := setupGetChangesRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/changes?wait=600", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockUseCase.AssertNotCalled(t, "Execute")
+}
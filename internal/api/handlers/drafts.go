@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DraftsHandler struct {
+	logger   config.Logger
+	usecases usecase.UseCases
+}
+
+func NewDraftsHandler(l config.Logger, u usecase.UseCases) *DraftsHandler {
+	return &DraftsHandler{
+		logger:   l,
+		usecases: u,
+	}
+}
+
+type GetDraftsParams struct {
+	Offset *int `form:"offset" binding:"omitempty,min=0"`
+	Limit  *int `form:"limit" binding:"omitempty,min=1"`
+}
+
+// GetDrafts godoc
+// @Summary Получение черновиков
+// @Description Возвращает черновики изменений, сохранённые при обновлении из внешнего сервиса с политикой draft, начиная с новых.
+// @Description У песни хранится только последний черновик. Пустые поля черновика внешний сервис менять не предлагал.
+// @Tags songs
+// @Produce json
+// @Param offset query int false "С какого черновика выводить"
+// @Param limit query int false "Сколько черновиков выводить"
+// @Success 200 {array} entities.SongDraftData "Черновики"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль viewer"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Черновики не найдены"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /songs/drafts [get]
+func (h *DraftsHandler) GetDrafts(c *gin.Context) {
+	var params GetDraftsParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	drafts, err := h.usecases.GetDrafts.Execute(c.Request.Context(), entities.DraftFilterData{
+		Offset: params.Offset,
+		Limit:  params.Limit,
+	})
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("No drafts found", "error", err)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
+		h.logger.Error("Getting drafts failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Drafts retrieved successfully", "count", len(drafts))
+	c.JSON(http.StatusOK, drafts)
+}
+
+// GetDraft godoc
+// @Summary Получение черновика песни
+// @Description Возвращает черновик изменений песни, сохранённый при обновлении из внешнего сервиса с политикой draft
+// @Tags songs
+// @Produce json
+// @Param id path int true "ID песни"
+// @Success 200 {object} entities.SongDraftData "Черновик"
+// @Failure 400 {object} ErrorResponse "Неверный формат ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль viewer"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Черновик не найден"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /song/{id}/draft [get]
+func (h *DraftsHandler) GetDraft(c *gin.Context) {
+	songIDParam := c.Param("id")
+	songID, err := strconv.Atoi(songIDParam)
+
+	if err != nil {
+		h.logger.Debug("Missing or invalid ID param for request", "ID param", songIDParam)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "song ID is required"})
+		return
+	}
+
+	drafts, err := h.usecases.GetDrafts.Execute(c.Request.Context(), entities.DraftFilterData{SongID: &songID})
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("Draft not found", "ID", songID)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
+		h.logger.Error("Getting draft failed", "ID", songID, "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, drafts[0])
+}
+
+// AcceptDraft godoc
+// @Summary Принятие черновика
+// @Description Применяет к песне черновик изменений в одной транзакции и удаляет его.
+// @Description Изменение попадает в журнал аудита и ленту событий как обновление из внешнего сервиса.
+// @Tags songs
+// @Produce json
+// @Param id path int true "ID песни"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 200 {object} entities.SongData "Данные обновлённой песни"
+// @Failure 400 {object} ErrorResponse "Неверный формат ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль editor"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Черновик не найден"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "editor"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /song/{id}/draft/accept [post]
+func (h *DraftsHandler) AcceptDraft(c *gin.Context) {
+	songIDParam := c.Param("id")
+	songID, err := strconv.Atoi(songIDParam)
+
+	if err != nil {
+		h.logger.Debug("Missing or invalid ID param for request", "ID param", songIDParam)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "song ID is required"})
+		return
+	}
+
+	song, err := h.usecases.AcceptDraft.Execute(c.Request.Context(), songID)
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("Draft not found", "ID", songID)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
+		h.logger.Error("Accepting draft failed", "ID", songID, "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Draft accepted successfully", "ID", songID)
+	c.JSON(http.StatusOK, song)
+}
+
+// RejectDraft godoc
+// @Summary Отклонение черновика
+// @Description Удаляет черновик изменений, не меняя песню
+// @Tags songs
+// @Param id path int true "ID песни"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 204 "Черновик удалён"
+// @Failure 400 {object} ErrorResponse "Неверный формат ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль editor"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Черновик не найден"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "editor"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /song/{id}/draft [delete]
+func (h *DraftsHandler) RejectDraft(c *gin.Context) {
+	songIDParam := c.Param("id")
+	songID, err := strconv.Atoi(songIDParam)
+
+	if err != nil {
+		h.logger.Debug("Missing or invalid ID param for request", "ID param", songIDParam)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "song ID is required"})
+		return
+	}
+
+	err = h.usecases.RejectDraft.Execute(c.Request.Context(), songID)
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("Draft not found", "ID", songID)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
+		h.logger.Error("Rejecting draft failed", "ID", songID, "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Draft rejected", "ID", songID)
+	c.Status(http.StatusNoContent)
+}
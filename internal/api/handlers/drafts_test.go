@@ -0,0 +1,101 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupDraftsRouter(mockLogger *MockLogger, mockGet *MockGetDraftsUseCase, mockAccept *MockAcceptDraftUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		GetDrafts:   mockGet,
+		AcceptDraft: mockAccept,
+	}
+
+	handler := handlers.NewDraftsHandler(mockLogger, useCases)
+	r.GET("/song/:id/draft", handler.GetDraft)
+	r.POST("/song/:id/draft/accept", handler.AcceptDraft)
+	return r
+}
+
+// Дата релиза черновика отдаётся в том же формате, что и у песни
+func TestDraftsHandler_GetDraft_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockGet := new(MockGetDraftsUseCase)
+
+	songID := 1
+	releaseDate := time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	mockGet.On("Execute", mock.Anything, entities.DraftFilterData{SongID: &songID}).
+		Return([]entities.SongDraftData{{SongID: songID, ReleaseDate: &releaseDate}}, nil)
+
+	router := setupDraftsRouter(mockLogger, mockGet, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/song/1/draft", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(songID), response["song_id"])
+	assert.Equal(t, "2006-07-16", response["release_date"])
+	assert.Nil(t, response["link"])
+	mockGet.AssertExpectations(t)
+}
+
+func TestDraftsHandler_AcceptDraft_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockAccept := new(MockAcceptDraftUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockAccept.On("Execute", mock.Anything, 1).
+		Return(&entities.SongData{ID: 1, Band: "Muse", Song: "Supermassive Black Hole", Link: "https://example.com"}, nil)
+
+	router := setupDraftsRouter(mockLogger, nil, mockAccept)
+
+	req, _ := http.NewRequest(http.MethodPost, "/song/1/draft/accept", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", response["link"])
+	mockAccept.AssertExpectations(t)
+}
+
+// У песни нет черновика
+func TestDraftsHandler_AcceptDraft_NotFound(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockAccept := new(MockAcceptDraftUseCase)
+
+	mockLogger.On("Debug", "Draft not found", mock.Anything).Once()
+	mockAccept.On("Execute", mock.Anything, 1).Return(nil, fmt.Errorf("%w song drafts not found", errs.ErrNotFound))
+
+	router := setupDraftsRouter(mockLogger, nil, mockAccept)
+
+	req, _ := http.NewRequest(http.MethodPost, "/song/1/draft/accept", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	mockLogger.AssertExpectations(t)
+}
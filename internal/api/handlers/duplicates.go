@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicatesHandler struct {
+	logger   config.Logger
+	usecases usecase.UseCases
+}
+
+func NewDuplicatesHandler(l config.Logger, u usecase.UseCases) *DuplicatesHandler {
+	return &DuplicatesHandler{
+		logger:   l,
+		usecases: u,
+	}
+}
+
+type GetDuplicatesParams struct {
+	MinScore *float64 `form:"min_score" binding:"omitempty,min=0,max=1"`
+	Offset   *int     `form:"offset" binding:"omitempty,min=0"`
+	Limit    *int     `form:"limit" binding:"omitempty,min=1"`
+}
+
+// GetDuplicates godoc
+// @Summary Получение возможных дубликатов
+// @Description Возвращает пары песен одной группы, похожие на дубликаты, по убыванию оценки.
+// @Description Оценка складывается из похожести нормализованных названий (без регистра, пунктуации и пометок вроде Remastered или Live) и похожести текстов.
+// @Description Список обновляется фоновой задачей поиска дубликатов.
+// @Tags songs
+// @Produce json
+// @Param min_score query number false "Минимальная оценка пары от 0 до 1"
+// @Param offset query int false "С какой пары выводить"
+// @Param limit query int false "Сколько пар выводить"
+// @Success 200 {array} entities.DuplicateData "Пары возможных дубликатов"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль viewer"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Дубликаты не найдены"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /songs/duplicates [get]
+func (h *DuplicatesHandler) GetDuplicates(c *gin.Context) {
+	var params GetDuplicatesParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	duplicates, err := h.usecases.GetDuplicates.Execute(c.Request.Context(), entities.DuplicateFilterData{
+		MinScore: params.MinScore,
+		Offset:   params.Offset,
+		Limit:    params.Limit,
+	})
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("No duplicates found", "error", err)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
+		h.logger.Error("Getting duplicates failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Duplicates retrieved successfully", "count", len(duplicates))
+	c.JSON(http.StatusOK, duplicates)
+}
+
+type MergeSongsParams struct {
+	KeepID  int `json:"keep_id" binding:"required,gt=0"`
+	MergeID int `json:"merge_id" binding:"required,gt=0"`
+}
+
+// MergeSongs godoc
+// @Summary Слияние дубликатов
+// @Description Сливает песню merge_id в песню keep_id в одной транзакции: пустые дата релиза, ссылка и текст
+// @Description оставляемой песни заполняются данными дубликата, после чего дубликат удаляется.
+// @Tags songs
+// @Accept json
+// @Produce json
+// @Param merge body MergeSongsParams true "ID оставляемой песни и дубликата"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 200 {object} entities.SongData "Данные оставленной песни"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Песня не найдена"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /songs/merge [post]
+func (h *DuplicatesHandler) MergeSongs(c *gin.Context) {
+	var params MergeSongsParams
+
+	if err := c.ShouldBindJSON(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	song, err := h.usecases.MergeSongs.Execute(c.Request.Context(), entities.MergeSongsData{
+		KeepID:  params.KeepID,
+		MergeID: params.MergeID,
+	})
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, errs.ErrInvalidInput):
+			h.logger.Debug("Invalid merge request", "error", err)
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, errs.ErrNotFound):
+			h.logger.Debug("Song for merge not found", "error", err)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+		default:
+			h.logger.Error("Merging songs failed", "error", err)
+			c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		}
+		return
+	}
+
+	h.logger.Info("Songs merged successfully", "keep_id", params.KeepID, "merge_id", params.MergeID)
+	c.JSON(http.StatusOK, song)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+type EventsHandler struct {
+	logger    config.Logger
+	usecases  usecase.UseCases
+	heartbeat time.Duration
+}
+
+func NewEventsHandler(l config.Logger, u usecase.UseCases, heartbeat time.Duration) *EventsHandler {
+	return &EventsHandler{
+		logger:    l,
+		usecases:  u,
+		heartbeat: heartbeat,
+	}
+}
+
+type StreamEventsParams struct {
+	SongID *int    `form:"song_id" binding:"omitempty,min=1"`
+	Band   *string `form:"band"`
+}
+
+// StreamEvents godoc
+// @Summary Поток событий
+// @Description Server-Sent Events с изменениями песен: song.created, song.updated, song.deleted и lyrics.updated.
+// @Description В id каждого события — его номер в ленте изменений, в event — тип, в data — событие целиком, как в GET /changes.
+// @Description Поток можно ограничить одной песней или группой. Раз в несколько секунд приходит событие ping без данных.
+// @Description При переподключении с заголовком Last-Event-ID поток сначала отдаёт события, пропущенные после этого номера,
+// @Description без заголовка — начинается с новых событий. Если клиент не успевает читать события, сервер закрывает поток,
+// @Description и клиент должен переподключиться с Last-Event-ID.
+// @Tags changes
+// @Produce text/event-stream
+// @Param song_id query int false "Только события песни с этим ID"
+// @Param band query string false "Только события песен этой группы"
+// @Param Last-Event-ID header int false "Номер последнего полученного события"
+// @Success 200 {object} entities.EventData "Поток событий"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль viewer"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /events/stream [get]
+func (h *EventsHandler) StreamEvents(c *gin.Context) {
+	var params StreamEventsParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	filter := entities.EventStreamFilterData{
+		SongID: params.SongID,
+		Band:   params.Band,
+	}
+
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		lastEventID, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || lastEventID < 0 {
+			h.logger.Debug("Invalid Last-Event-ID", "value", header)
+			c.JSON(http.StatusBadRequest, InvalidRequestResponse)
+			return
+		}
+		filter.LastEventID = &lastEventID
+	}
+
+	stream, err := h.usecases.StreamEvents.Execute(c.Request.Context(), filter)
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		h.logger.Error("Opening event stream failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	// поток живёт дольше таймаута записи ответа сервера
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Warn("Failed lifting write deadline, event stream may be cut off", "error", err)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	h.logger.Info("Event stream opened")
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	// Events закрывается и после отключения клиента, поэтому цикл завершается вместе с потоком
+	for {
+		select {
+		case e, ok := <-stream.Events:
+			if !ok {
+				if err := stream.Err(); err != nil {
+					h.logger.Warn("Event stream closed", "error", err)
+				} else {
+					h.logger.Info("Event stream closed")
+				}
+				return
+			}
+
+			c.Render(-1, sse.Event{
+				Id:    strconv.FormatInt(e.ID, 10),
+				Event: string(e.Type),
+				Data:  e,
+			})
+		case <-heartbeat.C:
+			c.Render(-1, sse.Event{Event: "ping", Data: ""})
+		}
+
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,94 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupStreamEventsRouter(mockLogger *MockLogger, mockUseCase *MockStreamEventsUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		StreamEvents: mockUseCase,
+	}
+
+	handler := handlers.NewEventsHandler(mockLogger, useCases, time.Minute)
+	r.GET("/events/stream", handler.StreamEvents)
+	return r
+}
+
+// Last-Event-ID и фильтры передаются в юзкейс, события пишутся в формате SSE до закрытия потока.
+// ResponseRecorder не умеет снимать таймаут записи, и это попадает в лог.
+func TestEventsHandler_StreamEvents_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockStreamEventsUseCase)
+
+	events := make(chan entities.EventData, 1)
+	events <- entities.EventData{ID: 42, Type: entities.EventSongUpdated, SongID: 7, Data: json.RawMessage(`{"id":7}`)}
+	close(events)
+
+	songID := 7
+	lastEventID := int64(41)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", "Failed lifting write deadline, event stream may be cut off", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, entities.EventStreamFilterData{SongID: &songID, LastEventID: &lastEventID}).
+		Return(&entities.EventStream{Events: events, Err: func() error { return nil }}, nil)
+
+	router := setupStreamEventsRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/events/stream?song_id=7", nil)
+	req.Header.Set("Last-Event-ID", "41")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/event-stream")
+	assert.Contains(t, recorder.Body.String(), "id:42\nevent:song.updated\ndata:{\"id\":42,")
+	mockUseCase.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestEventsHandler_StreamEvents_InvalidLastEventID(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockStreamEventsUseCase)
+
+	mockLogger.On("Debug", "Invalid Last-Event-ID", mock.Anything).Once()
+
+	router := setupStreamEventsRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/events/stream", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockUseCase.AssertNotCalled(t, "Execute")
+}
+
+func TestEventsHandler_StreamEvents_Forbidden(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockStreamEventsUseCase)
+
+	mockLogger.On("Debug", "Permission denied", mock.Anything)
+	mockUseCase.On("Execute", mock.Anything, entities.EventStreamFilterData{}).Return(nil, errs.ErrForbidden)
+
+	router := setupStreamEventsRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/events/stream", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/api/graphql"
+	"em-library/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GraphQLHandler struct {
+	logger config.Logger
+	server *graphql.Server
+}
+
+func NewGraphQLHandler(l config.Logger, u usecase.UseCases) *GraphQLHandler {
+	return &GraphQLHandler{
+		logger: l,
+		server: graphql.NewServer(l, u),
+	}
+}
+
+type GraphQLRequest struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type GraphQLResponse struct {
+	Data   map[string]any   `json:"data,omitempty"`
+	Errors []map[string]any `json:"errors,omitempty"`
+}
+
+// Query godoc
+// @Summary GraphQL запрос
+// @Description Выполняет GraphQL запрос или мутацию. Схема доступна по GET /graphql/schema и через интроспекцию.
+// @Description Резолверы вызывают те же юзкейсы, что и REST API, поэтому роли, проверки и транзакции у них общие:
+// @Description чтение требует роль viewer, createSong и updateSong — editor, deleteSong — admin.
+// @Description Ошибки возвращаются в errors со статусом 200, вид ошибки — в extensions.code:
+// @Description UNAUTHENTICATED, FORBIDDEN, NOT_FOUND, CONFLICT, BAD_USER_INPUT, BAD_GATEWAY или INTERNAL_SERVER_ERROR.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body GraphQLRequest true "Запрос, имя операции и переменные"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 200 {object} GraphQLResponse "Результат запроса и ошибки резолверов"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var params GraphQLRequest
+
+	if err := c.ShouldBindJSON(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response := h.server.Exec(c.Request.Context(), params.Query, params.OperationName, params.Variables)
+
+	h.logger.Info("GraphQL request executed", "operation", params.OperationName, "errors", len(response.Errors))
+	c.JSON(http.StatusOK, response)
+}
+
+// Schema godoc
+// @Summary GraphQL схема
+// @Description Возвращает схему GraphQL API в SDL для генерации клиентов
+// @Tags graphql
+// @Produce plain
+// @Success 200 {string} string "Схема в SDL"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /graphql/schema [get]
+func (h *GraphQLHandler) Schema(c *gin.Context) {
+	c.String(http.StatusOK, h.server.Schema())
+}
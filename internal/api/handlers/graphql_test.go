@@ -0,0 +1,158 @@
+package handlers_test
+
+import (
+	"bytes"
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupGraphQLRouter(mockLogger *MockLogger, useCases usecase.UseCases) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	handler := handlers.NewGraphQLHandler(mockLogger, useCases)
+	r.POST("/graphql", handler.Query)
+	r.GET("/graphql/schema", handler.Schema)
+	return r
+}
+
+func graphQLRequest(t *testing.T, router *gin.Engine, query string, variables map[string]any) map[string]any {
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	req, _ := http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response
+}
+
+// Тексты всех песен списка загружаются одним вызовом юзкейса
+func TestGraphQLHandler_Query_SongsWithLyrics(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockSongList := new(MockGetSongListUseCase)
+	mockLyrics := new(MockGetSongsLyricsUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	band := "Muse"
+	limit := 2
+	mockSongList.On("Execute", mock.Anything, entities.SongFilterData{Band: &band, Limit: &limit}).Return([]entities.SongData{
+		{ID: 1, Band: "Muse", Song: "Uprising", ReleaseDate: time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Band: "Muse", Song: "Madness"},
+	}, nil)
+	offset := 0
+	mockLyrics.On("Execute", mock.Anything, mock.MatchedBy(func(ids []int) bool {
+		return slices.Equal(slices.Sorted(slices.Values(ids)), []int{1, 2})
+	}), entities.LyricsFilterData{Offset: &offset}).Return(map[int][]entities.LyricsVerseData{
+		1: {{Index: 0, Content: "Paranoia is in bloom"}},
+	}, nil).Once()
+
+	router := setupGraphQLRouter(mockLogger, usecase.UseCases{GetSongList: mockSongList, GetSongsLyrics: mockLyrics})
+
+	response := graphQLRequest(t, router, `query($group: String) {
+		songs(filter: {group: $group}, limit: 2) { id song releaseDate lyrics { index content } }
+	}`, map[string]any{"group": "Muse"})
+
+	assert.Nil(t, response["errors"])
+	songs := response["data"].(map[string]any)["songs"].([]any)
+	assert.Len(t, songs, 2)
+	assert.Equal(t, map[string]any{
+		"id":          "1",
+		"song":        "Uprising",
+		"releaseDate": "2009-09-07",
+		"lyrics":      []any{map[string]any{"index": float64(0), "content": "Paranoia is in bloom"}},
+	}, songs[0])
+	assert.Nil(t, songs[1].(map[string]any)["lyrics"])
+	assert.Nil(t, songs[1].(map[string]any)["releaseDate"])
+	mockLyrics.AssertExpectations(t)
+}
+
+// Ошибки юзкейсов приходят в errors с кодом в extensions
+func TestGraphQLHandler_Query_MutationForbidden(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockDelete := new(MockDeleteSongUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Debug", "Permission denied", mock.Anything)
+	mockDelete.On("Execute", mock.Anything, 7).Return(errs.ErrForbidden)
+
+	router := setupGraphQLRouter(mockLogger, usecase.UseCases{DeleteSong: mockDelete})
+
+	response := graphQLRequest(t, router, `mutation { deleteSong(id: "7") }`, nil)
+
+	errors := response["errors"].([]any)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "forbidden", errors[0].(map[string]any)["message"])
+	assert.Equal(t, map[string]any{"code": "FORBIDDEN"}, errors[0].(map[string]any)["extensions"])
+}
+
+// Каждая ошибка из errs.Known получает свой код, а не INTERNAL_SERVER_ERROR
+func TestGraphQLHandler_Query_KnownErrors(t *testing.T) {
+	for _, knownErr := range errs.Known() {
+		t.Run(knownErr.Error(), func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockDelete := new(MockDeleteSongUseCase)
+
+			for _, method := range []string{"Debug", "Info", "Error"} {
+				mockLogger.On(method, mock.Anything, mock.Anything).Maybe()
+			}
+			mockDelete.On("Execute", mock.Anything, 7).Return(knownErr)
+
+			router := setupGraphQLRouter(mockLogger, usecase.UseCases{DeleteSong: mockDelete})
+
+			response := graphQLRequest(t, router, `mutation { deleteSong(id: "7") }`, nil)
+
+			public, _ := errs.PublicOf(knownErr)
+			errors := response["errors"].([]any)
+			assert.Len(t, errors, 1)
+			assert.Equal(t, public.Message, errors[0].(map[string]any)["message"])
+			assert.NotEqual(t, "INTERNAL_SERVER_ERROR", errors[0].(map[string]any)["extensions"].(map[string]any)["code"])
+		})
+	}
+}
+
+// Неверная дата не доходит до юзкейса
+func TestGraphQLHandler_Query_InvalidInput(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockCreate := new(MockCreateSongUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	router := setupGraphQLRouter(mockLogger, usecase.UseCases{CreateSong: mockCreate})
+
+	response := graphQLRequest(t, router, `mutation {
+		createSong(input: {group: "Muse", song: "Uprising", releaseDate: "07.09.2009"}) { id }
+	}`, nil)
+
+	errors := response["errors"].([]any)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, map[string]any{"code": "BAD_USER_INPUT"}, errors[0].(map[string]any)["extensions"])
+	mockCreate.AssertNotCalled(t, "Execute")
+}
+
+func TestGraphQLHandler_Schema(t *testing.T) {
+	router := setupGraphQLRouter(new(MockLogger), usecase.UseCases{})
+
+	req, _ := http.NewRequest(http.MethodGet, "/graphql/schema", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "type Song {")
+}
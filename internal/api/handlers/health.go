@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	logger   config.Logger
+	usecases usecase.UseCases
+	draining atomic.Bool
+}
+
+func NewHealthHandler(l config.Logger, u usecase.UseCases) *HealthHandler {
+	return &HealthHandler{
+		logger:   l,
+		usecases: u,
+	}
+}
+
+// После вызова readiness отвечает 503, чтобы на останавливающийся сервер
+// перестали направлять новые запросы.
+func (h *HealthHandler) SetDraining() {
+	h.draining.Store(true)
+}
+
+// GetLive godoc
+// @Summary Liveness
+// @Description Отвечает 200, пока процесс работает. Зависимости не проверяются.
+// @Tags health
+// @Produce json
+// @Success 200 {object} entities.HealthReport "Процесс работает"
+// @Router /health/live [get]
+func (h *HealthHandler) GetLive(c *gin.Context) {
+	c.JSON(http.StatusOK, entities.HealthReport{Status: entities.HealthOK})
+}
+
+// GetReady godoc
+// @Summary Readiness
+// @Description Проверяет подключение к базе, применённые миграции и, если включено, сервис с данными песен.
+// @Description Возвращает состояние и время ответа каждой зависимости. Если недоступна только некритичная
+// @Description зависимость, статус degraded и код 200. Во время остановки сервера всегда 503.
+// @Tags health
+// @Produce json
+// @Success 200 {object} entities.HealthReport "Сервис готов принимать запросы"
+// @Failure 503 {object} entities.HealthReport "Критичная зависимость недоступна или сервер останавливается"
+// @Router /health/ready [get]
+func (h *HealthHandler) GetReady(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, entities.HealthReport{
+			Status:  entities.HealthFail,
+			Message: "shutting down",
+		})
+		return
+	}
+
+	report := h.usecases.CheckReadiness.Execute(c.Request.Context())
+
+	if report.Status == entities.HealthFail {
+		h.logger.Warn("Readiness check failed", "dependencies", report.Dependencies)
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
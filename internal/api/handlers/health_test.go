@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupHealthRouter(mockLogger *MockLogger, mockUseCase *MockCheckReadinessUseCase) (*gin.Engine, *handlers.HealthHandler) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		CheckReadiness: mockUseCase,
+	}
+
+	handler := handlers.NewHealthHandler(mockLogger, useCases)
+	r.GET("/health/live", handler.GetLive)
+	r.GET("/health/ready", handler.GetReady)
+	return r, handler
+}
+
+func TestHealthHandler_GetReady_OK(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockCheckReadinessUseCase)
+
+	mockUseCase.On("Execute", mock.Anything).Return(&entities.HealthReport{
+		Status: entities.HealthDegraded,
+		Dependencies: map[string]entities.DependencyHealth{
+			"postgres":    {Status: entities.HealthOK, Critical: true, LatencyMs: 2},
+			"infoservice": {Status: entities.HealthFail, LatencyMs: 500, Error: "timeout"},
+		},
+	})
+
+	router, _ := setupHealthRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/health/ready", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var report entities.HealthReport
+	err := json.Unmarshal(recorder.Body.Bytes(), &report)
+	assert.NoError(t, err)
+	assert.Equal(t, entities.HealthDegraded, report.Status)
+	assert.Equal(t, int64(2), report.Dependencies["postgres"].LatencyMs)
+	assert.Equal(t, "timeout", report.Dependencies["infoservice"].Error)
+}
+
+func TestHealthHandler_GetReady_Fail(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockCheckReadinessUseCase)
+
+	mockUseCase.On("Execute", mock.Anything).Return(&entities.HealthReport{
+		Status: entities.HealthFail,
+		Dependencies: map[string]entities.DependencyHealth{
+			"postgres": {Status: entities.HealthFail, Critical: true, Error: "connection refused"},
+		},
+	})
+	mockLogger.On("Warn", "Readiness check failed", mock.Anything).Return()
+
+	router, _ := setupHealthRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/health/ready", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	mockLogger.AssertExpectations(t)
+}
+
+// Во время остановки сервера readiness отвечает 503 без проверки зависимостей, а liveness — 200
+func TestHealthHandler_Draining(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockCheckReadinessUseCase)
+
+	router, handler := setupHealthRouter(mockLogger, mockUseCase)
+	handler.SetDraining()
+
+	req, _ := http.NewRequest(http.MethodGet, "/health/ready", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "shutting down")
+	mockUseCase.AssertNotCalled(t, "Execute", mock.Anything)
+
+	req, _ = http.NewRequest(http.MethodGet, "/health/live", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
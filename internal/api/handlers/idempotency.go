@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var IdempotencyKeyReusedResponse = ErrorResponse{Error: "idempotency key was used for a different request"}
+var RequestInProgressResponse = ErrorResponse{Error: "request with this idempotency key is in progress"}
+
+// Возвращает middleware, который выполняет изменяющий запрос с заголовком Idempotency-Key
+// не больше одного раза. Ответ сохраняется, и повтор запроса с тем же ключом получает его
+// с заголовком Idempotent-Replayed, не выполняясь заново. Тот же ключ с другим методом, путём,
+// строкой запроса или телом — 422, пока первый запрос выполняется — 409. Ответы 5xx не сохраняются,
+// чтобы запрос можно было повторить. Запросы без заголовка проходят как обычно.
+func NewIdempotencyMiddleware(l config.Logger, u usecase.UseCases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			l.Debug("Failed reading request body", "error", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		request := entities.IdempotentRequest{
+			Key:    key,
+			Method: c.Request.Method,
+			Path:   c.Request.URL.Path,
+			Query:  c.Request.URL.RawQuery,
+			Body:   body,
+		}
+
+		saved, err := u.StartIdempotentRequest.Execute(c.Request.Context(), request)
+		if err != nil {
+			switch {
+			case errors.Is(err, errs.ErrInvalidInput):
+				l.Debug("Invalid idempotency key", "error", err)
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			case errors.Is(err, errs.ErrIdempotencyKeyReused):
+				l.Debug("Idempotency key reused", "error", err, "path", c.Request.URL.Path)
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, IdempotencyKeyReusedResponse)
+			case errors.Is(err, errs.ErrRequestInProgress):
+				l.Debug("Request with idempotency key is in progress", "error", err, "path", c.Request.URL.Path)
+				c.AbortWithStatusJSON(http.StatusConflict, RequestInProgressResponse)
+			default:
+				// недоступное хранилище ключей не должно останавливать изменения
+				l.Error("Idempotency key check failed", "error", err)
+				c.Next()
+				return
+			}
+
+			_ = c.Error(err)
+			return
+		}
+
+		if saved != nil {
+			l.Debug("Replaying saved response", "path", c.Request.URL.Path, "status", saved.Status)
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(saved.Status, saved.ContentType, saved.Body)
+			c.Abort()
+			return
+		}
+
+		w := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		var response *entities.IdempotentResponse
+		if w.Status() < http.StatusInternalServerError {
+			response = &entities.IdempotentResponse{
+				Status:      w.Status(),
+				ContentType: w.Header().Get("Content-Type"),
+				Body:        w.body.Bytes(),
+			}
+		}
+
+		// ответ сохраняется, даже если клиент уже отключился и не дождался его
+		ctx := context.WithoutCancel(c.Request.Context())
+		if err := u.FinishIdempotentRequest.Execute(ctx, request, response); err != nil {
+			l.Error("Failed to save idempotent response", "error", err)
+		}
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// Копирует тело ответа, чтобы его можно было сохранить
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
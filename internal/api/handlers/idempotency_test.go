@@ -0,0 +1,210 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const idempotentBody = `{"group":"Muse","song":"Uprising"}`
+
+var idempotentRequest = entities.IdempotentRequest{
+	Key:    "3f1c9a52",
+	Method: http.MethodPost,
+	Path:   "/song",
+	Body:   []byte(idempotentBody),
+}
+
+// Хэндлер отвечает status и считает, сколько раз его вызвали
+func setupIdempotencyRouter(
+	mockLogger *MockLogger,
+	mockStart *MockStartIdempotentRequestUseCase,
+	mockFinish *MockFinishIdempotentRequestUseCase,
+	status int,
+	calls *int,
+) *gin.Engine {
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		StartIdempotentRequest:  mockStart,
+		FinishIdempotentRequest: mockFinish,
+	}
+
+	r.POST("/song", handlers.NewIdempotencyMiddleware(mockLogger, useCases), func(c *gin.Context) {
+		*calls++
+		c.JSON(status, map[string]int{"id": 1})
+	})
+	return r
+}
+
+func idempotentPost(router *gin.Engine, key string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/song", strings.NewReader(idempotentBody))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// Первый запрос выполняется, и его ответ сохраняется
+func TestIdempotencyMiddleware_FirstRequest(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockStart := new(MockStartIdempotentRequestUseCase)
+	mockFinish := new(MockFinishIdempotentRequestUseCase)
+
+	mockStart.On("Execute", mock.Anything, idempotentRequest).Return(nil, nil)
+	mockFinish.On("Execute", mock.Anything, idempotentRequest, &entities.IdempotentResponse{
+		Status:      http.StatusCreated,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"id":1}`),
+	}).Return(nil)
+
+	var calls int
+	router := setupIdempotencyRouter(mockLogger, mockStart, mockFinish, http.StatusCreated, &calls)
+
+	recorder := idempotentPost(router, idempotentRequest.Key)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, `{"id":1}`, recorder.Body.String())
+	assert.Empty(t, recorder.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 1, calls)
+	mockStart.AssertExpectations(t)
+	mockFinish.AssertExpectations(t)
+}
+
+// Повтор получает сохранённый ответ, а хэндлер не вызывается
+func TestIdempotencyMiddleware_Replay(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockStart := new(MockStartIdempotentRequestUseCase)
+	mockFinish := new(MockFinishIdempotentRequestUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockStart.On("Execute", mock.Anything, idempotentRequest).Return(&entities.IdempotentResponse{
+		Status:      http.StatusCreated,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"id":7}`),
+	}, nil)
+
+	var calls int
+	router := setupIdempotencyRouter(mockLogger, mockStart, mockFinish, http.StatusCreated, &calls)
+
+	recorder := idempotentPost(router, idempotentRequest.Key)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, `{"id":7}`, recorder.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "true", recorder.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 0, calls)
+	mockFinish.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotencyMiddleware_Conflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"different request", fmt.Errorf("%w key", errs.ErrIdempotencyKeyReused), http.StatusUnprocessableEntity},
+		{"in progress", fmt.Errorf("%w key", errs.ErrRequestInProgress), http.StatusConflict},
+		{"invalid key", fmt.Errorf("%w key is too long", errs.ErrInvalidInput), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockStart := new(MockStartIdempotentRequestUseCase)
+			mockFinish := new(MockFinishIdempotentRequestUseCase)
+
+			mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+			mockStart.On("Execute", mock.Anything, idempotentRequest).Return(nil, tt.err)
+
+			var calls int
+			router := setupIdempotencyRouter(mockLogger, mockStart, mockFinish, http.StatusCreated, &calls)
+
+			recorder := idempotentPost(router, idempotentRequest.Key)
+
+			assert.Equal(t, tt.expected, recorder.Code)
+			assert.Equal(t, 0, calls)
+			mockFinish.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Строка запроса входит в запрос, поэтому тот же ключ с другими параметрами получает 422
+func TestIdempotencyMiddleware_QueryString(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockStart := new(MockStartIdempotentRequestUseCase)
+	mockFinish := new(MockFinishIdempotentRequestUseCase)
+
+	withQuery := idempotentRequest
+	withQuery.Query = "policy=draft"
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockStart.On("Execute", mock.Anything, withQuery).Return(nil, fmt.Errorf("%w key", errs.ErrIdempotencyKeyReused))
+
+	var calls int
+	router := setupIdempotencyRouter(mockLogger, mockStart, mockFinish, http.StatusCreated, &calls)
+
+	req, _ := http.NewRequest(http.MethodPost, "/song?policy=draft", strings.NewReader(idempotentBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotentRequest.Key)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Equal(t, 0, calls)
+	mockStart.AssertExpectations(t)
+}
+
+// Ответ 5xx не сохраняется, и запрос можно повторить с тем же ключом
+func TestIdempotencyMiddleware_ServerErrorReleasesKey(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockStart := new(MockStartIdempotentRequestUseCase)
+	mockFinish := new(MockFinishIdempotentRequestUseCase)
+
+	mockStart.On("Execute", mock.Anything, idempotentRequest).Return(nil, nil)
+	mockFinish.On("Execute", mock.Anything, idempotentRequest, (*entities.IdempotentResponse)(nil)).Return(nil)
+
+	var calls int
+	router := setupIdempotencyRouter(mockLogger, mockStart, mockFinish, http.StatusInternalServerError, &calls)
+
+	recorder := idempotentPost(router, idempotentRequest.Key)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, 1, calls)
+	mockFinish.AssertExpectations(t)
+}
+
+// Без заголовка и при недоступном хранилище ключей запрос выполняется как обычно
+func TestIdempotencyMiddleware_PassThrough(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockStart := new(MockStartIdempotentRequestUseCase)
+	mockFinish := new(MockFinishIdempotentRequestUseCase)
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockStart.On("Execute", mock.Anything, idempotentRequest).Return(nil, errors.New("connection refused"))
+
+	var calls int
+	router := setupIdempotencyRouter(mockLogger, mockStart, mockFinish, http.StatusCreated, &calls)
+
+	assert.Equal(t, http.StatusCreated, idempotentPost(router, "").Code)
+	assert.Equal(t, http.StatusCreated, idempotentPost(router, idempotentRequest.Key).Code)
+	assert.Equal(t, 2, calls)
+	mockStart.AssertNumberOfCalls(t, "Execute", 1)
+	mockFinish.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,6 +28,7 @@ func NewLyricsHandler(l config.Logger, u usecase.UseCases) *LyricsHandler {
 type GetLyricsParams struct {
 	Offset *int `form:"offset" binding:"omitempty,min=0"`
 	Limit  *int `form:"limit" binding:"omitempty,min=1"`
+	Censor bool `form:"censor"`
 }
 
 // GetLyrics godoc
@@ -38,10 +40,17 @@ type GetLyricsParams struct {
 // @Param id path int true "ID песни"
 // @Param offset query int false "С какого куплета начать"
 // @Param limit query int false "Сколько куплетов вывести для пагинации"
+// @Param censor query bool false "Замаскировать ненормативную лексику"
 // @Success 200 {array} entities.LyricsVerseData "Текст песни успешно получен"
 // @Failure 400 {object} ErrorResponse "Неверный запрос"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль viewer"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
 // @Failure 404 {object} ErrorResponse "Текст песни не найден"
 // @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /song/{id}/lyrics [get]
 func (h *LyricsHandler) GetLyrics(c *gin.Context) {
 	songIDParam := c.Param("id")
@@ -55,6 +64,7 @@ func (h *LyricsHandler) GetLyrics(c *gin.Context) {
 
 	var params GetLyricsParams
 	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
 		h.logger.Debug("Failed parsing request params", "error", err)
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 		return
@@ -63,9 +73,14 @@ func (h *LyricsHandler) GetLyrics(c *gin.Context) {
 	lyrics, err := h.usecases.GetSongLyrics.Execute(c.Request.Context(), songID, entities.LyricsFilterData{
 		Offset: params.Offset,
 		Limit:  params.Limit,
+		Censor: params.Censor,
 	})
 
 	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
 		if errors.Is(err, errs.ErrNotFound) {
 			h.logger.Debug("No lyrics found", "error", err)
 			c.JSON(http.StatusNotFound, NotFoundResponse)
@@ -80,3 +95,95 @@ func (h *LyricsHandler) GetLyrics(c *gin.Context) {
 	h.logger.Info("Song lyrics retrieved successfully", "song", songID)
 	c.JSON(http.StatusOK, lyrics)
 }
+
+type GetLyricsDiffParams struct {
+	Against string `form:"against" binding:"required"`
+}
+
+// GetLyricsDiff godoc
+// @Summary Сравнить текст песни
+// @Description Сравнивает сохранённый текст с текстом из внешнего сервиса (against=upstream)
+// @Description или с сохранённой ревизией (against=revision:<n>). Сравнение идёт от более старого текста к более новому:
+// @Description сохранённый текст → внешний сервис, ревизия → сохранённый текст.
+// @Tags lyrics
+// @Produce json
+// @Param id path int true "ID песни"
+// @Param against query string true "С чем сравнивать: upstream или revision:<n>"
+// @Success 200 {object} entities.LyricsDiffData "Построчный unified diff и сравнение по куплетам"
+// @Failure 400 {object} ErrorResponse "Неверный запрос"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль viewer"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Песня, текст или ревизия не найдены"
+// @Failure 502 {object} ErrorResponse "Ошибка внешнего сервиса"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /song/{id}/lyrics/diff [get]
+func (h *LyricsHandler) GetLyricsDiff(c *gin.Context) {
+	songIDParam := c.Param("id")
+	songID, err := strconv.Atoi(songIDParam)
+
+	if err != nil {
+		h.logger.Debug("Missing or invalid ID param for request", "ID param", songIDParam)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "song ID is required"})
+		return
+	}
+
+	var params GetLyricsDiffParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	target, ok := parseDiffTarget(params.Against)
+	if !ok {
+		h.logger.Debug("Invalid diff target", "against", params.Against)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "against must be upstream or revision:<n>"})
+		return
+	}
+
+	diff, err := h.usecases.GetLyricsDiff.Execute(c.Request.Context(), songID, target)
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, errs.ErrNotFound):
+			h.logger.Debug("Lyrics for diff not found", "error", err)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+		case errors.Is(err, errs.ErrServiceProblem{}):
+			h.logger.Error("External Service fail", "error", err)
+			c.JSON(http.StatusBadGateway, BadGatewayResponse)
+		default:
+			h.logger.Error("Getting lyrics diff failed", "error", err)
+			c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		}
+		return
+	}
+
+	h.logger.Info("Lyrics diff built successfully", "song", songID, "against", params.Against)
+	c.JSON(http.StatusOK, diff)
+}
+
+func parseDiffTarget(against string) (entities.LyricsDiffTarget, bool) {
+	if against == "upstream" {
+		return entities.LyricsDiffTarget{Upstream: true}, true
+	}
+
+	revisionParam, found := strings.CutPrefix(against, "revision:")
+	if !found {
+		return entities.LyricsDiffTarget{}, false
+	}
+
+	revision, err := strconv.Atoi(revisionParam)
+	if err != nil || revision < 1 {
+		return entities.LyricsDiffTarget{}, false
+	}
+
+	return entities.LyricsDiffTarget{Revision: revision}, true
+}
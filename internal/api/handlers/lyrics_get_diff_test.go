@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupGetLyricsDiffRouter(mockLogger *MockLogger, mockUseCase *MockGetLyricsDiffUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		GetLyricsDiff: mockUseCase,
+	}
+
+	handler := handlers.NewLyricsHandler(mockLogger, useCases)
+	r.GET("/songs/:id/lyrics/diff", handler.GetLyricsDiff)
+	return r
+}
+
+// Успешное сравнение с ревизией
+func TestLyricsHandler_GetLyricsDiff_Revision(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetLyricsDiffUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	expectedDiff := &entities.LyricsDiffData{
+		From:    "revision:2",
+		To:      "current",
+		Unified: "--- revision:2\n+++ current\n@@ -1 +1 @@\n-old\n+new\n",
+	}
+	mockUseCase.On("Execute", mock.Anything, 123, entities.LyricsDiffTarget{Revision: 2}).Return(expectedDiff, nil)
+
+	router := setupGetLyricsDiffRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/songs/123/lyrics/diff?against=revision:2", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response entities.LyricsDiffData
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDiff.Unified, response.Unified)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+// Неверное значение against
+func TestLyricsHandler_GetLyricsDiff_InvalidTarget(t *testing.T) {
+	testCases := []string{"", "?against=latest", "?against=revision:", "?against=revision:0", "?against=revision:abc"}
+
+	for _, query := range testCases {
+		t.Run(query, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockUseCase := new(MockGetLyricsDiffUseCase)
+
+			mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+			router := setupGetLyricsDiffRouter(mockLogger, mockUseCase)
+
+			req, _ := http.NewRequest(http.MethodGet, "/songs/123/lyrics/diff"+query, nil)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			mockUseCase.AssertNotCalled(t, "Execute")
+		})
+	}
+}
+
+// Внешний сервис недоступен
+func TestLyricsHandler_GetLyricsDiff_ServiceProblem(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetLyricsDiffUseCase)
+
+	mockLogger.On("Error", "External Service fail", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, 123, entities.LyricsDiffTarget{Upstream: true}).Return(nil, errs.ErrServiceProblem{})
+
+	router := setupGetLyricsDiffRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/songs/123/lyrics/diff?against=upstream", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+
+	mockLogger.AssertExpectations(t)
+	mockUseCase.AssertExpectations(t)
+}
@@ -213,3 +213,27 @@ func TestLyricsHandler_GetLyrics_NegativeOffset(t *testing.T) {
 
 	mockLogger.AssertExpectations(t)
 }
+
+// Параметр censor передаётся в юзкейс
+func TestLyricsHandler_GetLyrics_Censored(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetSongLyricsUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	songID := 123
+	mockUseCase.On("Execute", mock.Anything, songID, entities.LyricsFilterData{
+		Censor: true,
+	}).Return([]entities.LyricsVerseData{{Index: 0, Content: "**** verse"}}, nil)
+
+	router := setupGetLyricsRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/songs/123/lyrics?censor=1", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	mockUseCase.AssertExpectations(t)
+}
@@ -27,6 +27,22 @@ func (m *MockLogger) Warn(msg string, args ...any) {
 	m.Called(msg, args)
 }
 
+func (m *MockLogger) DebugContext(_ context.Context, msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) InfoContext(_ context.Context, msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) ErrorContext(_ context.Context, msg string, args ...any) {
+	m.Called(msg, args)
+}
+
+func (m *MockLogger) WarnContext(_ context.Context, msg string, args ...any) {
+	m.Called(msg, args)
+}
+
 type MockCreateSongUseCase struct {
 	mock.Mock
 }
@@ -84,3 +100,216 @@ func (m *MockUpdateSongUseCase) Execute(ctx context.Context, id int, data entiti
 	args := m.Called(ctx, id, data)
 	return args.Error(0)
 }
+
+type MockGetLyricsDiffUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetLyricsDiffUseCase) Execute(ctx context.Context, songID int, target entities.LyricsDiffTarget) (*entities.LyricsDiffData, error) {
+	args := m.Called(ctx, songID, target)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.LyricsDiffData), args.Error(1)
+}
+
+type MockRefreshSongUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRefreshSongUseCase) Execute(ctx context.Context, songID int, policy entities.RefreshPolicy) (*entities.RefreshResultData, error) {
+	args := m.Called(ctx, songID, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RefreshResultData), args.Error(1)
+}
+
+type MockMergeSongsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockMergeSongsUseCase) Execute(ctx context.Context, data entities.MergeSongsData) (*entities.SongData, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.SongData), args.Error(1)
+}
+
+type MockBatchSongsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockBatchSongsUseCase) Execute(ctx context.Context, data entities.BatchSongsData) (*entities.BatchResultData, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BatchResultData), args.Error(1)
+}
+
+type MockStartIdempotentRequestUseCase struct {
+	mock.Mock
+}
+
+func (m *MockStartIdempotentRequestUseCase) Execute(ctx context.Context, request entities.IdempotentRequest) (*entities.IdempotentResponse, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.IdempotentResponse), args.Error(1)
+}
+
+type MockFinishIdempotentRequestUseCase struct {
+	mock.Mock
+}
+
+func (m *MockFinishIdempotentRequestUseCase) Execute(ctx context.Context, request entities.IdempotentRequest, response *entities.IdempotentResponse) error {
+	args := m.Called(ctx, request, response)
+	return args.Error(0)
+}
+
+type MockAuthenticateUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAuthenticateUseCase) Execute(ctx context.Context, credentials entities.Credentials) (*entities.Principal, error) {
+	args := m.Called(ctx, credentials)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Principal), args.Error(1)
+}
+
+type MockGetAuditLogUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetAuditLogUseCase) Execute(ctx context.Context, filter entities.AuditFilterData) ([]entities.AuditRecordData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.AuditRecordData), args.Error(1)
+}
+
+type MockCheckRateLimitUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCheckRateLimitUseCase) Execute(ctx context.Context, group entities.RateLimitGroup, clientIP string) (*entities.RateLimitResult, error) {
+	args := m.Called(ctx, group, clientIP)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RateLimitResult), args.Error(1)
+}
+
+type MockCheckReadinessUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCheckReadinessUseCase) Execute(ctx context.Context) *entities.HealthReport {
+	args := m.Called(ctx)
+	return args.Get(0).(*entities.HealthReport)
+}
+
+type MockGetConfigUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetConfigUseCase) Execute(ctx context.Context) (*entities.ConfigState, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ConfigState), args.Error(1)
+}
+
+type MockCreateWebhookUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCreateWebhookUseCase) Execute(ctx context.Context, data entities.NewWebhookData) (*entities.CreatedWebhookData, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.CreatedWebhookData), args.Error(1)
+}
+
+type MockGetWebhookDeliveriesUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetWebhookDeliveriesUseCase) Execute(
+	ctx context.Context,
+	filter entities.WebhookDeliveryFilterData,
+) ([]entities.WebhookDeliveryData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.WebhookDeliveryData), args.Error(1)
+}
+
+type MockGetChangesUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetChangesUseCase) Execute(ctx context.Context, filter entities.ChangesFilterData) (*entities.ChangesData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ChangesData), args.Error(1)
+}
+
+type MockStreamEventsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockStreamEventsUseCase) Execute(ctx context.Context, filter entities.EventStreamFilterData) (*entities.EventStream, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.EventStream), args.Error(1)
+}
+
+type MockGetSongsLyricsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetSongsLyricsUseCase) Execute(ctx context.Context, songIDs []int, filter entities.LyricsFilterData) (map[int][]entities.LyricsVerseData, error) {
+	args := m.Called(ctx, songIDs, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int][]entities.LyricsVerseData), args.Error(1)
+}
+
+type MockGetDraftsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetDraftsUseCase) Execute(ctx context.Context, filter entities.DraftFilterData) ([]entities.SongDraftData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.SongDraftData), args.Error(1)
+}
+
+type MockAcceptDraftUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAcceptDraftUseCase) Execute(ctx context.Context, songID int) (*entities.SongData, error) {
+	args := m.Called(ctx, songID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.SongData), args.Error(1)
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"bytes"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const problemContentType = "application/problem+json"
+
+// Ошибка в формате RFC 7807, которой отвечает /api/v2
+type ProblemDetails struct {
+	// URI вида ошибки, по нему клиент отличает ошибки друг от друга
+	Type   string `json:"type" example:"urn:em-library:problem:not-found"`
+	Title  string `json:"title" example:"Resource not found"`
+	Status int    `json:"status" example:"404"`
+	Detail string `json:"detail,omitempty" example:"not found"`
+	// путь запроса, в котором произошла ошибка
+	Instance  string `json:"instance" example:"/api/v2/song/1/lyrics"`
+	RequestID string `json:"request_id,omitempty" example:"0af7651916cd43dd8448eb211c80319c"`
+	// ошибки проверки отдельных полей запроса
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field" example:"group"`
+	Message string `json:"message" example:"is required"`
+}
+
+type problemType struct {
+	status int
+	slug   string
+	title  string
+}
+
+var (
+	validationProblem     = problemType{http.StatusBadRequest, "validation-error", "Request validation failed"}
+	invalidRequestProblem = problemType{http.StatusBadRequest, "invalid-request", "Invalid request"}
+	invalidInputProblem   = problemType{http.StatusBadRequest, "invalid-input", "Invalid input"}
+	unauthorizedProblem   = problemType{http.StatusUnauthorized, "unauthorized", "Authentication required"}
+	forbiddenProblem      = problemType{http.StatusForbidden, "forbidden", "Permission denied"}
+	notFoundProblem       = problemType{http.StatusNotFound, "not-found", "Resource not found"}
+	alreadyExistsProblem  = problemType{http.StatusConflict, "already-exists", "Resource already exists"}
+	rateLimitedProblem    = problemType{http.StatusTooManyRequests, "rate-limited", "Too many requests"}
+	keyReusedProblem      = problemType{http.StatusUnprocessableEntity, "idempotency-key-reused", "Idempotency key reused"}
+	inProgressProblem     = problemType{http.StatusConflict, "request-in-progress", "Request in progress"}
+	badGatewayProblem     = problemType{http.StatusBadGateway, "external-service-error", "External service error"}
+)
+
+// Виды ошибок юзкейсов. Статусы те же, что в общей таблице errs, каждую ошибку из errs.Known
+// нужно добавить и сюда.
+var errorProblems = []struct {
+	err     error
+	problem problemType
+}{
+	{errs.ErrUnauthorized, unauthorizedProblem},
+	{errs.ErrForbidden, forbiddenProblem},
+	{errs.ErrNotFound, notFoundProblem},
+	{errs.ErrAlreadyExists, alreadyExistsProblem},
+	{errs.ErrInvalidInput, invalidInputProblem},
+	{errs.ErrServiceProblem{}, badGatewayProblem},
+	{errs.ErrIdempotencyKeyReused, keyReusedProblem},
+	{errs.ErrRequestInProgress, inProgressProblem},
+}
+
+// Виды ошибок по статусу ответа, когда хэндлер или middleware не приложили ошибку юзкейса
+var statusProblems = map[int]problemType{
+	http.StatusBadRequest:      invalidRequestProblem,
+	http.StatusUnauthorized:    unauthorizedProblem,
+	http.StatusForbidden:       forbiddenProblem,
+	http.StatusNotFound:        notFoundProblem,
+	http.StatusConflict:        alreadyExistsProblem,
+	http.StatusTooManyRequests: rateLimitedProblem,
+	http.StatusBadGateway:      badGatewayProblem,
+}
+
+func (p problemType) uri() string {
+	if p.slug == "" {
+		return "about:blank"
+	}
+	return "urn:em-library:problem:" + p.slug
+}
+
+// Переводит ошибки маршрутов /api/v2 в application/problem+json. Хэндлеры общие с /api/v1
+// и отвечают как раньше, а middleware подменяет ответы со статусом 4xx и 5xx. Вид ошибки и
+// статус берутся из ошибки юзкейса, приложенной к запросу, иначе из статуса ответа хэндлера.
+// Ошибки разбора запроса превращаются в список ошибок по полям.
+func NewProblemMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		w := &problemWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.Status() < http.StatusBadRequest || w.ResponseWriter.Written() {
+			return
+		}
+
+		problem := newProblem(c, w.Status(), w.body.Bytes())
+
+		body, err := json.Marshal(problem)
+		if err != nil {
+			return
+		}
+
+		c.Header("Content-Type", problemContentType)
+		c.Writer.WriteHeader(problem.Status)
+		_, _ = c.Writer.Write(body)
+	}
+}
+
+func newProblem(c *gin.Context, status int, body []byte) ProblemDetails {
+	problem := ProblemDetails{
+		Status:    status,
+		Instance:  c.Request.URL.Path,
+		RequestID: entities.RequestIDFromContext(c.Request.Context()),
+	}
+
+	// в теле ответа v1 описание ошибки для клиента, внутренние подробности туда не попадают
+	var resp ErrorResponse
+	if json.Unmarshal(body, &resp) == nil {
+		problem.Detail = resp.Error
+	}
+
+	pt, ok := statusProblems[status]
+	if !ok {
+		pt = problemType{status: status, title: http.StatusText(status)}
+	}
+
+	if err := c.Errors.Last(); err != nil {
+		if err.IsType(gin.ErrorTypeBind) {
+			pt = validationProblem
+			problem.Detail, problem.Errors = bindingErrors(err.Err, err.Meta)
+		} else {
+			for _, t := range errorProblems {
+				if errors.Is(err.Err, t.err) {
+					pt = t.problem
+					break
+				}
+			}
+			if errors.Is(err.Err, errs.ErrInvalidInput) {
+				problem.Detail = err.Err.Error()
+			}
+		}
+	}
+
+	problem.Type = pt.uri()
+	problem.Title = pt.title
+	problem.Status = pt.status
+	return problem
+}
+
+// Прикладывает к запросу ошибку разбора параметров, чтобы в ответе /api/v2 были ошибки по полям.
+// params — то, во что разбирался запрос: по его тегам json и form определяются имена полей.
+func bindingError(c *gin.Context, err error, params any) {
+	_ = c.Error(err).SetType(gin.ErrorTypeBind).SetMeta(params)
+}
+
+func bindingErrors(err error, params any) (string, []FieldError) {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			fields[i] = FieldError{
+				Field:   fieldName(params, fe.StructNamespace()),
+				Message: validationMessage(fe),
+			}
+		}
+		return "request contains invalid fields", fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return "request contains invalid fields", []FieldError{{
+			Field:   typeErr.Field,
+			Message: "must be " + typeErr.Type.String(),
+		}}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return "request body is not valid JSON", nil
+	}
+
+	return err.Error(), nil
+}
+
+// Имя поля в запросе по пути в структуре вида CreateSongParams.Band
+func fieldName(params any, namespace string) string {
+	t := reflect.TypeOf(params)
+	path := strings.Split(namespace, ".")[1:]
+
+	names := make([]string, 0, len(path))
+	for _, name := range path {
+		name, index, _ := strings.Cut(name, "[")
+
+		for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice) {
+			t = t.Elem()
+		}
+
+		if t == nil || t.Kind() != reflect.Struct {
+			names = append(names, name)
+			continue
+		}
+
+		f, ok := t.FieldByName(name)
+		if !ok {
+			names = append(names, name)
+			t = nil
+			continue
+		}
+
+		tagName := name
+		for _, tag := range []string{"json", "form", "uri"} {
+			if value, _, _ := strings.Cut(f.Tag.Get(tag), ","); value != "" && value != "-" {
+				tagName = value
+				break
+			}
+		}
+		if index != "" {
+			tagName += "[" + index
+		}
+
+		names = append(names, tagName)
+		t = f.Type
+	}
+
+	return strings.Join(names, ".")
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return "must be at least " + fe.Param() + lengthUnit(fe)
+	case "max":
+		return "must be at most " + fe.Param() + lengthUnit(fe)
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "url", "http_url":
+		return "must be a valid URL"
+	default:
+		return fmt.Sprintf("failed the %q check", fe.Tag())
+	}
+}
+
+// Для строк и списков min и max ограничивают длину
+func lengthUnit(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Map:
+		return " items long"
+	default:
+		return ""
+	}
+}
+
+// Откладывает ответы с ошибкой, чтобы middleware мог заменить их на problem details.
+// Успешные ответы, в том числе потоковые, пишутся сразу.
+type problemWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *problemWriter) failed() bool {
+	return w.Status() >= http.StatusBadRequest && !w.ResponseWriter.Written()
+}
+
+func (w *problemWriter) WriteHeaderNow() {
+	if !w.failed() {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *problemWriter) Write(data []byte) (int, error) {
+	if w.failed() {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *problemWriter) WriteString(s string) (int, error) {
+	if w.failed() {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *problemWriter) Flush() {
+	if !w.failed() {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// Нужен http.ResponseController, чтобы потоковые ответы могли продлить таймаут записи
+func (w *problemWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,151 @@
+package handlers_test
+
+import (
+	"bytes"
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupProblemRouter(mockLogger *MockLogger, useCases usecase.UseCases) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(entities.ContextWithRequestID(c.Request.Context(), "req-1"))
+	})
+
+	songs := handlers.NewSongsHandler(mockLogger, useCases)
+	v2 := r.Group("/api/v2", handlers.NewProblemMiddleware())
+	v2.POST("/song", songs.CreateSong)
+	v2.DELETE("/song/:id", songs.DeleteSong)
+	v2.GET("/songs", handlers.NewAuthMiddleware(mockLogger, useCases), songs.GetSongsList)
+	return r
+}
+
+func decodeProblem(t *testing.T, recorder *httptest.ResponseRecorder) handlers.ProblemDetails {
+	var problem handlers.ProblemDetails
+	assert.Equal(t, "application/problem+json", recorder.Header().Get("Content-Type"))
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	return problem
+}
+
+// Ошибки проверки тела запроса возвращаются по полям с именами из JSON
+func TestProblemMiddleware_ValidationErrors(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	router := setupProblemRouter(mockLogger, usecase.UseCases{})
+
+	body, _ := json.Marshal(map[string]any{"song": "Song", "enrich": "sometimes"})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v2/song", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	problem := decodeProblem(t, recorder)
+	assert.Equal(t, "urn:em-library:problem:validation-error", problem.Type)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "/api/v2/song", problem.Instance)
+	assert.Equal(t, "req-1", problem.RequestID)
+	assert.Equal(t, []handlers.FieldError{
+		{Field: "group", Message: "is required"},
+		{Field: "enrich", Message: "must be one of: auto, never, fill_missing"},
+	}, problem.Errors)
+}
+
+// Вид ошибки и статус определяются по ошибке юзкейса
+func TestProblemMiddleware_UseCaseError(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockDeleteSongUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockUseCase.On("Execute", mock.Anything, 7).Return(fmt.Errorf("%w song 7", errs.ErrNotFound))
+
+	router := setupProblemRouter(mockLogger, usecase.UseCases{DeleteSong: mockUseCase})
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v2/song/7", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	problem := decodeProblem(t, recorder)
+	assert.Equal(t, "urn:em-library:problem:not-found", problem.Type)
+	assert.Equal(t, "Resource not found", problem.Title)
+	assert.Equal(t, "not found", problem.Detail)
+	assert.Empty(t, problem.Errors)
+	mockUseCase.AssertExpectations(t)
+}
+
+// Ответы middleware без ошибки юзкейса переводятся по статусу, заголовки сохраняются
+func TestProblemMiddleware_Unauthorized(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockAuthenticateUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockUseCase.On("Execute", mock.Anything, entities.Credentials{}).Return(nil, errs.ErrUnauthorized)
+
+	router := setupProblemRouter(mockLogger, usecase.UseCases{Authenticate: mockUseCase})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v2/songs", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.NotEmpty(t, recorder.Header().Get("WWW-Authenticate"))
+	problem := decodeProblem(t, recorder)
+	assert.Equal(t, "urn:em-library:problem:unauthorized", problem.Type)
+	assert.Equal(t, "unauthorized", problem.Detail)
+}
+
+// Успешные ответы не меняются
+func TestProblemMiddleware_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockDeleteSongUseCase)
+
+	mockUseCase.On("Execute", mock.Anything, 7).Return(nil)
+
+	router := setupProblemRouter(mockLogger, usecase.UseCases{DeleteSong: mockUseCase})
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v2/song/7", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+}
+
+// Каждая ошибка из errs.Known получает свой вид проблемы со статусом из общей таблицы errs
+func TestProblemMiddleware_KnownErrors(t *testing.T) {
+	for _, knownErr := range errs.Known() {
+		t.Run(knownErr.Error(), func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/api/v2/fail", handlers.NewProblemMiddleware(), func(c *gin.Context) {
+				_ = c.Error(knownErr)
+				c.JSON(http.StatusInternalServerError, handlers.ServerErrorResponse)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/v2/fail", nil)
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+
+			public, _ := errs.PublicOf(knownErr)
+			assert.Equal(t, public.Status, recorder.Code)
+			problem := decodeProblem(t, recorder)
+			assert.Equal(t, public.Status, problem.Status)
+			assert.NotEqual(t, "about:blank", problem.Type)
+		})
+	}
+}
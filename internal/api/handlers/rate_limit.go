@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var TooManyRequestsResponse = ErrorResponse{Error: "too many requests"}
+
+// Возвращает middleware, ограничивающий частоту запросов клиента к группе маршрутов.
+// Ставится после аутентификации, чтобы лимит считался по клиенту, а не по IP.
+// Состояние лимита возвращается в заголовках RateLimit-*, при превышении — 429 с Retry-After.
+func NewRateLimitMiddleware(l config.Logger, u usecase.UseCases) func(group entities.RateLimitGroup) gin.HandlerFunc {
+	return func(group entities.RateLimitGroup) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			result, err := u.CheckRateLimit.Execute(c.Request.Context(), group, c.ClientIP())
+			if err != nil {
+				// недоступное хранилище лимитов не должно останавливать сервис
+				l.Error("Rate limit check failed", "error", err, "group", group)
+				c.Next()
+				return
+			}
+
+			if result == nil {
+				c.Next()
+				return
+			}
+
+			c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+			c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				retryAfter := max(ceilSeconds(result.RetryAfter), 1)
+				l.Debug("Rate limit exceeded", "group", group, "path", c.Request.URL.Path, "retry_after", retryAfter)
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, TooManyRequestsResponse)
+				return
+			}
+
+			c.Next()
+		}
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupRateLimitRouter(mockLogger *MockLogger, mockUseCase *MockCheckRateLimitUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		CheckRateLimit: mockUseCase,
+	}
+
+	rateLimit := handlers.NewRateLimitMiddleware(mockLogger, useCases)
+	r.POST("/song", rateLimit(entities.RateLimitUpstream), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	return r
+}
+
+func TestRateLimitMiddleware_Allowed(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockCheckRateLimitUseCase)
+
+	mockUseCase.On("Execute", mock.Anything, entities.RateLimitUpstream, mock.Anything).Return(&entities.RateLimitResult{
+		Allowed:   true,
+		Limit:     10,
+		Remaining: 9,
+		Reset:     1500 * time.Millisecond,
+	}, nil)
+
+	router := setupRateLimitRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/song", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "10", recorder.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "9", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", recorder.Header().Get("RateLimit-Reset"))
+	assert.Empty(t, recorder.Header().Get("Retry-After"))
+	mockUseCase.AssertExpectations(t)
+}
+
+// Лимит исчерпан
+func TestRateLimitMiddleware_TooManyRequests(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockCheckRateLimitUseCase)
+
+	mockLogger.On("Debug", "Rate limit exceeded", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, entities.RateLimitUpstream, mock.Anything).Return(&entities.RateLimitResult{
+		Allowed:    false,
+		Limit:      10,
+		Remaining:  0,
+		Reset:      20 * time.Second,
+		RetryAfter: 200 * time.Millisecond,
+	}, nil)
+
+	router := setupRateLimitRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/song", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.JSONEq(t, `{"errors":"too many requests"}`, recorder.Body.String())
+	assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "20", recorder.Header().Get("RateLimit-Reset"))
+	mockLogger.AssertExpectations(t)
+}
+
+// Ошибка хранилища лимитов не блокирует запросы
+func TestRateLimitMiddleware_LimiterError(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockCheckRateLimitUseCase)
+
+	mockLogger.On("Error", "Rate limit check failed", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, entities.RateLimitUpstream, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	router := setupRateLimitRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/song", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("RateLimit-Limit"))
+	mockLogger.AssertExpectations(t)
+}
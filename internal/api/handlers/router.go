@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"em-library/config"
+	"em-library/internal/entities"
 	"em-library/internal/usecase"
+	"time"
 
 	docs "em-library/docs"
 
@@ -12,16 +14,40 @@ import (
 )
 
 type Handlers struct {
-	config *config.Config
-	Songs  *SongsHandler
-	Lyrics *LyricsHandler
+	config      *config.Config
+	Songs       *SongsHandler
+	Lyrics      *LyricsHandler
+	Duplicates  *DuplicatesHandler
+	Drafts      *DraftsHandler
+	Audit       *AuditHandler
+	Health      *HealthHandler
+	Admin       *AdminHandler
+	Webhooks    *WebhooksHandler
+	Changes     *ChangesHandler
+	Events      *EventsHandler
+	GraphQL     *GraphQLHandler
+	Auth        gin.HandlerFunc
+	RateLimit   func(group entities.RateLimitGroup) gin.HandlerFunc
+	Idempotency gin.HandlerFunc
 }
 
 func NewHandlers(cfg *config.Config, usecases usecase.UseCases) *Handlers {
 	return &Handlers{
-		config: cfg,
-		Songs:  NewSongsHandler(cfg.Logger, usecases),
-		Lyrics: NewLyricsHandler(cfg.Logger, usecases),
+		config:      cfg,
+		Songs:       NewSongsHandler(cfg.Logger, usecases),
+		Lyrics:      NewLyricsHandler(cfg.Logger, usecases),
+		Duplicates:  NewDuplicatesHandler(cfg.Logger, usecases),
+		Drafts:      NewDraftsHandler(cfg.Logger, usecases),
+		Audit:       NewAuditHandler(cfg.Logger, usecases),
+		Health:      NewHealthHandler(cfg.Logger, usecases),
+		Admin:       NewAdminHandler(cfg.Logger, usecases),
+		Webhooks:    NewWebhooksHandler(cfg.Logger, usecases),
+		Changes:     NewChangesHandler(cfg.Logger, usecases),
+		Events:      NewEventsHandler(cfg.Logger, usecases, time.Duration(cfg.Changes.StreamHeartbeat)*time.Second),
+		GraphQL:     NewGraphQLHandler(cfg.Logger, usecases),
+		Auth:        NewAuthMiddleware(cfg.Logger, usecases),
+		RateLimit:   NewRateLimitMiddleware(cfg.Logger, usecases),
+		Idempotency: NewIdempotencyMiddleware(cfg.Logger, usecases),
 	}
 }
 
@@ -34,25 +60,96 @@ func (h *Handlers) RegisterRoutes(r *gin.Engine) {
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
 	// две группы нужны для версионирования API при возможных изменениях без обратной совместимости
+	// v2 отличается от v1 только форматом ошибок: application/problem+json по RFC 7807.
+	// /api сохраняет прежний формат ошибок, чтобы не сломать клиентов, которые к нему обращаются.
 	api := r.Group("/api")
 	apiV1 := r.Group("/api/v1")
+	apiV2 := r.Group("/api/v2", NewProblemMiddleware())
 
 	registerRoutes := func(groups ...*gin.RouterGroup) {
-		for _, g := range groups {
+		for _, public := range groups {
 			// healthcheck эндпойнты
-			g.GET("/ping", GetPingHandler)
-			g.GET("/teapot", GetTeapotHandler)
+			public.GET("/ping", GetPingHandler)
+			public.GET("/teapot", GetTeapotHandler)
+			public.GET("/health/live", h.Health.GetLive)
+			public.GET("/health/ready", h.Health.GetReady)
+
+			// остальные эндпойнты доступны только с API-ключом или JWT
+			g := public.Group("")
+			if h.config.Auth.Enabled {
+				g.Use(h.Auth)
+			} else {
+				g.Use(NewAnonymousMiddleware())
+			}
+
+			// у чтения, изменения и запросов во внешний сервис отдельные лимиты.
+			// Изменяющие запросы можно безопасно повторять с заголовком Idempotency-Key.
+			read := g.Group("", h.rateLimit(entities.RateLimitRead))
+			write := g.Group("", h.rateLimit(entities.RateLimitWrite), h.idempotency())
+			upstream := g.Group("", h.rateLimit(entities.RateLimitUpstream), h.idempotency())
 
 			// Песни
-			g.GET("/songs", h.Songs.GetSongsList)
-			g.POST("/song", h.Songs.CreateSong)
-			g.PATCH("/song/:id", h.Songs.UpdateSong)
-			g.DELETE("/song/:id", h.Songs.DeleteSong)
+			read.GET("/songs", h.Songs.GetSongsList)
+			upstream.POST("/song", h.Songs.CreateSong)
+			write.PATCH("/song/:id", h.Songs.UpdateSong)
+			write.DELETE("/song/:id", h.Songs.DeleteSong)
+			write.POST("/songs/batch", h.Songs.BatchSongs)
+			upstream.POST("/song/:id/refresh", h.Songs.RefreshSong)
+			upstream.POST("/songs/refresh", h.Songs.RefreshSongs)
+
+			// Дубликаты
+			read.GET("/songs/duplicates", h.Duplicates.GetDuplicates)
+			write.POST("/songs/merge", h.Duplicates.MergeSongs)
+
+			// Черновики обновлений из внешнего сервиса
+			read.GET("/songs/drafts", h.Drafts.GetDrafts)
+			read.GET("/song/:id/draft", h.Drafts.GetDraft)
+			write.POST("/song/:id/draft/accept", h.Drafts.AcceptDraft)
+			write.DELETE("/song/:id/draft", h.Drafts.RejectDraft)
 
 			// Тексты
-			g.GET("/song/:id/lyrics", h.Lyrics.GetLyrics)
+			read.GET("/song/:id/lyrics", h.Lyrics.GetLyrics)
+			upstream.GET("/song/:id/lyrics/diff", h.Lyrics.GetLyricsDiff)
+
+			// Журнал аудита
+			read.GET("/audit", h.Audit.GetAuditLog)
+
+			// Лента изменений
+			read.GET("/changes", h.Changes.GetChanges)
+			read.GET("/events/stream", h.Events.StreamEvents)
+
+			// Подписки на события
+			read.GET("/webhooks", h.Webhooks.GetWebhooks)
+			write.POST("/webhooks", h.Webhooks.CreateWebhook)
+			read.GET("/webhook/:id", h.Webhooks.GetWebhook)
+			write.PATCH("/webhook/:id", h.Webhooks.UpdateWebhook)
+			write.DELETE("/webhook/:id", h.Webhooks.DeleteWebhook)
+			read.GET("/webhook/:id/deliveries", h.Webhooks.GetWebhookDeliveries)
+			read.GET("/webhooks/dead-letters", h.Webhooks.GetDeadLetters)
+			write.POST("/webhooks/dead-letters/:id/redeliver", h.Webhooks.RedeliverWebhook)
+
+			// GraphQL: запросы и мутации считаются по лимиту изменений
+			write.POST("/graphql", h.GraphQL.Query)
+			read.GET("/graphql/schema", h.GraphQL.Schema)
+
+			// Администрирование
+			read.GET("/admin/config", h.Admin.GetConfig)
 		}
 	}
 
-	registerRoutes(api, apiV1)
+	registerRoutes(api, apiV1, apiV2)
+}
+
+func (h *Handlers) rateLimit(group entities.RateLimitGroup) gin.HandlerFunc {
+	if !h.config.RateLimit.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return h.RateLimit(group)
+}
+
+func (h *Handlers) idempotency() gin.HandlerFunc {
+	if !h.config.Idempotency.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return h.Idempotency
 }
@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"em-library/config"
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Формат problem+json отдаёт только /api/v2, /api и /api/v1 отвечают прежним ErrorResponse
+func TestRegisterRoutes_ErrorFormat(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		problem bool
+	}{
+		{"/api", false},
+		{"/api/v1", false},
+		{"/api/v2", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			handlers.NewHandlers(&config.Config{Logger: mockLogger}, usecase.UseCases{}).RegisterRoutes(r)
+
+			req, _ := http.NewRequest(http.MethodDelete, tt.prefix+"/song/abc", nil)
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			if tt.problem {
+				problem := decodeProblem(t, recorder)
+				assert.Equal(t, http.StatusBadRequest, problem.Status)
+				return
+			}
+
+			assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+			var response handlers.ErrorResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, "song ID is required", response.Error)
+		})
+	}
+}
+
+// Поток событий живёт дольше таймаута записи сервера во всех версиях API,
+// в том числе за middleware problem details в /api/v2
+func TestRegisterRoutes_EventStreamOutlivesWriteTimeout(t *testing.T) {
+	for _, prefix := range []string{"/api", "/api/v1", "/api/v2"} {
+		t.Run(prefix, func(t *testing.T) {
+			mockLogger := new(MockLogger)
+			mockRateLimit := new(MockCheckRateLimitUseCase)
+			mockStreamEvents := new(MockStreamEventsUseCase)
+
+			mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+			mockRateLimit.On("Execute", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			// событие приходит уже после таймаута записи
+			events := make(chan entities.EventData)
+			go func() {
+				time.Sleep(300 * time.Millisecond)
+				events <- entities.EventData{ID: 42, Type: entities.EventSongUpdated, SongID: 7}
+				close(events)
+			}()
+			mockStreamEvents.On("Execute", mock.Anything, entities.EventStreamFilterData{}).
+				Return(&entities.EventStream{Events: events, Err: func() error { return nil }}, nil)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			cfg := &config.Config{Logger: mockLogger, Changes: config.ChangesConfig{StreamHeartbeat: 60}}
+			handlers.NewHandlers(cfg, usecase.UseCases{
+				CheckRateLimit: mockRateLimit,
+				StreamEvents:   mockStreamEvents,
+			}).RegisterRoutes(r)
+
+			server := httptest.NewUnstartedServer(r)
+			server.Config.WriteTimeout = 100 * time.Millisecond
+			server.Start()
+			defer server.Close()
+
+			resp, err := http.Get(server.URL + prefix + "/events/stream")
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Contains(t, string(body), "id:42\nevent:song.updated\n")
+		})
+	}
+}
@@ -27,40 +27,71 @@ func NewSongsHandler(l config.Logger, u usecase.UseCases) *SongsHandler {
 }
 
 type CreateSongParams struct {
-	Band string `json:"group" binding:"required,min=1"`
-	Song string `json:"song" binding:"required,min=1"`
+	Band        string        `json:"group" binding:"required,min=1"`
+	Song        string        `json:"song" binding:"required,min=1"`
+	ReleaseDate *formats.Date `json:"release_date" binding:"omitempty"`
+	Link        *string       `json:"link" binding:"omitempty,min=1"`
+	Lyrics      *string       `json:"lyrics" binding:"omitempty,min=1"`
+	Enrich      *string       `json:"enrich" binding:"omitempty,oneof=auto never fill_missing"`
 }
 
 // CreateSong godoc
 // @Summary Создание новой песни
-// @Description Создает новую песню с указанными данными
+// @Description Создает новую песню с указанными данными. Дата релиза, ссылка и текст могут быть переданы в запросе.
+// @Description Режим enrich определяет обращение к внешнему сервису: auto (по умолчанию) — всегда запрашивать, переданные поля важнее данных сервиса;
+// @Description never — не запрашивать; fill_missing — запрашивать только незаполненные поля, ошибка сервиса не мешает созданию.
 // @Tags songs
 // @Accept json
 // @Produce json
 // @Param song body CreateSongParams true "Данные песни"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
 // @Success 201 {object} entities.SongData "Данные созданной песни"
 // @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль editor"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
 // @Failure 409 {object} ErrorResponse "Песня уже существует"
 // @Failure 502 {object} ErrorResponse "Ошибка внешнего сервиса"
 // @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "editor"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /song [post]
 func (h *SongsHandler) CreateSong(c *gin.Context) {
 	var params *CreateSongParams
 
 	if err := c.ShouldBindJSON(&params); err != nil {
+		bindingError(c, err, &params)
 		h.logger.Debug("Failed parsing request params", "error", err)
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	songData, err := h.usecases.CreateSong.Execute(
-		c.Request.Context(), entities.NewSongData{
-			Song: params.Song,
-			Band: params.Band,
-		},
-	)
+	data := entities.NewSongData{
+		Song: params.Song,
+		Band: params.Band,
+	}
+	if params.ReleaseDate != nil {
+		data.ReleaseDate = params.ReleaseDate.Time()
+	}
+	if params.Link != nil {
+		data.Link = *params.Link
+	}
+	if params.Lyrics != nil {
+		data.Lyrics = *params.Lyrics
+	}
+	if params.Enrich != nil {
+		data.Enrich = entities.EnrichMode(*params.Enrich)
+	}
+
+	songData, err := h.usecases.CreateSong.Execute(c.Request.Context(), data)
 
 	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
 		switch {
 		case errors.Is(err, errs.ErrAlreadyExists):
 			h.logger.Debug("Song already exists", "error", err)
@@ -87,6 +118,7 @@ type GetSongsParams struct {
 	Song            *string    `form:"song" binding:"omitempty,min=1"`
 	ReleaseDateFrom *time.Time `form:"release_date_from" binding:"omitempty" time_format:"2006-01-02"`
 	ReleaseDateTo   *time.Time `form:"release_date_to" binding:"omitempty" time_format:"2006-01-02"`
+	Explicit        *bool      `form:"explicit" binding:"omitempty"`
 	Offset          *int       `form:"offset" binding:"omitempty,min=0"`
 	Limit           *int       `form:"limit" binding:"omitempty,min=1"`
 }
@@ -101,17 +133,25 @@ type GetSongsParams struct {
 // @Param song query string false "Название песни"
 // @Param release_date_from query string false "Дата релиза от (формат: 2006-01-02)"
 // @Param release_date_to query string false "Дата релиза до (формат: 2006-01-02)"
+// @Param explicit query bool false "Фильтр по наличию ненормативной лексики"
 // @Param offset query int false "С какой песни выводить"
 // @Param limit query int false "Сколько песен выводить"
 // @Success 200 {array} entities.SongData "Список песен"
 // @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль viewer"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
 // @Failure 404 {object} ErrorResponse "Песни не найдены"
 // @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "viewer"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /songs [get]
 func (h *SongsHandler) GetSongsList(c *gin.Context) {
 	var params GetSongsParams
 
 	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
 		h.logger.Debug("Failed parsing request params", "error", err)
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 		return
@@ -123,11 +163,16 @@ func (h *SongsHandler) GetSongsList(c *gin.Context) {
 		Song:            params.Song,
 		ReleaseDateFrom: params.ReleaseDateFrom,
 		ReleaseDateTo:   params.ReleaseDateTo,
+		Explicit:        params.Explicit,
 		Offset:          params.Offset,
 		Limit:           params.Limit,
 	})
 
 	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
 		if errors.Is(err, errs.ErrNotFound) {
 			h.logger.Debug("No songs found", "error", err)
 			c.JSON(http.StatusNotFound, NotFoundResponse)
@@ -149,9 +194,18 @@ func (h *SongsHandler) GetSongsList(c *gin.Context) {
 // @Description Удаляет песню по указанному ID
 // @Tags songs
 // @Param id path int true "ID песни"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
 // @Success 204 "Песня успешно удалена"
 // @Failure 400 {object} ErrorResponse "Неверный формат ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Песня не найдена"
 // @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /song/{id} [delete]
 func (h *SongsHandler) DeleteSong(c *gin.Context) {
 	songIDParam := c.Param("id")
@@ -165,6 +219,16 @@ func (h *SongsHandler) DeleteSong(c *gin.Context) {
 
 	err = h.usecases.DeleteSong.Execute(c.Request.Context(), songID)
 	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("Song not found", "ID", songID)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
 		h.logger.Debug("Failed to delete song", "ID", songID)
 		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
 		return
@@ -188,10 +252,18 @@ type PatchSongParams struct {
 // @Accept json
 // @Param id path int true "ID песни"
 // @Param song body PatchSongParams true "Обновляемые данные песни"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
 // @Success 204 "Песня успешно обновлена"
 // @Failure 400 {object} ErrorResponse "Неверный формат запроса или ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль editor"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
 // @Failure 404 {object} ErrorResponse "Песня не найдена"
 // @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "editor"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /song/{id} [patch]
 func (h *SongsHandler) UpdateSong(c *gin.Context) {
 	songIDParam := c.Param("id")
@@ -205,6 +277,7 @@ func (h *SongsHandler) UpdateSong(c *gin.Context) {
 
 	var params PatchSongParams
 	if err := c.ShouldBindJSON(&params); err != nil {
+		bindingError(c, err, &params)
 		h.logger.Debug("Failed parsing request params", "error", err)
 		c.JSON(http.StatusBadRequest, InvalidRequestResponse)
 		return
@@ -225,6 +298,10 @@ func (h *SongsHandler) UpdateSong(c *gin.Context) {
 	})
 
 	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
 		if errors.Is(err, errs.ErrNotFound) {
 			h.logger.Debug("Song not found", "ID", songID)
 			c.JSON(http.StatusNotFound, NotFoundResponse)
@@ -238,3 +315,294 @@ func (h *SongsHandler) UpdateSong(c *gin.Context) {
 	h.logger.Info("Song updated successfully", "ID", songID)
 	c.Status(http.StatusNoContent)
 }
+
+type RefreshSongParams struct {
+	Policy *string `form:"policy" binding:"omitempty,oneof=overwrite fill_empty draft"`
+}
+
+// RefreshSong godoc
+// @Summary Обновление данных песни из внешнего сервиса
+// @Description Повторно запрашивает дату релиза, ссылку и текст во внешнем сервисе и сливает их с сохранёнными по политике:
+// @Description overwrite — заменить отличающиеся поля, fill_empty — заполнить только пустые, draft — сохранить отличия черновиком.
+// @Tags songs
+// @Produce json
+// @Param id path int true "ID песни"
+// @Param policy query string false "Политика слияния (overwrite, fill_empty, draft). По умолчанию из настроек сервиса"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 200 {object} entities.RefreshResultData "Изменённые поля"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса или ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль editor"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Песня не найдена"
+// @Failure 502 {object} ErrorResponse "Ошибка внешнего сервиса"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "editor"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /song/{id}/refresh [post]
+func (h *SongsHandler) RefreshSong(c *gin.Context) {
+	songIDParam := c.Param("id")
+	songID, err := strconv.Atoi(songIDParam)
+
+	if err != nil {
+		h.logger.Debug("Missing or invalid ID param for request", "ID param", songIDParam)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "song ID is required"})
+		return
+	}
+
+	var params RefreshSongParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var policy entities.RefreshPolicy
+	if params.Policy != nil {
+		policy = entities.RefreshPolicy(*params.Policy)
+	}
+
+	result, err := h.usecases.RefreshSong.Execute(c.Request.Context(), songID, policy)
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, errs.ErrNotFound):
+			h.logger.Debug("Song not found", "ID", songID)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+		case errors.Is(err, errs.ErrServiceProblem{}):
+			h.logger.Error("External Service fail", "error", err)
+			c.JSON(http.StatusBadGateway, BadGatewayResponse)
+		default:
+			h.logger.Error("Failed to refresh song", "ID", songID, "error", err)
+			c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		}
+		return
+	}
+
+	h.logger.Info("Song refreshed successfully", "ID", songID, "changes", len(result.Changes))
+	c.JSON(http.StatusOK, result)
+}
+
+type RefreshSongsParams struct {
+	GetSongsParams
+	RefreshSongParams
+}
+
+// RefreshSongs godoc
+// @Summary Массовое обновление песен из внешнего сервиса
+// @Description Обновляет все песни, подходящие под фильтр, по указанной политике слияния.
+// @Description Ошибка обновления одной песни не прерывает обработку остальных и возвращается в её результате.
+// @Tags songs
+// @Produce json
+// @Param id query int false "ID песни"
+// @Param group query string false "Название группы"
+// @Param song query string false "Название песни"
+// @Param release_date_from query string false "Дата релиза от (формат: 2006-01-02)"
+// @Param release_date_to query string false "Дата релиза до (формат: 2006-01-02)"
+// @Param explicit query bool false "Фильтр по наличию ненормативной лексики"
+// @Param offset query int false "С какой песни обновлять"
+// @Param limit query int false "Сколько песен обновить"
+// @Param policy query string false "Политика слияния (overwrite, fill_empty, draft). По умолчанию из настроек сервиса"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 200 {array} entities.RefreshResultData "Результаты обновления по песням"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль editor"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Песни не найдены"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "editor"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /songs/refresh [post]
+func (h *SongsHandler) RefreshSongs(c *gin.Context) {
+	var params RefreshSongsParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var policy entities.RefreshPolicy
+	if params.Policy != nil {
+		policy = entities.RefreshPolicy(*params.Policy)
+	}
+
+	results, err := h.usecases.RefreshSongs.Execute(c.Request.Context(), entities.SongFilterData{
+		ID:              params.ID,
+		Band:            params.Band,
+		Song:            params.Song,
+		ReleaseDateFrom: params.ReleaseDateFrom,
+		ReleaseDateTo:   params.ReleaseDateTo,
+		Explicit:        params.Explicit,
+		Offset:          params.Offset,
+		Limit:           params.Limit,
+	}, policy)
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("No songs found", "error", err)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
+		h.logger.Error("Refreshing songs failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Songs refreshed", "count", len(results))
+	c.JSON(http.StatusOK, results)
+}
+
+type BatchOperationParams struct {
+	Action string           `json:"action" binding:"required,oneof=update delete"`
+	ID     int              `json:"id" binding:"required,gt=0"`
+	Fields *PatchSongParams `json:"fields"`
+}
+
+type BatchFilterParams struct {
+	Band            *string       `json:"group" binding:"omitempty,min=1"`
+	Song            *string       `json:"song" binding:"omitempty,min=1"`
+	ReleaseDateFrom *formats.Date `json:"release_date_from"`
+	ReleaseDateTo   *formats.Date `json:"release_date_to"`
+	Explicit        *bool         `json:"explicit"`
+}
+
+type BatchSongsParams struct {
+	Mode       string                 `json:"mode" binding:"omitempty,oneof=atomic best_effort" example:"atomic"`
+	DryRun     bool                   `json:"dry_run"`
+	Operations []BatchOperationParams `json:"operations" binding:"omitempty,max=500,dive"`
+	Filter     *BatchFilterParams     `json:"filter"`
+	Action     *string                `json:"action" binding:"omitempty,oneof=update delete"`
+	Fields     *PatchSongParams       `json:"fields"`
+}
+
+// BatchSongs godoc
+// @Summary Пакетное изменение и удаление песен
+// @Description Выполняет список операций (operations) или одно действие action над всеми песнями, подходящими под filter, до 500 песен за раз.
+// @Description В режиме atomic (по умолчанию) операции выполняются в одной транзакции и при первой ошибке откатываются все, в режиме best_effort каждая выполняется отдельно.
+// @Description Ошибки отдельных операций возвращаются в их результатах. С dry_run ничего не меняется, а в результатах показано, какими станут песни.
+// @Description Для удаления нужна роль admin, для изменения — editor.
+// @Tags songs
+// @Accept json
+// @Produce json
+// @Param batch body BatchSongsParams true "Операции или фильтр с действием"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 200 {object} entities.BatchResultData "Результаты операций"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль editor или admin"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "editor"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /songs/batch [post]
+func (h *SongsHandler) BatchSongs(c *gin.Context) {
+	var params BatchSongsParams
+
+	if err := c.ShouldBindJSON(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	data := entities.BatchSongsData{
+		Mode:   entities.BatchMode(params.Mode),
+		DryRun: params.DryRun,
+		Update: updateSongData(params.Fields),
+	}
+
+	for _, op := range params.Operations {
+		data.Operations = append(data.Operations, entities.BatchOperationData{
+			Action: entities.BatchAction(op.Action),
+			SongID: op.ID,
+			Update: updateSongData(op.Fields),
+		})
+	}
+
+	if f := params.Filter; f != nil {
+		data.Filter = &entities.SongFilterData{
+			Band:     f.Band,
+			Song:     f.Song,
+			Explicit: f.Explicit,
+		}
+		if f.ReleaseDateFrom != nil {
+			from := f.ReleaseDateFrom.Time()
+			data.Filter.ReleaseDateFrom = &from
+		}
+		if f.ReleaseDateTo != nil {
+			to := f.ReleaseDateTo.Time()
+			data.Filter.ReleaseDateTo = &to
+		}
+	}
+	if params.Action != nil {
+		data.Action = entities.BatchAction(*params.Action)
+	}
+
+	result, err := h.usecases.BatchSongs.Execute(c.Request.Context(), data)
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrInvalidInput) {
+			h.logger.Debug("Invalid batch request", "error", err)
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		h.logger.Error("Batch operation failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	for _, item := range result.Items {
+		switch {
+		case item.Err == nil:
+		case errors.Is(item.Err, errs.ErrNotFound), errors.Is(item.Err, errs.ErrAlreadyExists), errors.Is(item.Err, errs.ErrInvalidInput):
+			h.logger.Debug("Batch operation rejected", "index", item.Index, "ID", item.SongID, "error", item.Err)
+		default:
+			h.logger.Error("Batch operation item failed", "index", item.Index, "ID", item.SongID, "error", item.Err)
+		}
+	}
+
+	h.logger.Info("Batch operation finished", "mode", result.Mode, "dry_run", result.DryRun,
+		"succeeded", result.Succeeded, "failed", result.Failed)
+	c.JSON(http.StatusOK, result)
+}
+
+func updateSongData(params *PatchSongParams) entities.UpdateSongData {
+	if params == nil {
+		return entities.UpdateSongData{}
+	}
+
+	data := entities.UpdateSongData{
+		Band:   params.Band,
+		Song:   params.Song,
+		Link:   params.Link,
+		Lyrics: params.Lyrics,
+	}
+	if params.ReleaseDate != nil {
+		releaseDate := params.ReleaseDate.Time()
+		data.ReleaseDate = &releaseDate
+	}
+	return data
+}
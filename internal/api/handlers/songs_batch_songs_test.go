@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"bytes"
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupBatchSongsRouter(mockLogger *MockLogger, mockUseCase *MockBatchSongsUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		BatchSongs: mockUseCase,
+	}
+
+	handler := handlers.NewSongsHandler(mockLogger, useCases)
+	r.POST("/songs/batch", handler.BatchSongs)
+	return r
+}
+
+func batchRequest(router *gin.Engine, body any) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, "/songs/batch", bytes.NewBuffer(data))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// Операции передаются в юзкейс, результаты отдаются по каждой операции
+func TestSongsHandler_BatchSongs_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockBatchSongsUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	band := "New Group"
+	expectedData := entities.BatchSongsData{
+		Mode: entities.BatchBestEffort,
+		Operations: []entities.BatchOperationData{
+			{Action: entities.BatchUpdate, SongID: 1, Update: entities.UpdateSongData{Band: &band}},
+			{Action: entities.BatchDelete, SongID: 2},
+		},
+	}
+	mockUseCase.On("Execute", mock.Anything, expectedData).Return(&entities.BatchResultData{
+		Mode:      entities.BatchBestEffort,
+		Succeeded: 1,
+		Failed:    1,
+		Items: []entities.BatchItemResultData{
+			{Index: 0, Action: entities.BatchUpdate, SongID: 1, Status: entities.BatchItemApplied, Song: &entities.SongAuditData{Band: band}},
+			{Index: 1, Action: entities.BatchDelete, SongID: 2, Status: entities.BatchItemFailed, Error: "not found",
+				Err: fmt.Errorf("%w song 2", errs.ErrNotFound)},
+		},
+	}, nil)
+
+	router := setupBatchSongsRouter(mockLogger, mockUseCase)
+
+	recorder := batchRequest(router, map[string]any{
+		"mode": "best_effort",
+		"operations": []map[string]any{
+			{"action": "update", "id": 1, "fields": map[string]string{"group": band}},
+			{"action": "delete", "id": 2},
+		},
+	})
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response entities.BatchResultData
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, entities.BatchItemFailed, response.Items[1].Status)
+	assert.Equal(t, "not found", response.Items[1].Error)
+	assert.Equal(t, band, response.Items[0].Song.Band)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+// Неизвестное действие отклоняется до вызова юзкейса
+func TestSongsHandler_BatchSongs_InvalidAction(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockBatchSongsUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	router := setupBatchSongsRouter(mockLogger, mockUseCase)
+
+	recorder := batchRequest(router, map[string]any{
+		"operations": []map[string]any{{"action": "tag", "id": 1}},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockUseCase.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+// Ошибка проверки пакета в юзкейсе возвращается как 400
+func TestSongsHandler_BatchSongs_InvalidInput(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockBatchSongsUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockUseCase.On("Execute", mock.Anything, entities.BatchSongsData{}).
+		Return(nil, fmt.Errorf("%w operations or filter is required", errs.ErrInvalidInput))
+
+	router := setupBatchSongsRouter(mockLogger, mockUseCase)
+
+	recorder := batchRequest(router, map[string]any{})
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	var response handlers.ErrorResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Contains(t, response.Error, "operations or filter is required")
+	mockUseCase.AssertExpectations(t)
+}
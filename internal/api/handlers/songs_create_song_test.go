@@ -268,3 +268,65 @@ func TestSongsHandler_CreateSong_ServiceProblem(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 	mockUseCase.AssertExpectations(t)
 }
+
+// Песня с данными, переданными вручную, без обращения к внешнему сервису
+func TestSongsHandler_CreateSong_ManualData(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockCreateSongUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	releaseDate := time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	expectedSong := &entities.SongData{
+		ID:          123,
+		Band:        "Curated Group",
+		Song:        "Curated Song",
+		ReleaseDate: releaseDate,
+		Link:        "https://example.com/curated",
+	}
+
+	mockUseCase.On("Execute", mock.Anything, entities.NewSongData{
+		Band:        "Curated Group",
+		Song:        "Curated Song",
+		ReleaseDate: releaseDate,
+		Link:        "https://example.com/curated",
+		Lyrics:      "Verse one",
+		Enrich:      entities.EnrichNever,
+	}).Return(expectedSong, nil)
+
+	router := setupPostSongsRouter(mockLogger, mockUseCase)
+
+	body := `{"group":"Curated Group","song":"Curated Song","release_date":"2006-07-16",` +
+		`"link":"https://example.com/curated","lyrics":"Verse one","enrich":"never"}`
+	req, _ := http.NewRequest(http.MethodPost, "/songs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+// Неизвестный режим обогащения
+func TestSongsHandler_CreateSong_InvalidEnrichMode(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockCreateSongUseCase)
+
+	mockLogger.On("Debug", "Failed parsing request params", mock.Anything).Once()
+
+	router := setupPostSongsRouter(mockLogger, mockUseCase)
+
+	body := `{"group":"Test Group","song":"Test Song","enrich":"sometimes"}`
+	req, _ := http.NewRequest(http.MethodPost, "/songs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	mockLogger.AssertExpectations(t)
+	mockUseCase.AssertNotCalled(t, "Execute")
+}
@@ -2,7 +2,9 @@ package handlers_test
 
 import (
 	"em-library/internal/api/handlers"
+	"em-library/internal/errs"
 	"em-library/internal/usecase"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -75,3 +77,22 @@ func TestSongsHandler_DeleteSong_InternalError(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 	mockUseCase.AssertExpectations(t)
 }
+
+// Отказ юзкейса по правам превращается в 403
+func TestSongsHandler_DeleteSong_Forbidden(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockDeleteSongUseCase)
+
+	mockLogger.On("Debug", "Permission denied", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, 123).Return(fmt.Errorf("%w admin role required", errs.ErrForbidden))
+
+	router := setupDeleteSongRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/songs/123", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.JSONEq(t, `{"errors":"forbidden"}`, recorder.Body.String())
+	mockUseCase.AssertExpectations(t)
+}
@@ -255,3 +255,27 @@ func TestSongsHandler_GetSongsList_Pagination(t *testing.T) {
 
 	mockUseCase.AssertExpectations(t)
 }
+
+// Фильтр по рейтингу explicit передаётся в юзкейс
+func TestSongsHandler_GetSongsList_ExplicitFilter(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockGetSongListUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	explicit := false
+	mockUseCase.On("Execute", mock.Anything, entities.SongFilterData{
+		Explicit: &explicit,
+	}).Return([]entities.SongData{{ID: 1, Band: "Clean Band", Song: "Clean Song"}}, nil)
+
+	router := setupGetSongsRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodGet, "/songs?explicit=false", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	mockUseCase.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"bytes"
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMergeSongsRouter(mockLogger *MockLogger, mockUseCase *MockMergeSongsUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		MergeSongs: mockUseCase,
+	}
+
+	handler := handlers.NewDuplicatesHandler(mockLogger, useCases)
+	r.POST("/songs/merge", handler.MergeSongs)
+	return r
+}
+
+// Успешное слияние
+func TestDuplicatesHandler_MergeSongs_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockMergeSongsUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	expected := &entities.SongData{ID: 1, Band: "The Beatles", Song: "Yesterday", Link: "https://example.com"}
+	mockUseCase.On("Execute", mock.Anything, entities.MergeSongsData{KeepID: 1, MergeID: 2}).Return(expected, nil)
+
+	router := setupMergeSongsRouter(mockLogger, mockUseCase)
+
+	body, _ := json.Marshal(map[string]int{"keep_id": 1, "merge_id": 2})
+	req, _ := http.NewRequest(http.MethodPost, "/songs/merge", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(expected.ID), response["id"])
+	assert.Equal(t, expected.Song, response["song"])
+
+	mockUseCase.AssertExpectations(t)
+}
+
+// Нет обязательного поля
+func TestDuplicatesHandler_MergeSongs_InvalidBody(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockMergeSongsUseCase)
+
+	mockLogger.On("Debug", "Failed parsing request params", mock.Anything).Once()
+
+	router := setupMergeSongsRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/songs/merge", bytes.NewBufferString(`{"keep_id": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockUseCase.AssertNotCalled(t, "Execute")
+}
+
+// Слияние песни самой с собой
+func TestDuplicatesHandler_MergeSongs_SameSong(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockMergeSongsUseCase)
+
+	mockLogger.On("Debug", "Invalid merge request", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, entities.MergeSongsData{KeepID: 1, MergeID: 1}).
+		Return(nil, fmt.Errorf("%w: cannot merge song into itself", errs.ErrInvalidInput))
+
+	router := setupMergeSongsRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/songs/merge", bytes.NewBufferString(`{"keep_id": 1, "merge_id": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+// Одна из песен не найдена
+func TestDuplicatesHandler_MergeSongs_NotFound(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockMergeSongsUseCase)
+
+	mockLogger.On("Debug", "Song for merge not found", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, entities.MergeSongsData{KeepID: 1, MergeID: 2}).Return(nil, errs.ErrNotFound)
+
+	router := setupMergeSongsRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/songs/merge", bytes.NewBufferString(`{"keep_id": 1, "merge_id": 2}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	mockUseCase.AssertExpectations(t)
+}
@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupRefreshSongRouter(mockLogger *MockLogger, mockUseCase *MockRefreshSongUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		RefreshSong: mockUseCase,
+	}
+
+	handler := handlers.NewSongsHandler(mockLogger, useCases)
+	r.POST("/songs/:id/refresh", handler.RefreshSong)
+	return r
+}
+
+// Успешное обновление с явной политикой
+func TestSongsHandler_RefreshSong_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockRefreshSongUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	expected := &entities.RefreshResultData{
+		SongID:  123,
+		Policy:  entities.RefreshOverwrite,
+		Applied: true,
+		Changes: []entities.FieldChangeData{{Field: "link", Old: "old", New: "new"}},
+	}
+	mockUseCase.On("Execute", mock.Anything, 123, entities.RefreshOverwrite).Return(expected, nil)
+
+	router := setupRefreshSongRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/songs/123/refresh?policy=overwrite", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response entities.RefreshResultData
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, response)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+// Без политики в юзкейс уходит пустая, чтобы применилась политика по умолчанию
+func TestSongsHandler_RefreshSong_DefaultPolicy(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockRefreshSongUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockUseCase.On("Execute", mock.Anything, 123, entities.RefreshPolicy("")).Return(&entities.RefreshResultData{
+		SongID: 123, Policy: entities.RefreshFillEmpty, Changes: []entities.FieldChangeData{},
+	}, nil)
+
+	router := setupRefreshSongRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/songs/123/refresh", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+// Неизвестная политика
+func TestSongsHandler_RefreshSong_InvalidPolicy(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockRefreshSongUseCase)
+
+	mockLogger.On("Debug", "Failed parsing request params", mock.Anything).Once()
+
+	router := setupRefreshSongRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/songs/123/refresh?policy=merge", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockLogger.AssertExpectations(t)
+	mockUseCase.AssertNotCalled(t, "Execute")
+}
+
+// Песня не найдена
+func TestSongsHandler_RefreshSong_NotFound(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockUseCase := new(MockRefreshSongUseCase)
+
+	mockLogger.On("Debug", "Song not found", mock.Anything).Once()
+	mockUseCase.On("Execute", mock.Anything, 123, entities.RefreshPolicy("")).Return(nil, errs.ErrNotFound)
+
+	router := setupRefreshSongRouter(mockLogger, mockUseCase)
+
+	req, _ := http.NewRequest(http.MethodPost, "/songs/123/refresh", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	mockLogger.AssertExpectations(t)
+}
@@ -0,0 +1,441 @@
+package handlers
+
+import (
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhooksHandler struct {
+	logger   config.Logger
+	usecases usecase.UseCases
+}
+
+func NewWebhooksHandler(l config.Logger, u usecase.UseCases) *WebhooksHandler {
+	return &WebhooksHandler{
+		logger:   l,
+		usecases: u,
+	}
+}
+
+type CreateWebhookParams struct {
+	URL    string   `json:"url" binding:"required,url"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=song.created song.updated song.deleted lyrics.updated"`
+}
+
+// CreateWebhook godoc
+// @Summary Создание подписки на события
+// @Description Подписывает URL на события библиотеки: song.created, song.updated, song.deleted, lyrics.updated.
+// @Description События отправляются POST-запросом с JSON события в теле. В заголовке X-EMLib-Signature передаётся
+// @Description sha256=<hex> — HMAC-SHA256 от строки "<X-EMLib-Timestamp>.<тело запроса>" на секрете подписки.
+// @Description Секрет возвращается только в ответе на создание. Ответ подписчика с кодом не из 2xx считается ошибкой,
+// @Description доставка повторяется с растущей задержкой, после исчерпания попыток попадает в dead-letter.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body CreateWebhookParams true "Адрес и события подписки"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 201 {object} entities.CreatedWebhookData "Подписка с секретом"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks [post]
+func (h *WebhooksHandler) CreateWebhook(c *gin.Context) {
+	var params CreateWebhookParams
+
+	if err := c.ShouldBindJSON(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	webhook, err := h.usecases.CreateWebhook.Execute(c.Request.Context(), entities.NewWebhookData{
+		URL:    params.URL,
+		Events: eventTypes(params.Events),
+	})
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrInvalidInput) {
+			h.logger.Debug("Invalid webhook", "error", err)
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		h.logger.Error("Creating webhook failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Webhook created successfully", "id", webhook.ID, "url", webhook.URL)
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// GetWebhooks godoc
+// @Summary Список подписок на события
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} entities.WebhookData "Подписки"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Подписок нет"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks [get]
+func (h *WebhooksHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.usecases.GetWebhooks.Execute(c.Request.Context())
+
+	if err != nil {
+		if respondAccessDenied(c, h.logger, err) {
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			h.logger.Debug("No webhooks found", "error", err)
+			c.JSON(http.StatusNotFound, NotFoundResponse)
+			return
+		}
+
+		h.logger.Error("Getting webhooks failed", "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+		return
+	}
+
+	h.logger.Info("Webhooks retrieved successfully", "count", len(webhooks))
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Summary Подписка на события
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "ID подписки"
+// @Success 200 {object} entities.WebhookData "Подписка"
+// @Failure 400 {object} ErrorResponse "Неверный формат ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Подписка не найдена"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhook/{id} [get]
+func (h *WebhooksHandler) GetWebhook(c *gin.Context) {
+	webhookID, ok := h.webhookID(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.usecases.GetWebhook.Execute(c.Request.Context(), webhookID)
+
+	if err != nil {
+		h.respondError(c, err, "Getting webhook failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+type UpdateWebhookParams struct {
+	URL    *string  `json:"url" binding:"omitempty,url"`
+	Events []string `json:"events" binding:"omitempty,min=1,dive,oneof=song.created song.updated song.deleted lyrics.updated"`
+	Active *bool    `json:"active"`
+}
+
+// UpdateWebhook godoc
+// @Summary Изменение подписки на события
+// @Description Меняет адрес, события или включает и выключает подписку. Выключенной подписке события
+// @Description не отправляются и не копятся.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "ID подписки"
+// @Param webhook body UpdateWebhookParams true "Изменяемые поля"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 200 {object} entities.WebhookData "Подписка"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Подписка не найдена"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhook/{id} [patch]
+func (h *WebhooksHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, ok := h.webhookID(c)
+	if !ok {
+		return
+	}
+
+	var params UpdateWebhookParams
+
+	if err := c.ShouldBindJSON(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	data := entities.UpdateWebhookData{
+		URL:    params.URL,
+		Active: params.Active,
+	}
+	if params.Events != nil {
+		data.Events = eventTypes(params.Events)
+	}
+
+	webhook, err := h.usecases.UpdateWebhook.Execute(c.Request.Context(), webhookID, data)
+
+	if err != nil {
+		h.respondError(c, err, "Updating webhook failed")
+		return
+	}
+
+	h.logger.Info("Webhook updated successfully", "id", webhookID)
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Удаление подписки на события
+// @Description Удаляет подписку вместе с журналом её доставок
+// @Tags webhooks
+// @Param id path int true "ID подписки"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 204 "Подписка удалена"
+// @Failure 400 {object} ErrorResponse "Неверный формат ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Подписка не найдена"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhook/{id} [delete]
+func (h *WebhooksHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, ok := h.webhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecases.DeleteWebhook.Execute(c.Request.Context(), webhookID); err != nil {
+		h.respondError(c, err, "Deleting webhook failed")
+		return
+	}
+
+	h.logger.Info("Webhook deleted successfully", "id", webhookID)
+	c.Status(http.StatusNoContent)
+}
+
+type GetWebhookDeliveriesParams struct {
+	Status *string `form:"status" binding:"omitempty,oneof=pending delivered dead"`
+	Offset *int    `form:"offset" binding:"omitempty,min=0"`
+	Limit  *int    `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Журнал доставок подписки
+// @Description Возвращает доставки событий подписчику от новых к старым: состояние, число попыток,
+// @Description код и ошибку последней попытки и время следующей.
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "ID подписки"
+// @Param status query string false "Состояние доставки" Enums(pending, delivered, dead)
+// @Param offset query int false "С какой доставки выводить"
+// @Param limit query int false "Сколько доставок выводить, не больше 500"
+// @Success 200 {array} entities.WebhookDeliveryData "Доставки"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Доставки не найдены"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhook/{id}/deliveries [get]
+func (h *WebhooksHandler) GetWebhookDeliveries(c *gin.Context) {
+	webhookID, ok := h.webhookID(c)
+	if !ok {
+		return
+	}
+
+	var params GetWebhookDeliveriesParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	filter := entities.WebhookDeliveryFilterData{
+		WebhookID: &webhookID,
+		Offset:    params.Offset,
+		Limit:     params.Limit,
+	}
+	if params.Status != nil {
+		status := entities.WebhookDeliveryStatus(*params.Status)
+		filter.Status = &status
+	}
+
+	deliveries, err := h.usecases.GetWebhookDeliveries.Execute(c.Request.Context(), filter)
+
+	if err != nil {
+		h.respondError(c, err, "Getting webhook deliveries failed")
+		return
+	}
+
+	h.logger.Info("Webhook deliveries retrieved successfully", "id", webhookID, "count", len(deliveries))
+	c.JSON(http.StatusOK, deliveries)
+}
+
+type GetDeadLettersParams struct {
+	Offset *int `form:"offset" binding:"omitempty,min=0"`
+	Limit  *int `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// GetDeadLetters godoc
+// @Summary Недоставленные события
+// @Description Доставки всех подписок, исчерпавшие попытки, от новых к старым.
+// @Description Их можно отправить заново через POST /webhooks/dead-letters/{id}/redeliver.
+// @Tags webhooks
+// @Produce json
+// @Param offset query int false "С какой доставки выводить"
+// @Param limit query int false "Сколько доставок выводить, не больше 500"
+// @Success 200 {array} entities.WebhookDeliveryData "Доставки"
+// @Failure 400 {object} ErrorResponse "Неверный формат запроса"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Недоставленных событий нет"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/dead-letters [get]
+func (h *WebhooksHandler) GetDeadLetters(c *gin.Context) {
+	var params GetDeadLettersParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		bindingError(c, err, &params)
+		h.logger.Debug("Failed parsing request params", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	status := entities.DeliveryDead
+	deliveries, err := h.usecases.GetWebhookDeliveries.Execute(c.Request.Context(), entities.WebhookDeliveryFilterData{
+		Status: &status,
+		Offset: params.Offset,
+		Limit:  params.Limit,
+	})
+
+	if err != nil {
+		h.respondError(c, err, "Getting dead letters failed")
+		return
+	}
+
+	h.logger.Info("Dead letters retrieved successfully", "count", len(deliveries))
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverWebhook godoc
+// @Summary Повторная отправка недоставленного события
+// @Description Возвращает доставку из dead-letter в очередь, попытки считаются заново.
+// @Tags webhooks
+// @Param id path int true "ID доставки"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом получит сохранённый ответ"
+// @Success 202 "Доставка поставлена в очередь"
+// @Failure 400 {object} ErrorResponse "Неверный формат ID"
+// @Failure 401 {object} ErrorResponse "Требуется API-ключ или JWT"
+// @Failure 403 {object} ErrorResponse "Недостаточно прав, нужна роль admin"
+// @Failure 422 {object} ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 429 {object} ErrorResponse "Превышен лимит запросов, повторить можно через Retry-After секунд"
+// @Failure 404 {object} ErrorResponse "Недоставленное событие не найдено"
+// @Failure 500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @x-required-role "admin"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /webhooks/dead-letters/{id}/redeliver [post]
+func (h *WebhooksHandler) RedeliverWebhook(c *gin.Context) {
+	deliveryIDParam := c.Param("id")
+	deliveryID, err := strconv.ParseInt(deliveryIDParam, 10, 64)
+
+	if err != nil {
+		h.logger.Debug("Missing or invalid ID param for request", "ID param", deliveryIDParam)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "delivery ID is required"})
+		return
+	}
+
+	if err := h.usecases.RedeliverWebhook.Execute(c.Request.Context(), deliveryID); err != nil {
+		h.respondError(c, err, "Redelivering webhook failed")
+		return
+	}
+
+	h.logger.Info("Webhook delivery requeued", "id", deliveryID)
+	c.Status(http.StatusAccepted)
+}
+
+func (h *WebhooksHandler) webhookID(c *gin.Context) (int, bool) {
+	webhookIDParam := c.Param("id")
+	webhookID, err := strconv.Atoi(webhookIDParam)
+
+	if err != nil {
+		h.logger.Debug("Missing or invalid ID param for request", "ID param", webhookIDParam)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "webhook ID is required"})
+		return 0, false
+	}
+
+	return webhookID, true
+}
+
+func (h *WebhooksHandler) respondError(c *gin.Context, err error, msg string) {
+	if respondAccessDenied(c, h.logger, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, errs.ErrInvalidInput):
+		h.logger.Debug("Invalid webhook request", "error", err)
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, errs.ErrNotFound):
+		h.logger.Debug("Webhook not found", "error", err)
+		c.JSON(http.StatusNotFound, NotFoundResponse)
+	default:
+		h.logger.Error(msg, "error", err)
+		c.JSON(http.StatusInternalServerError, ServerErrorResponse)
+	}
+}
+
+func eventTypes(names []string) []entities.EventType {
+	events := make([]entities.EventType, 0, len(names))
+	for _, name := range names {
+		events = append(events, entities.EventType(name))
+	}
+	return events
+}
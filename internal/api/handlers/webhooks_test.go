@@ -0,0 +1,158 @@
+package handlers_test
+
+import (
+	"bytes"
+	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupWebhooksRouter(
+	mockLogger *MockLogger,
+	mockCreate *MockCreateWebhookUseCase,
+	mockDeliveries *MockGetWebhookDeliveriesUseCase,
+) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	useCases := usecase.UseCases{
+		CreateWebhook:        mockCreate,
+		GetWebhookDeliveries: mockDeliveries,
+	}
+
+	handler := handlers.NewWebhooksHandler(mockLogger, useCases)
+	r.POST("/webhooks", handler.CreateWebhook)
+	r.GET("/webhook/:id/deliveries", handler.GetWebhookDeliveries)
+	r.GET("/webhooks/dead-letters", handler.GetDeadLetters)
+	return r
+}
+
+// Секрет возвращается в ответе на создание
+func TestWebhooksHandler_CreateWebhook_Success(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockCreate := new(MockCreateWebhookUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockCreate.On("Execute", mock.Anything, entities.NewWebhookData{
+		URL:    "https://example.com/hook",
+		Events: []entities.EventType{entities.EventSongCreated, entities.EventSongDeleted},
+	}).Return(&entities.CreatedWebhookData{
+		WebhookData: entities.WebhookData{
+			ID:     1,
+			URL:    "https://example.com/hook",
+			Events: []entities.EventType{entities.EventSongCreated, entities.EventSongDeleted},
+			Active: true,
+		},
+		Secret: "whsec_test",
+	}, nil)
+
+	router := setupWebhooksRouter(mockLogger, mockCreate, nil)
+
+	body := `{"url": "https://example.com/hook", "events": ["song.created", "song.deleted"]}`
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+
+	var response map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "whsec_test", response["secret"])
+	assert.Equal(t, true, response["active"])
+	mockCreate.AssertExpectations(t)
+}
+
+func TestWebhooksHandler_CreateWebhook_UnknownEvent(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockCreate := new(MockCreateWebhookUseCase)
+
+	mockLogger.On("Debug", "Failed parsing request params", mock.Anything).Once()
+
+	router := setupWebhooksRouter(mockLogger, mockCreate, nil)
+
+	body := `{"url": "https://example.com/hook", "events": ["song.played"]}`
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	mockCreate.AssertNotCalled(t, "Execute")
+}
+
+func TestWebhooksHandler_CreateWebhook_Forbidden(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockCreate := new(MockCreateWebhookUseCase)
+
+	mockLogger.On("Debug", "Permission denied", mock.Anything).Once()
+	mockCreate.On("Execute", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w admin role required", errs.ErrForbidden))
+
+	router := setupWebhooksRouter(mockLogger, mockCreate, nil)
+
+	body := `{"url": "https://example.com/hook", "events": ["song.created"]}`
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+// ID подписки из пути и фильтр по состоянию передаются в юзкейс
+func TestWebhooksHandler_GetWebhookDeliveries_Filters(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockDeliveries := new(MockGetWebhookDeliveriesUseCase)
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockDeliveries.On("Execute", mock.Anything, mock.MatchedBy(func(filter entities.WebhookDeliveryFilterData) bool {
+		return *filter.WebhookID == 3 && *filter.Status == entities.DeliveryPending && *filter.Limit == 20
+	})).Return([]entities.WebhookDeliveryData{
+		{ID: 10, WebhookID: 3, EventID: 7, EventType: entities.EventSongUpdated, Status: entities.DeliveryPending, Attempts: 2},
+	}, nil)
+
+	router := setupWebhooksRouter(mockLogger, nil, mockDeliveries)
+
+	req, _ := http.NewRequest(http.MethodGet, "/webhook/3/deliveries?status=pending&limit=20", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response []map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "song.updated", response[0]["event_type"])
+	mockDeliveries.AssertExpectations(t)
+}
+
+func TestWebhooksHandler_GetDeadLetters_NotFound(t *testing.T) {
+	mockLogger := new(MockLogger)
+	mockDeliveries := new(MockGetWebhookDeliveriesUseCase)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockDeliveries.On("Execute", mock.Anything, mock.MatchedBy(func(filter entities.WebhookDeliveryFilterData) bool {
+		return filter.WebhookID == nil && *filter.Status == entities.DeliveryDead
+	})).Return(nil, fmt.Errorf("%w webhook deliveries not found", errs.ErrNotFound))
+
+	router := setupWebhooksRouter(mockLogger, nil, mockDeliveries)
+
+	req, _ := http.NewRequest(http.MethodGet, "/webhooks/dead-letters", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	mockDeliveries.AssertExpectations(t)
+}
@@ -3,14 +3,23 @@ package app
 import (
 	"em-library/config"
 	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/internal/jobs"
 	"em-library/internal/repository"
 	"em-library/internal/services"
 	"em-library/internal/usecase"
+	"em-library/migrations"
 	"em-library/pkg/database"
+	"time"
 )
 
 type Application struct {
-	Handlers *handlers.Handlers
+	UseCases         usecase.UseCases
+	Handlers         *handlers.Handlers
+	RefreshStale     *jobs.StaleSongsRefresher
+	DetectDuplicates *jobs.DuplicatesDetector
+	DispatchWebhooks *jobs.WebhookDispatcher
+	ConfigReloader   *ConfigReloader
 }
 
 func New(cfg *config.Config, db *database.Database) *Application {
@@ -18,18 +27,84 @@ func New(cfg *config.Config, db *database.Database) *Application {
 		TransactionManager: db.TransactionManager,
 		SongRepo:           repository.NewPGSongRepository(db, cfg.Logger),
 		LyricsRepo:         repository.NewPGLyricsRepository(db, cfg.Logger),
+		DraftRepo:          repository.NewPGSongDraftRepository(db, cfg.Logger),
+		DuplicateRepo:      repository.NewPGDuplicateRepository(db, cfg.Logger),
+		APIKeyRepo:         repository.NewPGAPIKeyRepository(db, cfg.Logger),
+		AuditRepo:          repository.NewPGAuditRepository(db, cfg.Logger),
+		EventRepo:          repository.NewPGEventRepository(db, cfg.Logger),
+		WebhookRepo:        repository.NewPGWebhookRepository(db, cfg.Logger),
+		IdempotencyRepo:    repository.NewPGIdempotencyRepository(db, cfg.Logger),
 	}
 
+	infoService := services.NewRESTSongInfoService(cfg.Services, cfg.Logger)
+	rateLimits := usecase.NewRateLimitRules(rateLimitRules(cfg.RateLimit))
+	configReloader := NewConfigReloader(cfg, infoService, rateLimits)
+
 	services := usecase.Services{
-		SongInfoService: services.NewRESTSongInfoService(cfg.Services, cfg.Logger),
+		SongInfoService: infoService,
+		ContentFilter:   services.NewWordListContentFilter(cfg.ContentFilter, cfg.Logger),
+		TokenVerifier:   services.NewJWKSTokenVerifier(cfg.Auth, cfg.Logger),
+		RateLimiter:     services.NewMemoryRateLimiter(),
+		HealthChecks: []usecase.HealthCheck{
+			{Name: "postgres", Checker: db, Critical: true},
+			{Name: "migrations", Checker: database.NewMigrationsHealthCheck(db, migrations.FS), Critical: true},
+		},
+		ConfigSource:  configReloader,
+		WebhookSender: services.NewHTTPWebhookSender(cfg.Webhooks, cfg.Logger),
+		EventBroker:   services.NewEventBroker(cfg.Changes.StreamBuffer),
+	}
+
+	// общий лимит для нескольких экземпляров сервиса хранится в базе
+	if cfg.RateLimit.Backend == "postgres" {
+		services.RateLimiter = repository.NewPGRateLimitRepository(db, cfg.Logger)
 	}
 
-	usecases := usecase.NewUseCases(repos, services)
+	if cfg.Health.CheckInfoService {
+		services.HealthChecks = append(services.HealthChecks, usecase.HealthCheck{
+			Name:    "infoservice",
+			Checker: infoService,
+		})
+	}
+
+	options := usecase.Options{
+		RefreshPolicy:     entities.RefreshPolicy(cfg.Refresh.Policy),
+		DuplicateMinScore: cfg.Duplicates.MinScore,
+		RateLimits:        rateLimits,
+		HealthTimeout:     time.Duration(cfg.Health.Timeout) * time.Millisecond,
+
+		WebhookBatchSize: cfg.Webhooks.BatchSize,
+		// доставка откладывается с запасом, чтобы не отправить её повторно, пока ждём ответа подписчика
+		WebhookLease: 2 * time.Duration(cfg.Webhooks.Timeout) * time.Millisecond,
+		WebhookRetryPolicy: entities.WebhookRetryPolicy{
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			Backoff:     time.Duration(cfg.Webhooks.Backoff) * time.Second,
+			MaxBackoff:  time.Duration(cfg.Webhooks.MaxBackoff) * time.Second,
+		},
+
+		ChangesPollInterval: time.Duration(cfg.Changes.PollInterval) * time.Millisecond,
+
+		IdempotencyTTL: time.Duration(cfg.Idempotency.TTL) * time.Second,
+	}
+
+	usecases := usecase.NewUseCases(repos, services, options)
 
 	handlers := handlers.NewHandlers(cfg, usecases)
 
 	return &Application{
-		Handlers: handlers,
+		UseCases:         usecases,
+		Handlers:         handlers,
+		RefreshStale:     jobs.NewStaleSongsRefresher(cfg.Refresh, cfg.Logger, usecases),
+		DetectDuplicates: jobs.NewDuplicatesDetector(cfg.Duplicates, cfg.Logger, usecases),
+		DispatchWebhooks: jobs.NewWebhookDispatcher(cfg.Webhooks, cfg.Logger, usecases),
+		ConfigReloader:   configReloader,
 	}
 
 }
+
+func rateLimitRules(cfg config.RateLimitConfig) map[entities.RateLimitGroup]entities.RateLimitRule {
+	return map[entities.RateLimitGroup]entities.RateLimitRule{
+		entities.RateLimitRead:     entities.RateLimitRule(cfg.Read),
+		entities.RateLimitWrite:    entities.RateLimitRule(cfg.Write),
+		entities.RateLimitUpstream: entities.RateLimitRule(cfg.Upstream),
+	}
+}
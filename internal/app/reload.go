@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/services"
+	"em-library/internal/usecase"
+	"em-library/pkg/logging"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Параметры, которые применяются без перезапуска сервиса
+var reloadableKeys = []string{
+	"EMLIB_LOG_LEVEL",
+	"EMLIB_INFOSERVICE_URL",
+	"EMLIB_INFOSERVICE_TIMEOUT",
+	"EMLIB_RATE_LIMIT_READ_PER_MINUTE",
+	"EMLIB_RATE_LIMIT_READ_BURST",
+	"EMLIB_RATE_LIMIT_WRITE_PER_MINUTE",
+	"EMLIB_RATE_LIMIT_WRITE_BURST",
+	"EMLIB_RATE_LIMIT_UPSTREAM_PER_MINUTE",
+	"EMLIB_RATE_LIMIT_UPSTREAM_BURST",
+}
+
+// Перечитывает конфигурацию по SIGHUP или при изменении файла конфигурации и применяет
+// к работающим компонентам уровень логирования, адрес и таймаут внешнего сервиса и лимиты
+// запросов. Если новая конфигурация не проходит проверку, продолжает работать старая.
+type ConfigReloader struct {
+	logger      config.Logger
+	infoService *services.RESTSongInfoService
+	rateLimits  *usecase.RateLimitRules
+
+	mu sync.Mutex
+	// конфигурация при старте, с ней работают параметры, которые требуют перезапуска
+	startup *config.Config
+	// последняя успешно применённая конфигурация
+	applied    *config.Config
+	lastReload *entities.ConfigReloadResult
+}
+
+func NewConfigReloader(cfg *config.Config, is *services.RESTSongInfoService, rl *usecase.RateLimitRules) *ConfigReloader {
+	return &ConfigReloader{
+		logger:      cfg.Logger,
+		infoService: is,
+		rateLimits:  rl,
+		startup:     cfg,
+		applied:     cfg,
+	}
+}
+
+// Блокируется до отмены контекста
+func (r *ConfigReloader) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var fileEvents <-chan struct{}
+	if r.startup.File != "" {
+		events, err := r.watchFile(ctx, r.startup.File)
+		if err != nil {
+			r.logger.Error("Failed to watch config file, reload only by SIGHUP", "file", r.startup.File, "error", err)
+		}
+		fileEvents = events
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.Reload("signal")
+		case <-fileEvents:
+			r.Reload("file")
+		}
+	}
+}
+
+func (r *ConfigReloader) Reload(trigger string) entities.ConfigReloadResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := entities.ConfigReloadResult{
+		Time:    time.Now(),
+		Trigger: trigger,
+	}
+	defer func() { r.lastReload = &result }()
+
+	cfg, err := r.applied.Reload()
+	if err != nil {
+		for _, e := range unwrapErrors(err) {
+			result.Errors = append(result.Errors, e.Error())
+		}
+		r.logger.Error("Config reload failed, keeping current config", "trigger", trigger, "error", err)
+		return result
+	}
+
+	for _, key := range r.applied.Diff(cfg) {
+		if slices.Contains(reloadableKeys, key) {
+			result.Applied = append(result.Applied, key)
+		}
+	}
+	for _, key := range r.startup.Diff(cfg) {
+		if !slices.Contains(reloadableKeys, key) {
+			result.RestartRequired = append(result.RestartRequired, key)
+		}
+	}
+
+	logging.SetLevel(cfg.Log.Level)
+	r.infoService.Reload(cfg.Services)
+	r.rateLimits.Set(rateLimitRules(cfg.RateLimit))
+
+	r.applied = cfg
+	result.Success = true
+
+	r.logger.Info("Config reloaded", "trigger", trigger, "applied", result.Applied, "restart_required", result.RestartRequired)
+	return result
+}
+
+// Для перезагружаемых параметров показывает применённые значения, для остальных — значения при старте
+func (r *ConfigReloader) ConfigState() entities.ConfigState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	applied := make(map[string]config.Setting)
+	for _, s := range r.applied.Settings() {
+		applied[s.Key] = s
+	}
+
+	state := entities.ConfigState{
+		File:       r.startup.File,
+		LastReload: r.lastReload,
+	}
+	for _, s := range r.startup.Settings() {
+		if a, ok := applied[s.Key]; ok && slices.Contains(reloadableKeys, s.Key) {
+			s = a
+		}
+		state.Settings = append(state.Settings, entities.ConfigSetting{
+			Key:    s.Key,
+			Value:  s.Value,
+			Source: s.Source,
+		})
+	}
+
+	return state
+}
+
+// Следит за каталогом, а не за самим файлом: многие редакторы при сохранении
+// заменяют файл целиком. Несколько событий подряд схлопываются в одно.
+func (r *ConfigReloader) watchFile(ctx context.Context, file string) (<-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	file = filepath.Clean(file)
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	events := make(chan struct{}, 1)
+
+	go func() {
+		defer watcher.Close()
+
+		var debounce <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-watcher.Events:
+				if filepath.Clean(event.Name) == file && !event.Has(fsnotify.Chmod) {
+					debounce = time.After(500 * time.Millisecond)
+				}
+			case err := <-watcher.Errors:
+				r.logger.Warn("Config file watcher error", "error", err)
+			case <-debounce:
+				debounce = nil
+				select {
+				case events <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func unwrapErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+const apiKeysUsage = `usage: main apikey <command> [flags]
+
+commands:
+  create -name <name> [-role viewer|editor|admin]
+                        выпустить новый ключ
+  list                  показать все ключи
+  revoke -id <id>       отозвать ключ`
+
+// Управление API-ключами из командной строки: main apikey create|list|revoke.
+type APIKeys struct {
+	out      io.Writer
+	usecases usecase.UseCases
+}
+
+func NewAPIKeys(out io.Writer, u usecase.UseCases) *APIKeys {
+	return &APIKeys{
+		out:      out,
+		usecases: u,
+	}
+}
+
+// Команды выполняются от имени системного клиента с правами admin.
+func (c *APIKeys) Run(ctx context.Context, args []string) error {
+	ctx = entities.ContextWithPrincipal(ctx, entities.SystemPrincipal)
+
+	if len(args) == 0 {
+		return errors.New(apiKeysUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		return c.create(ctx, args[1:])
+	case "list":
+		return c.list(ctx)
+	case "revoke":
+		return c.revoke(ctx, args[1:])
+	}
+
+	return fmt.Errorf("unknown command %q\n%s", args[0], apiKeysUsage)
+}
+
+func (c *APIKeys) create(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	name := fs.String("name", "", "название ключа, например имя сервиса-клиента")
+	role := fs.String("role", string(entities.RoleViewer), "роль ключа: viewer, editor или admin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	key, err := c.usecases.CreateAPIKey.Execute(ctx, *name, entities.Role(*role))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "id:   %d\nname: %s\nrole: %s\nkey:  %s\n\n", key.ID, key.Name, key.Role, key.Key)
+	fmt.Fprintln(c.out, "Сохраните ключ: он показывается только один раз.")
+
+	return nil
+}
+
+func (c *APIKeys) list(ctx context.Context) error {
+	keys, err := c.usecases.GetAPIKeys.Execute(ctx)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			fmt.Fprintln(c.out, "no api keys")
+			return nil
+		}
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tROLE\tPREFIX\tCREATED\tLAST USED\tREVOKED")
+	for _, k := range keys {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			k.ID, k.Name, k.Role, k.Prefix, formatTime(&k.CreatedAt), formatTime(k.LastUsedAt), formatTime(k.RevokedAt))
+	}
+
+	return w.Flush()
+}
+
+func (c *APIKeys) revoke(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("apikey revoke", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	id := fs.Int("id", 0, "ID отзываемого ключа")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := c.usecases.RevokeAPIKey.Execute(ctx, *id); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "api key %d revoked\n", *id)
+	return nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
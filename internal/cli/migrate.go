@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"em-library/pkg/database"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pressly/goose/v3"
+)
+
+const migrateUsage = `usage: main migrate <command> [flags]
+
+commands:
+  up [-dry-run]         применить все новые миграции
+  down [-to <version>] [-dry-run]
+                        откатить последнюю миграцию или все миграции новее version
+  redo                  откатить и заново применить последнюю миграцию
+  status                показать применённые и ожидающие миграции
+
+С -dry-run команда только выводит миграции и их SQL, ничего не меняя в базе.`
+
+// Управление миграциями из командной строки: main migrate up|down|redo|status.
+// Работает без пула соединений и юзкейсов, поэтому годится и для пустой базы.
+type Migrate struct {
+	out      io.Writer
+	migrator *database.Migrator
+}
+
+func NewMigrate(out io.Writer, m *database.Migrator) *Migrate {
+	return &Migrate{
+		out:      out,
+		migrator: m,
+	}
+}
+
+func (c *Migrate) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		return c.up(ctx, args[1:])
+	case "down":
+		return c.down(ctx, args[1:])
+	case "redo":
+		results, err := c.migrator.Redo(ctx)
+		c.printResults(results)
+		return err
+	case "status":
+		return c.status(ctx)
+	}
+
+	return fmt.Errorf("unknown command %q\n%s", args[0], migrateUsage)
+}
+
+func (c *Migrate) up(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("migrate up", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	dryRun := fs.Bool("dry-run", false, "только показать миграции, которые будут применены")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *dryRun {
+		plan, err := c.migrator.PlanUp(ctx)
+		if err != nil {
+			return err
+		}
+		c.printPlan("apply", plan)
+		return nil
+	}
+
+	results, err := c.migrator.Up(ctx)
+	c.printResults(results)
+	if err == nil && len(results) == 0 {
+		fmt.Fprintln(c.out, "no pending migrations")
+	}
+	return err
+}
+
+func (c *Migrate) down(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	to := fs.Int64("to", 0, "откатить все миграции новее этой версии")
+	dryRun := fs.Bool("dry-run", false, "только показать миграции, которые будут откачены")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *dryRun {
+		plan, err := c.migrator.PlanDown(ctx, *to)
+		if err != nil {
+			return err
+		}
+		c.printPlan("roll back", plan)
+		return nil
+	}
+
+	results, err := c.migrator.Down(ctx, *to)
+	c.printResults(results)
+	return err
+}
+
+func (c *Migrate) status(ctx context.Context) error {
+	statuses, err := c.migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tMIGRATION\tSTATE\tAPPLIED")
+	for _, s := range statuses {
+		applied := "-"
+		if !s.AppliedAt.IsZero() {
+			applied = s.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Source.Version, s.Source.Path, s.State, applied)
+	}
+
+	return w.Flush()
+}
+
+func (c *Migrate) printPlan(action string, plan []database.PendingMigration) {
+	if len(plan) == 0 {
+		fmt.Fprintf(c.out, "no migrations to %s\n", action)
+		return
+	}
+
+	fmt.Fprintf(c.out, "would %s %d migrations:\n", action, len(plan))
+	for _, m := range plan {
+		fmt.Fprintf(c.out, "\n-- %s\n%s\n", m.Name, m.SQL)
+	}
+}
+
+func (c *Migrate) printResults(results []*goose.MigrationResult) {
+	for _, r := range results {
+		fmt.Fprintln(c.out, r)
+	}
+}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const songsUsage = `usage: main <command> [flags]
+
+commands:
+  import [-enrich never|auto|fill_missing] <file>
+                        добавить песни из JSON-файла, уже существующие пропускаются
+  export <file>         выгрузить все песни с текстами в JSON-файл
+  refresh [-group <group>] [-song <song>] [-policy overwrite|fill_empty|draft] [-limit <n>]
+                        обновить песни из внешнего сервиса
+  reindex               перепроверить тексты по спискам слов и заново найти дубликаты
+
+Вместо <file> можно передать "-", тогда используется stdin или stdout.`
+
+// Тексты хранятся с экранированными переводами строк, куплеты разделены пустой строкой
+const verseSeparator = "\\n\\n"
+
+// Сколько песен запрашивается за раз при выгрузке
+const exportPageSize = 500
+
+// Песня в файле импорта и выгрузки
+type songRecord struct {
+	Group       string `json:"group"`
+	Song        string `json:"song"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	Link        string `json:"link,omitempty"`
+	Lyrics      string `json:"lyrics,omitempty"`
+}
+
+// Обслуживание библиотеки из командной строки: main import|export|refresh|reindex.
+type Songs struct {
+	out      io.Writer
+	usecases usecase.UseCases
+}
+
+func NewSongs(out io.Writer, u usecase.UseCases) *Songs {
+	return &Songs{
+		out:      out,
+		usecases: u,
+	}
+}
+
+// Команды выполняются от имени системного клиента с правами admin.
+func (c *Songs) Run(ctx context.Context, args []string) error {
+	ctx = entities.ContextWithPrincipal(ctx, entities.SystemPrincipal)
+
+	if len(args) == 0 {
+		return errors.New(songsUsage)
+	}
+
+	switch args[0] {
+	case "import":
+		return c.importSongs(ctx, args[1:])
+	case "export":
+		return c.exportSongs(ctx, args[1:])
+	case "refresh":
+		return c.refresh(ctx, args[1:])
+	case "reindex":
+		return c.reindex(ctx)
+	}
+
+	return fmt.Errorf("unknown command %q\n%s", args[0], songsUsage)
+}
+
+func (c *Songs) importSongs(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	enrich := fs.String("enrich", string(entities.EnrichNever), "обращение к внешнему сервису: never, auto или fill_missing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New(songsUsage)
+	}
+
+	in := os.Stdin
+	if fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var records []songRecord
+	if err := json.NewDecoder(in).Decode(&records); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", fs.Arg(0), err)
+	}
+
+	var created, skipped int
+	for i, r := range records {
+		data := entities.NewSongData{
+			Band:   r.Group,
+			Song:   r.Song,
+			Link:   r.Link,
+			Lyrics: r.Lyrics,
+			Enrich: entities.EnrichMode(*enrich),
+		}
+		if r.ReleaseDate != "" {
+			releaseDate, err := time.Parse(time.DateOnly, r.ReleaseDate)
+			if err != nil {
+				return fmt.Errorf("song %d (%s - %s): release_date must be in format 2006-01-02", i+1, r.Group, r.Song)
+			}
+			data.ReleaseDate = releaseDate
+		}
+
+		if _, err := c.usecases.CreateSong.Execute(ctx, data); err != nil {
+			if errors.Is(err, errs.ErrAlreadyExists) {
+				skipped++
+				continue
+			}
+			return fmt.Errorf("song %d (%s - %s): %w", i+1, r.Group, r.Song, err)
+		}
+		created++
+	}
+
+	fmt.Fprintf(c.out, "imported %d songs, skipped %d existing\n", created, skipped)
+	return nil
+}
+
+func (c *Songs) exportSongs(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New(songsUsage)
+	}
+
+	records := []songRecord{}
+	for offset := 0; ; offset += exportPageSize {
+		limit := exportPageSize
+		songs, err := c.usecases.GetSongList.Execute(ctx, entities.SongFilterData{Offset: &offset, Limit: &limit})
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return err
+		}
+
+		for _, song := range songs {
+			verses, err := c.usecases.GetSongLyrics.Execute(ctx, song.ID, entities.LyricsFilterData{})
+			if err != nil && !errors.Is(err, errs.ErrNotFound) {
+				return fmt.Errorf("song %d: %w", song.ID, err)
+			}
+
+			contents := make([]string, len(verses))
+			for i, verse := range verses {
+				contents[i] = verse.Content
+			}
+
+			record := songRecord{
+				Group:  song.Band,
+				Song:   song.Song,
+				Link:   song.Link,
+				Lyrics: strings.Join(contents, verseSeparator),
+			}
+			if !song.ReleaseDate.IsZero() {
+				record.ReleaseDate = song.ReleaseDate.Format(time.DateOnly)
+			}
+			records = append(records, record)
+		}
+
+		if len(songs) < exportPageSize {
+			break
+		}
+	}
+
+	out := os.Stdout
+	if args[0] != "-" {
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(records); err != nil {
+		return err
+	}
+
+	if args[0] != "-" {
+		fmt.Fprintf(c.out, "exported %d songs to %s\n", len(records), args[0])
+	}
+	return nil
+}
+
+func (c *Songs) refresh(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("refresh", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	group := fs.String("group", "", "обновить только песни группы")
+	song := fs.String("song", "", "обновить только песни с названием")
+	policy := fs.String("policy", string(entities.RefreshFillEmpty), "политика слияния: overwrite, fill_empty или draft")
+	limit := fs.Int("limit", 50, "сколько песен обновить")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filter := entities.SongFilterData{Limit: limit}
+	if *group != "" {
+		filter.Band = group
+	}
+	if *song != "" {
+		filter.Song = song
+	}
+
+	results, err := c.usecases.RefreshSongs.Execute(ctx, filter, entities.RefreshPolicy(*policy))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			fmt.Fprintln(c.out, "no songs to refresh")
+			return nil
+		}
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tAPPLIED\tCHANGES\tERROR")
+	for _, r := range results {
+		fields := make([]string, len(r.Changes))
+		for i, change := range r.Changes {
+			fields[i] = change.Field
+		}
+		fmt.Fprintf(w, "%d\t%t\t%s\t%s\n", r.SongID, r.Applied, strings.Join(fields, ","), r.Error)
+	}
+
+	return w.Flush()
+}
+
+func (c *Songs) reindex(ctx context.Context) error {
+	updated, err := c.usecases.ReindexSongs.Execute(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "explicit flag fixed for %d songs\n", updated)
+
+	count, err := c.usecases.DetectDuplicates.Execute(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "found %d possible duplicate pairs\n", count)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Действие над сущностью, попадающее в журнал аудита
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditMerge   AuditAction = "merge"
+	AuditRefresh AuditAction = "refresh"
+)
+
+const AuditEntitySong = "song"
+
+// Состояние песни вместе с текстом до или после изменения
+type SongAuditData struct {
+	ID          int    `json:"id"`
+	Band        string `json:"group"`
+	Song        string `json:"song"`
+	ReleaseDate string `json:"release_date"`
+	Link        string `json:"link"`
+	Explicit    *bool  `json:"explicit"`
+	Lyrics      string `json:"lyrics"`
+}
+
+// DTO для новой записи журнала. Before и After сохраняются как JSON,
+// nil означает, что состояния нет (до создания или после удаления).
+type NewAuditRecordData struct {
+	Actor     string
+	Action    AuditAction
+	Entity    string
+	EntityID  int
+	Before    any
+	After     any
+	RequestID string
+}
+
+type AuditRecordData struct {
+	ID        int64           `json:"id"`
+	Actor     string          `json:"actor"`
+	Action    AuditAction     `json:"action"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Before    json.RawMessage `json:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" swaggertype:"object"`
+	RequestID string          `json:"request_id"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// DTO для фильтрации журнала аудита
+type AuditFilterData struct {
+	Entity   *string
+	EntityID *int
+	Actor    *string
+	Action   *AuditAction
+	From     *time.Time
+	To       *time.Time
+	Offset   *int
+	Limit    *int
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+// Способ, которым клиент подтвердил свою личность
+type AuthMethod string
+
+const (
+	AuthAPIKey AuthMethod = "api_key"
+	AuthJWT    AuthMethod = "jwt"
+	AuthSystem AuthMethod = "system"
+	AuthNone   AuthMethod = "none"
+)
+
+// Роль клиента. Каждая следующая роль включает права предыдущих:
+// viewer только читает, editor создаёт и меняет песни, admin ещё и удаляет.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Есть ли у роли права требуемой роли. Неизвестная роль не разрешает ничего.
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+// Аутентифицированный клиент, от имени которого выполняется запрос
+type Principal struct {
+	Subject string
+	Name    string
+	Method  AuthMethod
+	Role    Role
+}
+
+// Клиент для фоновых задач и команд CLI, которые запускает сам сервис
+var SystemPrincipal = &Principal{
+	Subject: "system",
+	Name:    "system",
+	Method:  AuthSystem,
+	Role:    RoleAdmin,
+}
+
+// Клиент для запросов при выключенной аутентификации
+var AnonymousPrincipal = &Principal{
+	Subject: "anonymous",
+	Name:    "anonymous",
+	Method:  AuthNone,
+	Role:    RoleAdmin,
+}
+
+// Учётные данные из запроса: API-ключ из X-API-Key или токен из Authorization: Bearer
+type Credentials struct {
+	APIKey string
+	Bearer string
+}
+
+// DTO для сохранения нового API-ключа. Сам ключ не хранится, только его хэш.
+type NewAPIKeyData struct {
+	Name   string
+	Prefix string
+	Hash   string
+	Role   Role
+}
+
+type APIKeyData struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       Role       `json:"role"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// Только что выпущенный ключ. Key показывается один раз и больше нигде не сохраняется.
+type CreatedAPIKeyData struct {
+	APIKeyData
+	Key string `json:"key"`
+}
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
@@ -0,0 +1,74 @@
+package entities
+
+// Действие над песней в пакетной операции
+type BatchAction string
+
+const (
+	BatchUpdate BatchAction = "update"
+	BatchDelete BatchAction = "delete"
+)
+
+// Как выполнять пакет: все операции в одной транзакции или каждую отдельно
+type BatchMode string
+
+const (
+	// при первой ошибке откатываются все операции
+	BatchAtomic BatchMode = "atomic"
+	// каждая операция в своей транзакции, ошибка одной не мешает остальным
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// Результат операции пакета
+type BatchItemStatus string
+
+const (
+	BatchItemApplied BatchItemStatus = "applied"
+	BatchItemFailed  BatchItemStatus = "failed"
+	// операция выполнилась, но была отменена из-за ошибки другой операции атомарного пакета
+	BatchItemRolledBack BatchItemStatus = "rolled_back"
+	// операция не выполнялась, потому что атомарный пакет уже завершился ошибкой
+	BatchItemSkipped BatchItemStatus = "skipped"
+	// пробный запуск: операция будет выполнена
+	BatchItemPlanned BatchItemStatus = "planned"
+)
+
+// DTO для одной операции пакета. Update используется только в BatchUpdate.
+type BatchOperationData struct {
+	Action BatchAction
+	SongID int
+	Update UpdateSongData
+}
+
+// DTO для пакетного изменения песен. Операции задаются списком Operations
+// или фильтром Filter с одним действием Action для всех найденных песен.
+type BatchSongsData struct {
+	Operations []BatchOperationData
+
+	Filter *SongFilterData
+	Action BatchAction
+	Update UpdateSongData
+
+	Mode   BatchMode
+	DryRun bool
+}
+
+// Результат операции пакета. Song — состояние песни после операции, при удалении — до неё.
+type BatchItemResultData struct {
+	Index  int             `json:"index"`
+	Action BatchAction     `json:"action"`
+	SongID int             `json:"id"`
+	Status BatchItemStatus `json:"status"`
+	Error  string          `json:"error,omitempty"`
+	Song   *SongAuditData  `json:"song,omitempty"`
+	// исходная ошибка для лога, клиенту отдаётся только Error
+	Err error `json:"-"`
+}
+
+// Результат пакета
+type BatchResultData struct {
+	Mode      BatchMode             `json:"mode"`
+	DryRun    bool                  `json:"dry_run"`
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Items     []BatchItemResultData `json:"items"`
+}
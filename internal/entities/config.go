@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+// Параметр конфигурации с итоговым значением и источником: default, file или env
+type ConfigSetting struct {
+	Key    string `json:"key" example:"EMLIB_INFOSERVICE_TIMEOUT"`
+	Value  string `json:"value" example:"500"`
+	Source string `json:"source" example:"file"`
+}
+
+type ConfigReloadResult struct {
+	Time time.Time `json:"time"`
+	// Что вызвало перезагрузку: signal или file
+	Trigger string   `json:"trigger" example:"signal"`
+	Success bool     `json:"success"`
+	Errors  []string `json:"errors,omitempty"`
+	// Параметры, новые значения которых применены без перезапуска
+	Applied []string `json:"applied,omitempty" example:"EMLIB_LOG_LEVEL"`
+	// Параметры, которые изменились, но вступят в силу только после перезапуска
+	RestartRequired []string `json:"restart_required,omitempty" example:"EMLIB_DB_HOST"`
+}
+
+// Конфигурация, с которой сейчас работает сервис, и итог последней перезагрузки
+type ConfigState struct {
+	File       string              `json:"file,omitempty" example:"config.yaml"`
+	Settings   []ConfigSetting     `json:"settings"`
+	LastReload *ConfigReloadResult `json:"last_reload,omitempty"`
+}
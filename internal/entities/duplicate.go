@@ -0,0 +1,38 @@
+package entities
+
+// DTO песни вместе с текстом для поиска дубликатов
+type SongWithLyricsData struct {
+	SongData
+	Lyrics string
+}
+
+// DTO для сохранения найденной пары дубликатов
+type NewDuplicateData struct {
+	SongID      int
+	DuplicateID int
+	TitleScore  float64
+	LyricsScore *float64
+	Score       float64
+}
+
+// DTO для пары песен, похожих на дубликаты
+type DuplicateData struct {
+	Song        SongData `json:"song"`
+	Duplicate   SongData `json:"duplicate"`
+	TitleScore  float64  `json:"title_score"`
+	LyricsScore *float64 `json:"lyrics_score"`
+	Score       float64  `json:"score"`
+}
+
+// Параметры запроса списка дубликатов
+type DuplicateFilterData struct {
+	MinScore *float64
+	Offset   *int
+	Limit    *int
+}
+
+// DTO для слияния двух песен: MergeID сливается в KeepID и удаляется
+type MergeSongsData struct {
+	KeepID  int
+	MergeID int
+}
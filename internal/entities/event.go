@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Тип события об изменении библиотеки
+type EventType string
+
+const (
+	EventSongCreated   EventType = "song.created"
+	EventSongUpdated   EventType = "song.updated"
+	EventSongDeleted   EventType = "song.deleted"
+	EventLyricsUpdated EventType = "lyrics.updated"
+)
+
+var EventTypes = []EventType{EventSongCreated, EventSongUpdated, EventSongDeleted, EventLyricsUpdated}
+
+func (t EventType) Valid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DTO для нового события. Data — состояние песни после изменения,
+// для удалённой песни — состояние до удаления.
+type NewEventData struct {
+	Type      EventType
+	SongID    int
+	Data      SongAuditData
+	RequestID string
+}
+
+// Событие из ленты изменений. ID — номер в общей последовательности событий,
+// удаление песни приходит tombstone-событием с Deleted и последним состоянием песни.
+type EventData struct {
+	ID        int64           `json:"id"`
+	Type      EventType       `json:"type"`
+	SongID    int             `json:"song_id"`
+	Deleted   bool            `json:"deleted"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+	RequestID string          `json:"request_id"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Параметры выборки событий из outbox
+type EventFilterData struct {
+	Since int64
+	Limit int
+}
+
+// Параметры запроса ленты изменений: события с номером больше Since.
+// Если таких нет, запрос ждёт новых до Wait.
+type ChangesFilterData struct {
+	Since int64
+	Limit int
+	Wait  time.Duration
+}
+
+// Страница ленты изменений. Next передаётся в since следующего запроса.
+type ChangesData struct {
+	Changes []EventData `json:"changes"`
+	Next    int64       `json:"next"`
+}
+
+// Фильтр потока событий. LastEventID — номер последнего полученного события,
+// поток начинается со следующего. Без него поток начинается с новых событий.
+type EventStreamFilterData struct {
+	SongID      *int
+	Band        *string
+	LastEventID *int64
+}
+
+func (f EventStreamFilterData) Match(e EventData) bool {
+	if f.SongID != nil && e.SongID != *f.SongID {
+		return false
+	}
+
+	if f.Band != nil {
+		var song SongAuditData
+		if err := json.Unmarshal(e.Data, &song); err != nil || song.Band != *f.Band {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Поток событий подписки. Events закрывается, когда поток завершился,
+// после этого Err возвращает причину или nil, если клиент отключился.
+type EventStream struct {
+	Events <-chan EventData
+	Err    func() error
+}
@@ -0,0 +1,25 @@
+package entities
+
+// Состояние сервиса или одной из его зависимостей
+type HealthStatus string
+
+const (
+	HealthOK       HealthStatus = "ok"
+	HealthDegraded HealthStatus = "degraded"
+	HealthFail     HealthStatus = "fail"
+)
+
+type DependencyHealth struct {
+	Status    HealthStatus `json:"status" example:"ok"`
+	Critical  bool         `json:"critical" example:"true"`
+	LatencyMs int64        `json:"latency_ms" example:"3"`
+	Error     string       `json:"error,omitempty" example:"connection refused"`
+}
+
+// Отчёт о готовности сервиса. Если не работает критичная зависимость, статус fail,
+// если некритичная — degraded, и сервис продолжает принимать запросы.
+type HealthReport struct {
+	Status       HealthStatus                `json:"status" example:"ok"`
+	Message      string                      `json:"message,omitempty" example:"shutting down"`
+	Dependencies map[string]DependencyHealth `json:"dependencies,omitempty"`
+}
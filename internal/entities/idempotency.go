@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// Изменяющий запрос с заголовком Idempotency-Key
+type IdempotentRequest struct {
+	Key    string
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// Сохранённый ответ на запрос, который отдаётся повторно при его повторе
+type IdempotentResponse struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// Запись о ключе идемпотентности. Response пуст, пока первый запрос с ключом выполняется.
+type IdempotencyKeyData struct {
+	Key         string
+	Fingerprint string
+	Response    *IdempotentResponse
+	CreatedAt   time.Time
+}
@@ -17,3 +17,33 @@ type LyricsVerseData struct {
 	Index   int
 	Content string
 }
+
+// DTO для сохранённой ревизии текста песни
+type LyricsRevisionData struct {
+	SongID   int
+	Revision int
+	Content  string
+}
+
+// С чем сравнивать текущий текст: с внешним сервисом или с ревизией
+type LyricsDiffTarget struct {
+	Upstream bool
+	Revision int
+}
+
+// Результат сравнения текстов
+type LyricsDiffData struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Unified string          `json:"unified"`
+	Verses  []VerseDiffData `json:"verses"`
+}
+
+// Изменение одного куплета: equal, changed, added или removed
+type VerseDiffData struct {
+	Op        string  `json:"op"`
+	FromIndex *int    `json:"from_index,omitempty"`
+	ToIndex   *int    `json:"to_index,omitempty"`
+	From      *string `json:"from,omitempty"`
+	To        *string `json:"to,omitempty"`
+}
@@ -4,4 +4,5 @@ package entities
 type LyricsFilterData struct {
 	Offset *int
 	Limit  *int
+	Censor bool
 }
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// Группа маршрутов с общим лимитом запросов
+type RateLimitGroup string
+
+const (
+	RateLimitRead     RateLimitGroup = "read"
+	RateLimitWrite    RateLimitGroup = "write"
+	RateLimitUpstream RateLimitGroup = "upstream"
+)
+
+// Правило token bucket: в ведре помещается Burst запросов подряд,
+// и оно пополняется на PerMinute запросов в минуту.
+type RateLimitRule struct {
+	PerMinute int
+	Burst     int
+}
+
+// Правило с нулевыми значениями ничего не ограничивает
+func (r RateLimitRule) Enabled() bool {
+	return r.PerMinute > 0 && r.Burst > 0
+}
+
+// Сколько токенов будет в ведре через elapsed, если сейчас в нём tokens
+func (r RateLimitRule) Refill(tokens float64, elapsed time.Duration) float64 {
+	return math.Min(float64(r.Burst), tokens+elapsed.Seconds()*r.rate())
+}
+
+// Результат попытки по количеству токенов, оставшихся в ведре после неё
+func (r RateLimitRule) Result(tokens float64, allowed bool) RateLimitResult {
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Limit:     r.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     r.timeToRefill(float64(r.Burst) - tokens),
+	}
+	if !allowed {
+		result.RetryAfter = r.timeToRefill(1 - tokens)
+	}
+	return result
+}
+
+// токенов в секунду
+func (r RateLimitRule) rate() float64 {
+	return float64(r.PerMinute) / 60
+}
+
+func (r RateLimitRule) timeToRefill(tokens float64) time.Duration {
+	if tokens <= 0 || !r.Enabled() {
+		return 0
+	}
+	return time.Duration(tokens / r.rate() * float64(time.Second))
+}
+
+// Результат попытки выполнить запрос. Reset — через сколько ведро наполнится целиком,
+// RetryAfter — через сколько можно повторить отклонённый запрос.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Политика слияния данных из внешнего сервиса с сохранёнными
+type RefreshPolicy string
+
+const (
+	// заменить все отличающиеся поля
+	RefreshOverwrite RefreshPolicy = "overwrite"
+	// заполнить только пустые поля
+	RefreshFillEmpty RefreshPolicy = "fill_empty"
+	// ничего не менять, сохранить отличия черновиком
+	RefreshDraft RefreshPolicy = "draft"
+)
+
+// Изменение одного поля песни при обновлении
+type FieldChangeData struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Результат обновления одной песни
+type RefreshResultData struct {
+	SongID  int               `json:"id"`
+	Policy  RefreshPolicy     `json:"policy"`
+	Applied bool              `json:"applied"`
+	Changes []FieldChangeData `json:"changes"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// DTO для черновика изменений, предложенных внешним сервисом.
+// Пустые поля внешний сервис менять не предлагал.
+type SongDraftData struct {
+	SongID      int        `json:"song_id"`
+	ReleaseDate *time.Time `json:"release_date"`
+	Link        *string    `json:"link"`
+	Lyrics      *string    `json:"lyrics"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (d SongDraftData) MarshalJSON() ([]byte, error) {
+	type Alias SongDraftData
+	var releaseDate *string
+	if d.ReleaseDate != nil {
+		formatted := d.ReleaseDate.Format("2006-01-02")
+		releaseDate = &formatted
+	}
+	return json.Marshal(&struct {
+		ReleaseDate *string `json:"release_date"`
+		*Alias
+	}{
+		ReleaseDate: releaseDate,
+		Alias:       (*Alias)(&d),
+	})
+}
+
+// Параметры запроса списка черновиков
+type DraftFilterData struct {
+	SongID *int
+	Offset *int
+	Limit  *int
+}
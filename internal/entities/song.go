@@ -5,30 +5,55 @@ import (
 	"time"
 )
 
-// DTO для создания новой песни
+// Режим обогащения данных песни из внешнего сервиса при создании
+type EnrichMode string
+
+const (
+	// всегда запрашивать внешний сервис, его ошибка прерывает создание
+	EnrichAuto EnrichMode = "auto"
+	// не обращаться к внешнему сервису
+	EnrichNever EnrichMode = "never"
+	// запрашивать только если не хватает полей, ошибка сервиса не мешает созданию
+	EnrichFillMissing EnrichMode = "fill_missing"
+)
+
+// DTO для создания новой песни. Пустые ReleaseDate, Link и Lyrics
+// считаются незаполненными и при обогащении берутся из внешнего сервиса.
 type NewSongData struct {
 	Band        string
 	Song        string
 	ReleaseDate time.Time
 	Link        string
+	Lyrics      string
+	Explicit    bool
+	Enrich      EnrichMode
 }
 
 // DTO для полной информации о песне (без текста)
 type SongData struct {
-	ID          int       `json:"id"`
-	Band        string    `json:"group"`
-	Song        string    `json:"song"`
+	ID   int    `json:"id"`
+	Band string `json:"group"`
+	Song string `json:"song"`
+	// нулевая, если дата релиза неизвестна, в JSON тогда null
 	ReleaseDate time.Time `json:"release_date"`
 	Link        string    `json:"link"`
+	// nil, если текст песни ещё не проверялся по спискам слов
+	Explicit *bool `json:"explicit"`
 }
 
 func (s SongData) MarshalJSON() ([]byte, error) {
 	type Alias SongData
+	var releaseDate *string
+	if !s.ReleaseDate.IsZero() {
+		formatted := s.ReleaseDate.Format("2006-01-02")
+		releaseDate = &formatted
+	}
+
 	return json.Marshal(&struct {
-		ReleaseDate string `json:"release_date"`
+		ReleaseDate *string `json:"release_date"`
 		*Alias
 	}{
-		ReleaseDate: s.ReleaseDate.Format("2006-01-02"),
+		ReleaseDate: releaseDate,
 		Alias:       (*Alias)(&s),
 	})
 }
@@ -47,4 +72,6 @@ type UpdateSongData struct {
 	ReleaseDate *time.Time
 	Link        *string
 	Lyrics      *string
+	Explicit    *bool
+	RefreshedAt *time.Time
 }
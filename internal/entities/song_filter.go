@@ -9,6 +9,16 @@ type SongFilterData struct {
 	Song            *string
 	ReleaseDateFrom *time.Time
 	ReleaseDateTo   *time.Time
-	Offset          *int
-	Limit           *int
+	Explicit        *bool
+	RefreshedBefore *time.Time
+	// песни после этой позиции в порядке выдачи, для постраничного чтения без смещения
+	After  *SongCursor
+	Offset *int
+	Limit  *int
+}
+
+// Позиция песни в списке. Список упорядочен по дате релиза, песни с одной датой — по ID.
+type SongCursor struct {
+	ReleaseDate time.Time
+	ID          int
 }
@@ -0,0 +1,106 @@
+package entities
+
+import "time"
+
+// DTO для новой подписки. Секрет генерируется сервисом и показывается только при создании.
+type NewWebhookData struct {
+	URL    string
+	Events []EventType
+	Secret string
+}
+
+// DTO для изменения подписки, nil означает, что поле не меняется
+type UpdateWebhookData struct {
+	URL    *string
+	Events []EventType
+	Active *bool
+}
+
+type WebhookData struct {
+	ID        int         `json:"id"`
+	URL       string      `json:"url"`
+	Events    []EventType `json:"events"`
+	Active    bool        `json:"active"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Созданная подписка вместе с секретом для проверки подписи
+type CreatedWebhookData struct {
+	WebhookData
+	Secret string `json:"secret"`
+}
+
+// Состояние доставки события подписчику. Доставка, исчерпавшая попытки,
+// попадает в dead и повторяется только вручную.
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliveryDelivered WebhookDeliveryStatus = "delivered"
+	DeliveryDead      WebhookDeliveryStatus = "dead"
+)
+
+type WebhookDeliveryData struct {
+	ID             int64                 `json:"id"`
+	WebhookID      int                   `json:"webhook_id"`
+	EventID        int64                 `json:"event_id"`
+	EventType      EventType             `json:"event_type"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	LastStatusCode *int                  `json:"last_status_code"`
+	LastError      string                `json:"last_error"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// Параметры запроса журнала доставок
+type WebhookDeliveryFilterData struct {
+	WebhookID *int
+	Status    *WebhookDeliveryStatus
+	Offset    *int
+	Limit     *int
+}
+
+// Доставка, которую пора отправить, вместе с адресом, секретом и событием
+type PendingWebhookDeliveryData struct {
+	ID        int64
+	Attempts  int
+	WebhookID int
+	URL       string
+	Secret    string
+	Event     EventData
+}
+
+// Результат попытки доставки. RetryIn — через сколько повторить неудачную доставку.
+type WebhookAttemptData struct {
+	Status     WebhookDeliveryStatus
+	StatusCode *int
+	Error      string
+	RetryIn    time.Duration
+}
+
+// Итог одного прохода рассылки
+type WebhookDispatchResult struct {
+	Delivered int
+	Retried   int
+	Dead      int
+}
+
+// Настройки повторов: задержка растёт вдвое с каждой неудачной попыткой, но не больше MaxBackoff.
+// После MaxAttempts попыток доставка попадает в dead-letter.
+type WebhookRetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Задержка перед повтором после attempt-й неудачной попытки, попытки считаются с 1
+func (p WebhookRetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxBackoff)
+}
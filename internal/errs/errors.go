@@ -5,6 +5,15 @@ import "errors"
 var (
 	ErrAlreadyExists = errors.New("resource already exists")
 	ErrNotFound      = errors.New("resource not found")
+	ErrInvalidInput  = errors.New("invalid input")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+	ErrLagged        = errors.New("subscriber lagged behind")
+
+	// ключ идемпотентности уже использован для другого запроса
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused")
+	// запрос с тем же ключом идемпотентности ещё выполняется
+	ErrRequestInProgress = errors.New("request in progress")
 )
 
 type ErrServiceProblem struct {
@@ -12,6 +21,10 @@ type ErrServiceProblem struct {
 }
 
 func (err ErrServiceProblem) Error() string {
+	// пустое значение служит образцом для errors.Is
+	if err.Err == nil {
+		return "external service problem"
+	}
 	return err.Err.Error()
 }
 
@@ -0,0 +1,53 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Как ошибку юзкейса видит клиент
+type Public struct {
+	// HTTP-статус, по нему же выбираются коды gRPC и GraphQL
+	Status int
+	// сообщение для клиента, если пустое — текст самой ошибки
+	Message string
+}
+
+// Таблица общая для REST, gRPC и GraphQL, поэтому новая ошибка для клиентов добавляется только сюда
+var publicErrors = []struct {
+	err    error
+	public Public
+}{
+	{ErrUnauthorized, Public{http.StatusUnauthorized, "unauthorized"}},
+	{ErrForbidden, Public{http.StatusForbidden, "forbidden"}},
+	{ErrNotFound, Public{http.StatusNotFound, "not found"}},
+	{ErrAlreadyExists, Public{http.StatusConflict, "already exists"}},
+	{ErrInvalidInput, Public{http.StatusBadRequest, ""}},
+	{ErrServiceProblem{}, Public{http.StatusBadGateway, "external service error"}},
+	{ErrIdempotencyKeyReused, Public{http.StatusUnprocessableEntity, "idempotency key reused"}},
+	{ErrRequestInProgress, Public{http.StatusConflict, "request in progress"}},
+}
+
+// Возвращает статус и сообщение ошибки юзкейса. Для внутренних ошибок ok == false,
+// их подробности клиенту не отдаются.
+func PublicOf(err error) (Public, bool) {
+	for _, e := range publicErrors {
+		if errors.Is(err, e.err) {
+			public := e.public
+			if public.Message == "" {
+				public.Message = err.Error()
+			}
+			return public, true
+		}
+	}
+	return Public{}, false
+}
+
+// Все ошибки, которые видят клиенты, чтобы транспорты могли проверить, что знают каждую
+func Known() []error {
+	known := make([]error, 0, len(publicErrors))
+	for _, e := range publicErrors {
+		known = append(known, e.err)
+	}
+	return known
+}
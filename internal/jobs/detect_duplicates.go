@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"time"
+)
+
+// Периодически ищет песни, похожие на дубликаты, и сохраняет найденные пары.
+type DuplicatesDetector struct {
+	logger   config.Logger
+	cfg      config.DuplicatesConfig
+	usecases usecase.UseCases
+}
+
+func NewDuplicatesDetector(cfg config.DuplicatesConfig, l config.Logger, u usecase.UseCases) *DuplicatesDetector {
+	return &DuplicatesDetector{
+		logger:   l,
+		cfg:      cfg,
+		usecases: u,
+	}
+}
+
+// Первый поиск выполняется сразу после запуска, затем раз в интервал.
+// Блокируется до отмены контекста. При нулевом интервале сразу возвращается.
+func (j *DuplicatesDetector) Run(ctx context.Context) {
+	if j.cfg.Interval <= 0 {
+		j.logger.InfoContext(ctx, "Duplicates detection job disabled")
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(j.cfg.Interval) * time.Minute)
+	defer ticker.Stop()
+
+	// задача работает от имени сервиса, а не пользователя
+	ctx = entities.ContextWithPrincipal(ctx, entities.SystemPrincipal)
+
+	j.logger.InfoContext(ctx, "Duplicates detection job started", "interval_minutes", j.cfg.Interval, "min_score", j.cfg.MinScore)
+
+	for {
+		j.detect(ctx)
+
+		select {
+		case <-ctx.Done():
+			j.logger.InfoContext(ctx, "Duplicates detection job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *DuplicatesDetector) detect(ctx context.Context) {
+	count, err := j.usecases.DetectDuplicates.Execute(ctx)
+	if err != nil {
+		j.logger.ErrorContext(ctx, "Duplicates detection failed", "error", err)
+		return
+	}
+
+	j.logger.InfoContext(ctx, "Duplicates detection finished", "pairs", count)
+}
@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"time"
+)
+
+// Периодически рассылает подписчикам события из очереди доставок.
+type WebhookDispatcher struct {
+	logger   config.Logger
+	cfg      config.WebhooksConfig
+	usecases usecase.UseCases
+}
+
+func NewWebhookDispatcher(cfg config.WebhooksConfig, l config.Logger, u usecase.UseCases) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		logger:   l,
+		cfg:      cfg,
+		usecases: u,
+	}
+}
+
+// Блокируется до отмены контекста. При нулевом интервале сразу возвращается.
+func (j *WebhookDispatcher) Run(ctx context.Context) {
+	if j.cfg.Interval <= 0 {
+		j.logger.InfoContext(ctx, "Webhook dispatch job disabled")
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(j.cfg.Interval) * time.Second)
+	defer ticker.Stop()
+
+	ctx = entities.ContextWithPrincipal(ctx, entities.SystemPrincipal)
+
+	j.logger.InfoContext(ctx, "Webhook dispatch job started", "interval_seconds", j.cfg.Interval, "batch_size", j.cfg.BatchSize)
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.InfoContext(ctx, "Webhook dispatch job stopped")
+			return
+		case <-ticker.C:
+			j.dispatch(ctx)
+		}
+	}
+}
+
+// Рассылает пачки, пока очередь не опустеет, чтобы накопившиеся события не ждали следующих тиков.
+func (j *WebhookDispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		result, err := j.usecases.DispatchWebhooks.Execute(ctx)
+		if err != nil {
+			j.logger.ErrorContext(ctx, "Webhook dispatch failed", "error", err)
+			return
+		}
+
+		total := result.Delivered + result.Retried + result.Dead
+		if total == 0 {
+			return
+		}
+
+		if result.Dead > 0 {
+			j.logger.WarnContext(ctx, "Webhook deliveries moved to dead letters", "count", result.Dead)
+		}
+		j.logger.InfoContext(ctx, "Webhooks dispatched", "delivered", result.Delivered, "retried", result.Retried, "dead", result.Dead)
+
+		if total < j.cfg.BatchSize {
+			return
+		}
+	}
+}
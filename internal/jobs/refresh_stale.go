@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"time"
+)
+
+// Периодически обновляет из внешнего сервиса песни, которые не обновлялись дольше StaleDays дней.
+type StaleSongsRefresher struct {
+	logger   config.Logger
+	cfg      config.RefreshConfig
+	usecases usecase.UseCases
+}
+
+func NewStaleSongsRefresher(cfg config.RefreshConfig, l config.Logger, u usecase.UseCases) *StaleSongsRefresher {
+	return &StaleSongsRefresher{
+		logger:   l,
+		cfg:      cfg,
+		usecases: u,
+	}
+}
+
+// Блокируется до отмены контекста. При нулевом интервале сразу возвращается.
+func (j *StaleSongsRefresher) Run(ctx context.Context) {
+	if j.cfg.Interval <= 0 {
+		j.logger.InfoContext(ctx, "Stale songs refresh job disabled")
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(j.cfg.Interval) * time.Minute)
+	defer ticker.Stop()
+
+	ctx = entities.ContextWithPrincipal(ctx, entities.SystemPrincipal)
+
+	j.logger.InfoContext(ctx, "Stale songs refresh job started", "interval_minutes", j.cfg.Interval, "stale_days", j.cfg.StaleDays)
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.InfoContext(ctx, "Stale songs refresh job stopped")
+			return
+		case <-ticker.C:
+			j.refreshStale(ctx)
+		}
+	}
+}
+
+func (j *StaleSongsRefresher) refreshStale(ctx context.Context) {
+	refreshedBefore := time.Now().AddDate(0, 0, -j.cfg.StaleDays)
+	limit := j.cfg.BatchSize
+
+	results, err := j.usecases.RefreshSongs.Execute(ctx, entities.SongFilterData{
+		RefreshedBefore: &refreshedBefore,
+		Limit:           &limit,
+	}, entities.RefreshPolicy(j.cfg.Policy))
+
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			j.logger.DebugContext(ctx, "No stale songs to refresh")
+			return
+		}
+		j.logger.ErrorContext(ctx, "Stale songs refresh failed", "error", err)
+		return
+	}
+
+	var failed, changed int
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			failed++
+			j.logger.WarnContext(ctx, "Stale song refresh failed", "id", result.SongID, "error", result.Error)
+		case len(result.Changes) > 0:
+			changed++
+		}
+	}
+
+	j.logger.InfoContext(ctx, "Stale songs refreshed", "total", len(results), "changed", changed, "failed", failed)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/pkg/database"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+)
+
+type PGAPIKeyRepository struct {
+	db     *database.Database
+	logger config.Logger
+}
+
+func NewPGAPIKeyRepository(db *database.Database, l config.Logger) *PGAPIKeyRepository {
+	return &PGAPIKeyRepository{
+		db:     db,
+		logger: l,
+	}
+}
+
+var apiKeyColumns = []any{"id", "name", "prefix", "role", "created_at", "last_used_at", "revoked_at"}
+
+func (r *PGAPIKeyRepository) Create(ctx context.Context, data entities.NewAPIKeyData) (entities.APIKeyData, error) {
+	stmt := psql.Insert(
+		im.Into("api_keys", "name", "prefix", "key_hash", "role"),
+		im.Values(
+			psql.Arg(data.Name),
+			psql.Arg(data.Prefix),
+			psql.Arg(data.Hash),
+			psql.Arg(data.Role),
+		),
+		im.Returning(apiKeyColumns...),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing insert api key query", "query", query, "name", data.Name)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "insert api key"), query, args...)
+	if err != nil {
+		return entities.APIKeyData{}, err
+	}
+
+	key, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[entities.APIKeyData])
+	if err != nil {
+		return entities.APIKeyData{}, err
+	}
+
+	r.logger.DebugContext(ctx, "api key inserted successfully", "id", key.ID)
+	return key, nil
+}
+
+// Время использования ключа обновляется не чаще раза в минуту,
+// чтобы каждый запрос с ключом не превращался в запись в базу.
+const apiKeyTouchInterval = time.Minute
+
+// Находит действующий ключ по хэшу и отмечает время его использования,
+// если с прошлой отметки прошло больше apiKeyTouchInterval.
+func (r *PGAPIKeyRepository) MarkUsed(ctx context.Context, hash string) (entities.APIKeyData, error) {
+	stmt := psql.Select(
+		sm.Columns(apiKeyColumns...),
+		sm.From("api_keys"),
+		sm.Where(psql.Quote("key_hash").EQ(psql.Arg(hash))),
+		sm.Where(psql.Quote("revoked_at").IsNull()),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select api key by hash query", "query", query)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select api key by hash"), query, args...)
+	if err != nil {
+		return entities.APIKeyData{}, err
+	}
+
+	keys, err := pgx.CollectRows(rows, pgx.RowToStructByName[entities.APIKeyData])
+	if err != nil {
+		return entities.APIKeyData{}, err
+	}
+
+	if len(keys) == 0 {
+		return entities.APIKeyData{}, fmt.Errorf("%w active api key not found", errs.ErrNotFound)
+	}
+
+	key := keys[0]
+	if key.LastUsedAt != nil && time.Since(*key.LastUsedAt) < apiKeyTouchInterval {
+		return key, nil
+	}
+
+	// условие повторяется в запросе, чтобы одновременные запросы с ключом не писали его по очереди
+	update := psql.Update(
+		um.Table("api_keys"),
+		um.SetCol("last_used_at").To(psql.Raw("NOW()")),
+		um.Where(psql.Quote("id").EQ(psql.Arg(key.ID))),
+		um.Where(psql.Or(
+			psql.Quote("last_used_at").IsNull(),
+			psql.Quote("last_used_at").LT(
+				psql.Raw("NOW() - ?::bigint * INTERVAL '1 millisecond'", apiKeyTouchInterval.Milliseconds()),
+			),
+		)),
+	)
+
+	query, args = update.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing mark api key used query", "query", query, "id", key.ID)
+
+	// ключ действителен, даже если время использования отметить не удалось
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "mark api key used"), query, args...); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to mark api key used", "id", key.ID, "error", err)
+	}
+
+	return key, nil
+}
+
+func (r *PGAPIKeyRepository) GetList(ctx context.Context) ([]entities.APIKeyData, error) {
+	stmt := psql.Select(
+		sm.Columns(apiKeyColumns...),
+		sm.From("api_keys"),
+		sm.OrderBy("id"),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select api keys query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select api keys"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := pgx.CollectRows(rows, pgx.RowToStructByName[entities.APIKeyData])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w api keys not found", errs.ErrNotFound)
+	}
+
+	r.logger.DebugContext(ctx, "Successfully queried api keys", "count", len(keys))
+	return keys, nil
+}
+
+func (r *PGAPIKeyRepository) Revoke(ctx context.Context, keyID int) error {
+	stmt := psql.Update(
+		um.Table("api_keys"),
+		um.SetCol("revoked_at").To(psql.Raw("NOW()")),
+		um.Where(psql.Quote("id").EQ(psql.Arg(keyID))),
+		um.Where(psql.Quote("revoked_at").IsNull()),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing revoke api key query", "query", query, "args", args)
+
+	ct, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "revoke api key"), query, args...)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("%w no active api key with id %d", errs.ErrNotFound, keyID)
+	}
+
+	r.logger.DebugContext(ctx, "api key revoked successfully", "id", keyID)
+	return nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/pkg/database"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+)
+
+type PGAuditRepository struct {
+	db     *database.Database
+	logger config.Logger
+}
+
+func NewPGAuditRepository(db *database.Database, l config.Logger) *PGAuditRepository {
+	return &PGAuditRepository{
+		db:     db,
+		logger: l,
+	}
+}
+
+func (r *PGAuditRepository) Create(ctx context.Context, data entities.NewAuditRecordData) error {
+	before, err := marshalAuditState(data.Before)
+	if err != nil {
+		return err
+	}
+
+	after, err := marshalAuditState(data.After)
+	if err != nil {
+		return err
+	}
+
+	stmt := psql.Insert(
+		im.Into("audit_log", "actor", "action", "entity", "entity_id", "before", "after", "request_id"),
+		im.Values(
+			psql.Arg(data.Actor),
+			psql.Arg(data.Action),
+			psql.Arg(data.Entity),
+			psql.Arg(data.EntityID),
+			psql.Arg(before),
+			psql.Arg(after),
+			psql.Arg(data.RequestID),
+		),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing insert audit record query", "query", query, "action", data.Action, "entity_id", data.EntityID)
+
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "insert audit record"), query, args...); err != nil {
+		return err
+	}
+
+	r.logger.DebugContext(ctx, "audit record inserted successfully", "entity", data.Entity, "entity_id", data.EntityID)
+
+	return nil
+}
+
+// Состояние пишется в jsonb строкой, отсутствующее состояние — NULL.
+func marshalAuditState(state any) (*string, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	s := string(b)
+	return &s, nil
+}
+
+func (r *PGAuditRepository) GetList(
+	ctx context.Context,
+	filter entities.AuditFilterData,
+) ([]entities.AuditRecordData, error) {
+
+	stmt := psql.Select(
+		sm.Columns("id", "actor", "action", "entity", "entity_id", "before", "after", "request_id", "created_at"),
+		sm.From("audit_log"),
+		sm.OrderBy("id").Desc(),
+	)
+
+	if filter.Entity != nil {
+		stmt.Apply(sm.Where(psql.Quote("entity").EQ(psql.Arg(*filter.Entity))))
+	}
+
+	if filter.EntityID != nil {
+		stmt.Apply(sm.Where(psql.Quote("entity_id").EQ(psql.Arg(*filter.EntityID))))
+	}
+
+	if filter.Actor != nil {
+		stmt.Apply(sm.Where(psql.Quote("actor").EQ(psql.Arg(*filter.Actor))))
+	}
+
+	if filter.Action != nil {
+		stmt.Apply(sm.Where(psql.Quote("action").EQ(psql.Arg(*filter.Action))))
+	}
+
+	if filter.From != nil {
+		stmt.Apply(sm.Where(psql.Quote("created_at").GTE(psql.Arg(*filter.From))))
+	}
+
+	if filter.To != nil {
+		stmt.Apply(sm.Where(psql.Quote("created_at").LT(psql.Arg(*filter.To))))
+	}
+
+	if filter.Offset != nil {
+		stmt.Apply(sm.Offset(*filter.Offset))
+	}
+
+	if filter.Limit != nil {
+		stmt.Apply(sm.Limit(*filter.Limit))
+	}
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select audit log query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select audit log"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[entities.AuditRecordData])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w audit records not found", errs.ErrNotFound)
+	}
+
+	r.logger.DebugContext(ctx, "Successfully queried audit log", "count", len(records))
+	return records, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/pkg/database"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+)
+
+type PGSongDraftRepository struct {
+	db     *database.Database
+	logger config.Logger
+}
+
+func NewPGSongDraftRepository(db *database.Database, l config.Logger) *PGSongDraftRepository {
+	return &PGSongDraftRepository{
+		db:     db,
+		logger: l,
+	}
+}
+
+// Сохраняет черновик. У песни хранится только последний предложенный черновик.
+func (r *PGSongDraftRepository) Save(ctx context.Context, data entities.SongDraftData) error {
+	stmt := psql.Insert(
+		im.Into("song_drafts", "song_id", "release_date", "link", "lyrics", "created_at"),
+		im.Values(
+			psql.Arg(data.SongID),
+			psql.Arg(data.ReleaseDate),
+			psql.Arg(data.Link),
+			psql.Arg(data.Lyrics),
+			psql.Raw("NOW()"),
+		),
+		im.OnConflict("song_id").DoUpdate(
+			im.SetExcluded("release_date", "link", "lyrics", "created_at"),
+		),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing upsert song draft query", "query", query, "args", args)
+
+	_, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "upsert song draft"), query, args...)
+	if err != nil {
+		return err
+	}
+
+	r.logger.DebugContext(ctx, "song draft saved successfully", "song_id", data.SongID)
+
+	return nil
+}
+
+func (r *PGSongDraftRepository) GetList(ctx context.Context, filter entities.DraftFilterData) ([]entities.SongDraftData, error) {
+	stmt := psql.Select(
+		sm.Columns("song_id", "release_date", "link", "lyrics", "created_at"),
+		sm.From("song_drafts"),
+		sm.OrderBy("created_at").Desc(),
+		sm.OrderBy("song_id"),
+	)
+
+	if filter.SongID != nil {
+		stmt.Apply(sm.Where(psql.Quote("song_id").EQ(psql.Arg(*filter.SongID))))
+	}
+
+	if filter.Offset != nil {
+		stmt.Apply(sm.Offset(*filter.Offset))
+	}
+
+	if filter.Limit != nil {
+		stmt.Apply(sm.Limit(*filter.Limit))
+	}
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select song drafts query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select song drafts"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	drafts, err := pgx.CollectRows(rows, pgx.RowToStructByName[entities.SongDraftData])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(drafts) == 0 {
+		return nil, fmt.Errorf("%w song drafts not found", errs.ErrNotFound)
+	}
+
+	r.logger.DebugContext(ctx, "Successfully queried song drafts", "count", len(drafts))
+	return drafts, nil
+}
+
+func (r *PGSongDraftRepository) Delete(ctx context.Context, songID int) error {
+	stmt := psql.Delete(
+		dm.From("song_drafts"),
+		dm.Where(psql.Quote("song_id").EQ(psql.Arg(songID))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing delete song draft query", "query", query, "args", args)
+
+	ct, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "delete song draft"), query, args...)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("%w no draft for song with id %d", errs.ErrNotFound, songID)
+	}
+
+	r.logger.DebugContext(ctx, "song draft deleted successfully", "song_id", songID)
+	return nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/pkg/database"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+)
+
+type PGDuplicateRepository struct {
+	db     *database.Database
+	logger config.Logger
+}
+
+func NewPGDuplicateRepository(db *database.Database, l config.Logger) *PGDuplicateRepository {
+	return &PGDuplicateRepository{
+		db:     db,
+		logger: l,
+	}
+}
+
+// Все песни вместе с текстами, упорядоченные по группе, для попарного сравнения.
+func (r *PGDuplicateRepository) GetSongsWithLyrics(ctx context.Context) ([]entities.SongWithLyricsData, error) {
+	stmt := psql.Select(
+		sm.Columns("s.id", "s.band", "s.song", releaseDateColumn("s"), "s.link", "s.explicit", psql.Raw("COALESCE(l.content, '')")),
+		sm.From("songs").As("s"),
+		sm.LeftJoin("lyrics").As("l").OnEQ(psql.Quote("l", "song_id"), psql.Quote("s", "id")),
+		sm.OrderBy("s.band"),
+		sm.OrderBy("s.id"),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select songs with lyrics query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select songs with lyrics"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	songs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.SongWithLyricsData, error) {
+		var s entities.SongWithLyricsData
+		err := row.Scan(&s.ID, &s.Band, &s.Song, &s.ReleaseDate, &s.Link, &s.Explicit, &s.Lyrics)
+		return s, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.DebugContext(ctx, "Successfully queried songs with lyrics", "count", len(songs))
+	return songs, nil
+}
+
+// Заменяет сохранённые пары дубликатов результатом нового поиска.
+// Вызывается в транзакции, чтобы список не оказался пустым для параллельных запросов.
+func (r *PGDuplicateRepository) Replace(ctx context.Context, duplicates []entities.NewDuplicateData) error {
+	deleteStmt := psql.Delete(dm.From("song_duplicates"))
+
+	query, args := deleteStmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing delete duplicates query", "query", query, "args", args)
+
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "delete duplicates"), query, args...); err != nil {
+		return err
+	}
+
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	rows := make([][]bob.Expression, 0, len(duplicates))
+	for _, d := range duplicates {
+		rows = append(rows, []bob.Expression{
+			psql.Arg(d.SongID),
+			psql.Arg(d.DuplicateID),
+			psql.Arg(d.TitleScore),
+			psql.Arg(d.LyricsScore),
+			psql.Arg(d.Score),
+		})
+	}
+
+	insertStmt := psql.Insert(
+		im.Into("song_duplicates", "song_id", "duplicate_id", "title_score", "lyrics_score", "score"),
+		im.Rows(rows...),
+	)
+
+	query, args = insertStmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing insert duplicates query", "query", query, "count", len(duplicates))
+
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "insert duplicates"), query, args...); err != nil {
+		return err
+	}
+
+	r.logger.DebugContext(ctx, "duplicates replaced successfully", "count", len(duplicates))
+
+	return nil
+}
+
+func (r *PGDuplicateRepository) GetList(
+	ctx context.Context,
+	filter entities.DuplicateFilterData,
+) ([]entities.DuplicateData, error) {
+
+	stmt := psql.Select(
+		sm.Columns(
+			"a.id", "a.band", "a.song", releaseDateColumn("a"), "a.link", "a.explicit",
+			"b.id", "b.band", "b.song", releaseDateColumn("b"), "b.link", "b.explicit",
+			"d.title_score", "d.lyrics_score", "d.score",
+		),
+		sm.From("song_duplicates").As("d"),
+		sm.InnerJoin("songs").As("a").OnEQ(psql.Quote("a", "id"), psql.Quote("d", "song_id")),
+		sm.InnerJoin("songs").As("b").OnEQ(psql.Quote("b", "id"), psql.Quote("d", "duplicate_id")),
+		sm.OrderBy("d.score").Desc(),
+		sm.OrderBy("d.song_id"),
+	)
+
+	if filter.MinScore != nil {
+		stmt.Apply(sm.Where(psql.Quote("d", "score").GTE(psql.Arg(*filter.MinScore))))
+	}
+
+	if filter.Offset != nil {
+		stmt.Apply(sm.Offset(*filter.Offset))
+	}
+
+	if filter.Limit != nil {
+		stmt.Apply(sm.Limit(*filter.Limit))
+	}
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select duplicates query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select duplicates"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.DuplicateData, error) {
+		var d entities.DuplicateData
+		err := row.Scan(
+			&d.Song.ID, &d.Song.Band, &d.Song.Song, &d.Song.ReleaseDate, &d.Song.Link, &d.Song.Explicit,
+			&d.Duplicate.ID, &d.Duplicate.Band, &d.Duplicate.Song, &d.Duplicate.ReleaseDate, &d.Duplicate.Link, &d.Duplicate.Explicit,
+			&d.TitleScore, &d.LyricsScore, &d.Score,
+		)
+		return d, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(duplicates) == 0 {
+		return nil, fmt.Errorf("%w duplicates not found", errs.ErrNotFound)
+	}
+
+	r.logger.DebugContext(ctx, "Successfully queried duplicates", "count", len(duplicates))
+	return duplicates, nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/pkg/database"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+)
+
+// Outbox событий об изменениях песен. Запись выполняется в транзакции изменения,
+// вместе с событием в ней же создаются доставки для подписанных вебхуков.
+type PGEventRepository struct {
+	db     *database.Database
+	logger config.Logger
+}
+
+// ключ advisory lock, под которым пишутся события
+const eventsLockID = 7_320_114
+
+func NewPGEventRepository(db *database.Database, l config.Logger) *PGEventRepository {
+	return &PGEventRepository{
+		db:     db,
+		logger: l,
+	}
+}
+
+// Транзакции, пишущие события, выстраиваются в очередь на advisory lock до своего коммита.
+// Поэтому номера событий видны читателям строго по возрастанию, и читатель, продолжающий
+// с последнего полученного номера, не пропустит событие из транзакции, закоммиченной позже.
+func (r *PGEventRepository) Create(ctx context.Context, events []entities.NewEventData) ([]entities.EventData, error) {
+	lockQuery := "SELECT pg_advisory_xact_lock($1)"
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "lock events"), lockQuery, eventsLockID); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]bob.Expression, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(e.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, []bob.Expression{
+			psql.Arg(e.Type),
+			psql.Arg(e.SongID),
+			psql.Arg(string(payload)),
+			psql.Arg(e.RequestID),
+		})
+	}
+
+	insertStmt := psql.Insert(
+		im.Into("events", "type", "song_id", "payload", "request_id"),
+		im.Rows(rows...),
+		im.Returning("id", "type", "song_id", "payload", "request_id", "created_at"),
+	)
+
+	query, args := insertStmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing insert events query", "query", query, "count", len(events))
+
+	result, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "insert events"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := pgx.CollectRows(result, scanEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(created))
+	for _, e := range created {
+		ids = append(ids, e.ID)
+	}
+
+	deliveriesStmt := psql.Insert(
+		im.Into("webhook_deliveries", "webhook_id", "event_id"),
+		im.Query(psql.Select(
+			sm.Columns("w.id", "e.id"),
+			sm.From("events").As("e"),
+			sm.InnerJoin("webhooks").As("w").On(
+				psql.Quote("w", "active"),
+				psql.Raw("e.type = ANY (w.events)"),
+			),
+			sm.Where(psql.Raw("e.id = ANY (?)", ids)),
+		)),
+	)
+
+	query, args = deliveriesStmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing insert webhook deliveries query", "query", query, "args", args)
+
+	ct, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "insert webhook deliveries"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.DebugContext(ctx, "events inserted successfully", "events", len(ids), "deliveries", ct.RowsAffected())
+
+	return created, nil
+}
+
+// События с номером больше filter.Since по возрастанию номера. Пустой результат — не ошибка:
+// для ленты изменений он означает, что новых событий нет.
+func (r *PGEventRepository) GetList(ctx context.Context, filter entities.EventFilterData) ([]entities.EventData, error) {
+	stmt := psql.Select(
+		sm.Columns("id", "type", "song_id", "payload", "request_id", "created_at"),
+		sm.From("events"),
+		sm.Where(psql.Quote("id").GT(psql.Arg(filter.Since))),
+		sm.OrderBy("id"),
+		sm.Limit(filter.Limit),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select events query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select events"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := pgx.CollectRows(rows, scanEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.DebugContext(ctx, "Successfully queried events", "count", len(events))
+	return events, nil
+}
+
+// Номер последнего события или 0, если событий ещё не было
+func (r *PGEventRepository) GetLastID(ctx context.Context) (int64, error) {
+	query := "SELECT COALESCE(MAX(id), 0) FROM events"
+	r.logger.DebugContext(ctx, "executing select last event id query", "query", query)
+
+	var id int64
+	err := r.db.Conn(ctx).QueryRow(database.WithStatementName(ctx, "select last event id"), query).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func scanEvent(row pgx.CollectableRow) (entities.EventData, error) {
+	var e entities.EventData
+	err := row.Scan(&e.ID, &e.Type, &e.SongID, &e.Data, &e.RequestID, &e.CreatedAt)
+	e.Deleted = e.Type == entities.EventSongDeleted
+	return e, err
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/pkg/database"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+)
+
+// Хранит ключи идемпотентности и ответы на запросы с ними до истечения срока хранения.
+// Ключ занимается одним запросом, поэтому повторы, пришедшие одновременно, не выполнятся дважды.
+type PGIdempotencyRepository struct {
+	db          *database.Database
+	logger      config.Logger
+	lastCleanup atomic.Int64
+}
+
+func NewPGIdempotencyRepository(db *database.Database, l config.Logger) *PGIdempotencyRepository {
+	r := &PGIdempotencyRepository{
+		db:     db,
+		logger: l,
+	}
+	r.lastCleanup.Store(time.Now().Unix())
+	return r
+}
+
+type idempotencyKeyRow struct {
+	Key                 string
+	Fingerprint         string
+	ResponseStatus      *int
+	ResponseContentType *string
+	ResponseBody        []byte
+	CreatedAt           time.Time
+}
+
+// Занимает ключ для запроса. Истёкший ключ и ключ, запрос с которым не завершился
+// за lockTimeout, занимаются заново. Если ключ занят, возвращает его запись, иначе nil.
+func (r *PGIdempotencyRepository) Acquire(
+	ctx context.Context,
+	key, fingerprint string,
+	ttl, lockTimeout time.Duration,
+) (*entities.IdempotencyKeyData, error) {
+
+	r.cleanup(ctx)
+
+	stmt := psql.Insert(
+		im.Into("idempotency_keys", "key", "fingerprint", "created_at", "expires_at"),
+		im.Values(
+			psql.Arg(key),
+			psql.Arg(fingerprint),
+			psql.Raw("NOW()"),
+			psql.Raw("NOW() + ?::bigint * INTERVAL '1 millisecond'", ttl.Milliseconds()),
+		),
+		im.OnConflict("key").DoUpdate(
+			im.SetExcluded("fingerprint", "created_at", "expires_at"),
+			im.SetCol("response_status").ToArg(nil),
+			im.SetCol("response_content_type").ToArg(nil),
+			im.SetCol("response_body").ToArg(nil),
+			im.Where(psql.Or(
+				psql.Quote("idempotency_keys", "expires_at").LT(psql.Raw("NOW()")),
+				psql.And(
+					psql.Quote("idempotency_keys", "response_status").IsNull(),
+					psql.Quote("idempotency_keys", "created_at").LT(
+						psql.Raw("NOW() - ?::bigint * INTERVAL '1 millisecond'", lockTimeout.Milliseconds()),
+					),
+				),
+			)),
+		),
+		im.Returning("key"),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing acquire idempotency key query", "query", query)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "acquire idempotency key"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	acquired, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	if len(acquired) > 0 {
+		return nil, nil
+	}
+
+	return r.get(ctx, key)
+}
+
+func (r *PGIdempotencyRepository) get(ctx context.Context, key string) (*entities.IdempotencyKeyData, error) {
+	stmt := psql.Select(
+		sm.Columns("key", "fingerprint", "response_status", "response_content_type", "response_body", "created_at"),
+		sm.From("idempotency_keys"),
+		sm.Where(psql.Quote("key").EQ(psql.Arg(key))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select idempotency key query", "query", query)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select idempotency key"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[idempotencyKeyRow])
+	if err != nil {
+		return nil, err
+	}
+
+	// ключ мог быть освобождён между попыткой занять его и чтением
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w no idempotency key %q", errs.ErrNotFound, key)
+	}
+
+	row := records[0]
+	data := &entities.IdempotencyKeyData{
+		Key:         row.Key,
+		Fingerprint: row.Fingerprint,
+		CreatedAt:   row.CreatedAt,
+	}
+	if row.ResponseStatus != nil {
+		data.Response = &entities.IdempotentResponse{
+			Status: *row.ResponseStatus,
+			Body:   row.ResponseBody,
+		}
+		if row.ResponseContentType != nil {
+			data.Response.ContentType = *row.ResponseContentType
+		}
+	}
+
+	return data, nil
+}
+
+func (r *PGIdempotencyRepository) SaveResponse(ctx context.Context, key string, response entities.IdempotentResponse) error {
+	stmt := psql.Update(
+		um.Table("idempotency_keys"),
+		um.SetCol("response_status").ToArg(response.Status),
+		um.SetCol("response_content_type").ToArg(response.ContentType),
+		um.SetCol("response_body").ToArg(response.Body),
+		um.Where(psql.Quote("key").EQ(psql.Arg(key))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing save idempotent response query", "query", query, "status", response.Status)
+
+	_, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "save idempotent response"), query, args...)
+	return err
+}
+
+func (r *PGIdempotencyRepository) Delete(ctx context.Context, key string) error {
+	stmt := psql.Delete(
+		dm.From("idempotency_keys"),
+		dm.Where(psql.Quote("key").EQ(psql.Arg(key))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing delete idempotency key query", "query", query)
+
+	_, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "delete idempotency key"), query, args...)
+	return err
+}
+
+// Раз в несколько минут удаляет истёкшие ключи
+func (r *PGIdempotencyRepository) cleanup(ctx context.Context) {
+	last := r.lastCleanup.Load()
+	now := time.Now().Unix()
+	if now-last < int64((10*time.Minute).Seconds()) || !r.lastCleanup.CompareAndSwap(last, now) {
+		return
+	}
+
+	stmt := psql.Delete(
+		dm.From("idempotency_keys"),
+		dm.Where(psql.Quote("expires_at").LT(psql.Raw("NOW()"))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing cleanup idempotency keys query", "query", query)
+
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "cleanup idempotency keys"), query, args...); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to clean up idempotency keys", "error", err)
+	}
+}
@@ -40,16 +40,16 @@ func (r *PGLyricsRepository) Create(ctx context.Context, data entities.NewLyrics
 	)
 
 	query, args := stmt.MustBuild(ctx)
-	r.logger.Debug("executing insert lyrics query", "query", query, "args", args)
+	r.logger.DebugContext(ctx, "executing insert lyrics query", "query", query, "args", args)
 
-	_, err := r.db.Conn(ctx).Exec(ctx, query, args...)
+	_, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "insert lyrics"), query, args...)
 	if err != nil {
 		return err
 	}
 
-	r.logger.Debug("lyrics inserted successfully", "song_id", data.SongID)
+	r.logger.DebugContext(ctx, "lyrics inserted successfully", "song_id", data.SongID)
 
-	return nil
+	return r.createRevision(ctx, data.SongID, data.Content)
 }
 
 func (r *PGLyricsRepository) Get(ctx context.Context, songID int) (entities.LyricsData, error) {
@@ -60,10 +60,10 @@ func (r *PGLyricsRepository) Get(ctx context.Context, songID int) (entities.Lyri
 	)
 
 	query, args := stmt.MustBuild(ctx)
-	r.logger.Debug("executing select lyrics query", "query", query, "args", args)
+	r.logger.DebugContext(ctx, "executing select lyrics query", "query", query, "args", args)
 
 	var content string
-	err := r.db.Conn(ctx).QueryRow(ctx, query, args...).Scan(&content)
+	err := r.db.Conn(ctx).QueryRow(database.WithStatementName(ctx, "select lyrics"), query, args...).Scan(&content)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -71,7 +71,7 @@ func (r *PGLyricsRepository) Get(ctx context.Context, songID int) (entities.Lyri
 		}
 		return entities.LyricsData{}, err
 	}
-	r.logger.Debug("lyrics queried successfully", "song_id", songID)
+	r.logger.DebugContext(ctx, "lyrics queried successfully", "song_id", songID)
 
 	return entities.LyricsData{
 		SongID:  songID,
@@ -79,12 +79,67 @@ func (r *PGLyricsRepository) Get(ctx context.Context, songID int) (entities.Lyri
 	}, nil
 }
 
+// Тексты нескольких песен одним запросом. Песен без текста нет в результате.
+func (r *PGLyricsRepository) GetList(ctx context.Context, songIDs []int) ([]entities.LyricsData, error) {
+	stmt := psql.Select(
+		sm.Columns("song_id", "content"),
+		sm.From("lyrics"),
+		sm.Where(psql.Raw("song_id = ANY (?)", songIDs)),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select lyrics list query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select lyrics list"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	lyrics, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.LyricsData, error) {
+		var l entities.LyricsData
+		err := row.Scan(&l.SongID, &l.Content)
+		return l, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.DebugContext(ctx, "lyrics list queried successfully", "songs", len(songIDs), "found", len(lyrics))
+	return lyrics, nil
+}
+
 func (r *PGLyricsRepository) Update(ctx context.Context, songID int, data entities.UpdateSongData) error {
 
 	if data.Lyrics == nil {
 		return nil
 	}
 
+	// строка текста блокируется до конца транзакции, чтобы сравнение не устарело к моменту изменения
+	current := psql.Select(
+		sm.Columns("content"),
+		sm.From("lyrics"),
+		sm.Where(psql.Quote("song_id").EQ(psql.Arg(songID))),
+		sm.ForUpdate(),
+	)
+
+	query, args := current.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select lyrics for update query", "query", query, "args", args)
+
+	var content string
+	err := r.db.Conn(ctx).QueryRow(database.WithStatementName(ctx, "select lyrics for update"), query, args...).Scan(&content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w song lyrics not found", errs.ErrNotFound)
+		}
+		return err
+	}
+
+	// тот же текст не меняем, иначе каждое такое изменение добавляло бы одинаковую ревизию
+	if content == *data.Lyrics {
+		r.logger.DebugContext(ctx, "lyrics unchanged, skipping update", "id", songID)
+		return nil
+	}
+
 	stmt := psql.Update(
 		um.Table("lyrics"),
 		um.SetCol("content").ToArg(data.Lyrics),
@@ -92,10 +147,10 @@ func (r *PGLyricsRepository) Update(ctx context.Context, songID int, data entiti
 		um.Where(psql.Quote("song_id").EQ(psql.Arg(songID))),
 	)
 
-	query, args := stmt.MustBuild(ctx)
-	r.logger.Debug("executing update lyrics query", "query", query, "args", args)
+	query, args = stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing update lyrics query", "query", query, "args", args)
 
-	ct, err := r.db.Conn(ctx).Exec(ctx, query, args...)
+	ct, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "update lyrics"), query, args...)
 	if err != nil {
 		return err
 	}
@@ -103,11 +158,80 @@ func (r *PGLyricsRepository) Update(ctx context.Context, songID int, data entiti
 		return fmt.Errorf("%w no lyrics rows updated", errs.ErrNotFound)
 	}
 
-	r.logger.Debug("lyrics updated successfully", "id", songID)
+	r.logger.DebugContext(ctx, "lyrics updated successfully", "id", songID)
+
+	return r.createRevision(ctx, songID, *data.Lyrics)
+}
+
+// Сохраняет текст очередной ревизией. Вызывается в той же транзакции, что и изменение текста.
+// Номер ревизии считается по предыдущим, поэтому строка песни блокируется до конца транзакции:
+// иначе одновременные изменения текста получат одинаковый номер и одно из них упадёт.
+func (r *PGLyricsRepository) createRevision(ctx context.Context, songID int, content string) error {
+	lock := psql.Select(
+		sm.Columns("id"),
+		sm.From("songs"),
+		sm.Where(psql.Quote("id").EQ(psql.Arg(songID))),
+		sm.ForUpdate(),
+	)
+
+	query, args := lock.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing lock song query", "query", query, "args", args)
+
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "lock song"), query, args...); err != nil {
+		return err
+	}
+
+	stmt := psql.Insert(
+		im.Into("lyrics_revisions", "song_id", "revision", "content"),
+		im.Values(
+			psql.Arg(songID),
+			psql.Raw("(SELECT COALESCE(MAX(revision), 0) + 1 FROM lyrics_revisions WHERE song_id = ?)", songID),
+			psql.Arg(content),
+		),
+	)
+
+	query, args = stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing insert lyrics revision query", "query", query, "args", args)
+
+	_, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "insert lyrics revision"), query, args...)
+	if err != nil {
+		return err
+	}
+
+	r.logger.DebugContext(ctx, "lyrics revision inserted successfully", "song_id", songID)
 
 	return nil
 }
 
+func (r *PGLyricsRepository) GetRevision(ctx context.Context, songID, revision int) (entities.LyricsRevisionData, error) {
+	stmt := psql.Select(
+		sm.Columns("content"),
+		sm.From("lyrics_revisions"),
+		sm.Where(psql.Quote("song_id").EQ(psql.Arg(songID))),
+		sm.Where(psql.Quote("revision").EQ(psql.Arg(revision))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select lyrics revision query", "query", query, "args", args)
+
+	var content string
+	err := r.db.Conn(ctx).QueryRow(database.WithStatementName(ctx, "select lyrics revision"), query, args...).Scan(&content)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.LyricsRevisionData{}, fmt.Errorf("%w lyrics revision not found", errs.ErrNotFound)
+		}
+		return entities.LyricsRevisionData{}, err
+	}
+	r.logger.DebugContext(ctx, "lyrics revision queried successfully", "song_id", songID, "revision", revision)
+
+	return entities.LyricsRevisionData{
+		SongID:   songID,
+		Revision: revision,
+		Content:  content,
+	}, nil
+}
+
 func (r *PGLyricsRepository) Delete(ctx context.Context, songID int) error {
 
 	stmt := psql.Delete(
@@ -116,14 +240,14 @@ func (r *PGLyricsRepository) Delete(ctx context.Context, songID int) error {
 	)
 
 	query, args := stmt.MustBuild(ctx)
-	r.logger.Debug("executing delete lyrics query", "query", query, "args", args)
+	r.logger.DebugContext(ctx, "executing delete lyrics query", "query", query, "args", args)
 
-	_, err := r.db.Conn(ctx).Exec(ctx, query, args...)
+	_, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "delete lyrics"), query, args...)
 	if err != nil {
 		return err
 	}
 
-	r.logger.Debug("lyrics deleted successfully", "song_id", songID)
+	r.logger.DebugContext(ctx, "lyrics deleted successfully", "song_id", songID)
 
 	return nil
 }
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/pkg/database"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+)
+
+// Хранит ведра token bucket в Postgres, чтобы несколько экземпляров сервиса
+// считали общий лимит. Ведро пополняется и уменьшается одним запросом, без отдельной транзакции.
+type PGRateLimitRepository struct {
+	db          *database.Database
+	logger      config.Logger
+	lastCleanup atomic.Int64
+}
+
+func NewPGRateLimitRepository(db *database.Database, l config.Logger) *PGRateLimitRepository {
+	r := &PGRateLimitRepository{
+		db:     db,
+		logger: l,
+	}
+	r.lastCleanup.Store(time.Now().Unix())
+	return r
+}
+
+type rateLimitBucket struct {
+	Tokens  float64
+	Allowed bool
+}
+
+func (r *PGRateLimitRepository) Take(ctx context.Context, key string, rule entities.RateLimitRule) (entities.RateLimitResult, error) {
+	// количество токенов после пополнения за время с прошлого запроса
+	refilled := func() any {
+		return psql.Raw(
+			"LEAST(?::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8 * ?::float8 / 60)",
+			rule.Burst, rule.PerMinute,
+		)
+	}
+
+	stmt := psql.Insert(
+		im.IntoAs("rate_limit_buckets", "b", "key", "tokens", "allowed", "updated_at"),
+		im.Values(
+			psql.Arg(key),
+			psql.Arg(float64(rule.Burst-1)),
+			psql.Arg(true),
+			psql.Raw("NOW()"),
+		),
+		im.OnConflict("key").DoUpdate(
+			im.SetCol("tokens").To(psql.Raw("CASE WHEN ? >= 1 THEN ? - 1 ELSE ? END", refilled(), refilled(), refilled())),
+			im.SetCol("allowed").To(psql.Raw("? >= 1", refilled())),
+			im.SetCol("updated_at").To(psql.Raw("NOW()")),
+		),
+		im.Returning("tokens", "allowed"),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing take rate limit token query", "query", query, "key", key)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "take rate limit token"), query, args...)
+	if err != nil {
+		return entities.RateLimitResult{}, err
+	}
+
+	bucket, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[rateLimitBucket])
+	if err != nil {
+		return entities.RateLimitResult{}, err
+	}
+
+	r.cleanup(ctx)
+
+	return rule.Result(bucket.Tokens, bucket.Allowed), nil
+}
+
+// Раз в несколько минут удаляет давно не использованные ведра: они уже полные
+// и при следующем запросе создадутся заново.
+func (r *PGRateLimitRepository) cleanup(ctx context.Context) {
+	last := r.lastCleanup.Load()
+	now := time.Now().Unix()
+	if now-last < int64((10*time.Minute).Seconds()) || !r.lastCleanup.CompareAndSwap(last, now) {
+		return
+	}
+
+	stmt := psql.Delete(
+		dm.From("rate_limit_buckets"),
+		dm.Where(psql.Quote("updated_at").LT(psql.Raw("NOW() - INTERVAL '1 hour'"))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing cleanup rate limit buckets query", "query", query)
+
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "cleanup rate limit buckets"), query, args...); err != nil {
+		r.logger.ErrorContext(ctx, "Failed to clean up rate limit buckets", "error", err)
+	}
+}
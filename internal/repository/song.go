@@ -11,6 +11,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/dialect/psql"
 	"github.com/stephenafamo/bob/dialect/psql/dm"
 	"github.com/stephenafamo/bob/dialect/psql/im"
@@ -37,12 +38,13 @@ const (
 
 func (r *PGSongRepository) Create(ctx context.Context, data entities.NewSongData) (int, error) {
 	stmt := psql.Insert(
-		im.Into("songs", "band", "song", "release_date", "link"),
+		im.Into("songs", "band", "song", "release_date", "link", "explicit"),
 		im.Values(
 			psql.Arg(data.Band),
 			psql.Arg(data.Song),
-			psql.Arg(data.ReleaseDate),
+			psql.Arg(nullableDate(data.ReleaseDate)),
 			psql.Arg(data.Link),
+			psql.Arg(data.Explicit),
 		),
 		im.Returning("id"),
 	)
@@ -50,8 +52,8 @@ func (r *PGSongRepository) Create(ctx context.Context, data entities.NewSongData
 	query, args := stmt.MustBuild(ctx)
 
 	var id int
-	r.logger.Debug("executing insert song query", "query", query, "args", args)
-	err := r.db.Conn(ctx).QueryRow(ctx, query, args...).Scan(&id)
+	r.logger.DebugContext(ctx, "executing insert song query", "query", query, "args", args)
+	err := r.db.Conn(ctx).QueryRow(database.WithStatementName(ctx, "insert song"), query, args...).Scan(&id)
 	if err != nil {
 		pgErr, ok := err.(*pgconn.PgError)
 		if ok && pgErr.Code == PG_ERROR_EXISTS && pgErr.ConstraintName == SONG_BAND_UNIQ_CONSTR {
@@ -59,7 +61,7 @@ func (r *PGSongRepository) Create(ctx context.Context, data entities.NewSongData
 		}
 		return 0, err
 	}
-	r.logger.Debug("song inserted successfully", "id", id)
+	r.logger.DebugContext(ctx, "song inserted successfully", "id", id)
 	return id, nil
 }
 
@@ -69,9 +71,13 @@ func (r *PGSongRepository) GetList(
 ) ([]entities.SongData, error) {
 
 	stmt := psql.Select(
-		sm.Columns("id", "band", "song", "release_date", "link"),
+		sm.Columns("id", "band", "song", releaseDateColumn(""), "link", "explicit"),
 		sm.From("songs"),
-		sm.OrderBy("release_date"),
+		// ID делает порядок однозначным, иначе песни с одной датой могли бы повторяться или пропадать между страницами.
+		// Песни с неизвестной датой идут в конце. Колонка указана с таблицей, чтобы сортировать
+		// по самой дате, а не по одноимённому выражению из releaseDateColumn.
+		sm.OrderBy(psql.Quote("songs", "release_date")).NullsLast(),
+		sm.OrderBy("id"),
 	)
 
 	if filter.ID != nil {
@@ -94,6 +100,18 @@ func (r *PGSongRepository) GetList(
 		stmt.Apply(sm.Where(psql.Quote("release_date").LTE(psql.Arg(*filter.ReleaseDateTo))))
 	}
 
+	if filter.Explicit != nil {
+		stmt.Apply(sm.Where(psql.Quote("explicit").EQ(psql.Arg(*filter.Explicit))))
+	}
+
+	if filter.RefreshedBefore != nil {
+		stmt.Apply(sm.Where(psql.Quote("refreshed_at").LT(psql.Arg(*filter.RefreshedBefore))))
+	}
+
+	if filter.After != nil {
+		stmt.Apply(sm.Where(songsAfter(*filter.After)))
+	}
+
 	if filter.Offset != nil {
 		stmt.Apply(sm.Offset(*filter.Offset))
 	}
@@ -103,9 +121,9 @@ func (r *PGSongRepository) GetList(
 	}
 
 	query, args := stmt.MustBuild(ctx)
-	r.logger.Debug("executing select song list query", "query", query, "args", args)
+	r.logger.DebugContext(ctx, "executing select song list query", "query", query, "args", args)
 
-	rows, err := r.db.Conn(ctx).Query(ctx, query, args...)
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select song list"), query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +137,7 @@ func (r *PGSongRepository) GetList(
 		return nil, fmt.Errorf("%w songs not found", errs.ErrNotFound)
 	}
 
-	r.logger.Debug("Successfully queried songs", "count", len(songs))
+	r.logger.DebugContext(ctx, "Successfully queried songs", "count", len(songs))
 	return songs, nil
 }
 
@@ -149,7 +167,7 @@ func (r *PGSongRepository) Update(ctx context.Context, songID int, data entities
 
 	if data.ReleaseDate != nil {
 		stmt.Apply(
-			um.SetCol("release_date").ToArg(*data.ReleaseDate),
+			um.SetCol("release_date").ToArg(nullableDate(*data.ReleaseDate)),
 		)
 		nothingToUpdate = false
 	}
@@ -161,22 +179,40 @@ func (r *PGSongRepository) Update(ctx context.Context, songID int, data entities
 		nothingToUpdate = false
 	}
 
+	if data.Explicit != nil {
+		stmt.Apply(
+			um.SetCol("explicit").ToArg(*data.Explicit),
+		)
+		nothingToUpdate = false
+	}
+
+	if data.RefreshedAt != nil {
+		stmt.Apply(
+			um.SetCol("refreshed_at").ToArg(*data.RefreshedAt),
+		)
+		nothingToUpdate = false
+	}
+
 	if nothingToUpdate {
 		return nil
 	}
 
 	query, args := stmt.MustBuild(ctx)
-	r.logger.Debug("executing update song query", "query", query, "args", args)
+	r.logger.DebugContext(ctx, "executing update song query", "query", query, "args", args)
 
-	ct, err := r.db.Conn(ctx).Exec(ctx, query, args...)
+	ct, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "update song"), query, args...)
 	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == PG_ERROR_EXISTS && pgErr.ConstraintName == SONG_BAND_UNIQ_CONSTR {
+			return fmt.Errorf("%w song with the same group and name already exists", errs.ErrAlreadyExists)
+		}
 		return err
 	}
 	if ct.RowsAffected() == 0 {
 		return fmt.Errorf("%w no song rows updated", errs.ErrNotFound)
 	}
 
-	r.logger.Debug("song updated successfully", "id", songID)
+	r.logger.DebugContext(ctx, "song updated successfully", "id", songID)
 
 	return nil
 }
@@ -188,14 +224,48 @@ func (r *PGSongRepository) Delete(ctx context.Context, songID int) error {
 	)
 
 	query, args := stmt.MustBuild(ctx)
-	r.logger.Debug("executing delete song query", "query", query, "args", args)
+	r.logger.DebugContext(ctx, "executing delete song query", "query", query, "args", args)
 
-	_, err := r.db.Conn(ctx).Exec(ctx, query, args...)
+	_, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "delete song"), query, args...)
 	if err != nil {
 		return err
 	}
 
-	r.logger.Debug("song deleted successfully", "id", songID)
+	r.logger.DebugContext(ctx, "song deleted successfully", "id", songID)
 
 	return nil
 }
+
+// Неизвестная дата релиза хранится как NULL, а в entities это нулевое время
+func nullableDate(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// Дата релиза для чтения в entities: NULL превращается в нулевое время
+func releaseDateColumn(table string) bob.Expression {
+	column := "release_date"
+	if table != "" {
+		column = table + "." + column
+	}
+	return psql.Raw(fmt.Sprintf("COALESCE(%s, '0001-01-01'::date) AS release_date", column))
+}
+
+// Песни после позиции курсора в порядке (release_date NULLS LAST, id)
+func songsAfter(cursor entities.SongCursor) bob.Expression {
+	if cursor.ReleaseDate.IsZero() {
+		return psql.And(
+			psql.Quote("release_date").IsNull(),
+			psql.Quote("id").GT(psql.Arg(cursor.ID)),
+		)
+	}
+
+	return psql.Or(
+		psql.Group(psql.Quote("release_date"), psql.Quote("id")).GT(
+			psql.ArgGroup(cursor.ReleaseDate, cursor.ID),
+		),
+		psql.Quote("release_date").IsNull(),
+	)
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/pkg/database"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+)
+
+// Отдаёт в метрики размеры библиотеки. Считает их одним запросом при каждом сборе метрик.
+type PGStatsCollector struct {
+	db     *database.Database
+	logger config.Logger
+
+	songs         *prometheus.Desc
+	explicitSongs *prometheus.Desc
+	duplicates    *prometheus.Desc
+	drafts        *prometheus.Desc
+	apiKeys       *prometheus.Desc
+}
+
+func NewPGStatsCollector(db *database.Database, l config.Logger) *PGStatsCollector {
+	return &PGStatsCollector{
+		db:     db,
+		logger: l,
+		songs: prometheus.NewDesc("emlib_songs",
+			"Песни в библиотеке.", nil, nil),
+		explicitSongs: prometheus.NewDesc("emlib_explicit_songs",
+			"Песни с нецензурным текстом.", nil, nil),
+		duplicates: prometheus.NewDesc("emlib_song_duplicates",
+			"Найденные пары возможных дубликатов.", nil, nil),
+		drafts: prometheus.NewDesc("emlib_song_drafts",
+			"Черновики обновлений из внешнего сервиса.", nil, nil),
+		apiKeys: prometheus.NewDesc("emlib_active_api_keys",
+			"Действующие API-ключи.", nil, nil),
+	}
+}
+
+type libraryStats struct {
+	Songs         int64
+	ExplicitSongs int64
+	Duplicates    int64
+	Drafts        int64
+	APIKeys       int64 `db:"api_keys"`
+}
+
+func (c *PGStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.songs
+	ch <- c.explicitSongs
+	ch <- c.duplicates
+	ch <- c.drafts
+	ch <- c.apiKeys
+}
+
+func (c *PGStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	stats, err := c.getStats(ctx)
+	if err != nil {
+		c.logger.Error("Failed to collect library stats", "error", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.songs, prometheus.GaugeValue, float64(stats.Songs))
+	ch <- prometheus.MustNewConstMetric(c.explicitSongs, prometheus.GaugeValue, float64(stats.ExplicitSongs))
+	ch <- prometheus.MustNewConstMetric(c.duplicates, prometheus.GaugeValue, float64(stats.Duplicates))
+	ch <- prometheus.MustNewConstMetric(c.drafts, prometheus.GaugeValue, float64(stats.Drafts))
+	ch <- prometheus.MustNewConstMetric(c.apiKeys, prometheus.GaugeValue, float64(stats.APIKeys))
+}
+
+func (c *PGStatsCollector) getStats(ctx context.Context) (libraryStats, error) {
+	stmt := psql.Select(
+		sm.Columns(
+			psql.Raw("(SELECT COUNT(*) FROM songs)").As("songs"),
+			psql.Raw("(SELECT COUNT(*) FROM songs WHERE explicit)").As("explicit_songs"),
+			psql.Raw("(SELECT COUNT(*) FROM song_duplicates)").As("duplicates"),
+			psql.Raw("(SELECT COUNT(*) FROM song_drafts)").As("drafts"),
+			psql.Raw("(SELECT COUNT(*) FROM api_keys WHERE revoked_at IS NULL)").As("api_keys"),
+		),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	c.logger.DebugContext(ctx, "executing select library stats query", "query", query)
+
+	rows, err := c.db.Conn(ctx).Query(database.WithStatementName(ctx, "select library stats"), query, args...)
+	if err != nil {
+		return libraryStats{}, err
+	}
+
+	return pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[libraryStats])
+}
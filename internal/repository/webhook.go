@@ -0,0 +1,374 @@
+package repository
+
+import (
+	"context"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/pkg/database"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+)
+
+type PGWebhookRepository struct {
+	db     *database.Database
+	logger config.Logger
+}
+
+func NewPGWebhookRepository(db *database.Database, l config.Logger) *PGWebhookRepository {
+	return &PGWebhookRepository{
+		db:     db,
+		logger: l,
+	}
+}
+
+var webhookColumns = []any{"id", "url", "events", "active", "created_at", "updated_at"}
+
+func scanWebhook(row pgx.CollectableRow) (entities.WebhookData, error) {
+	var w entities.WebhookData
+	var events []string
+
+	if err := row.Scan(&w.ID, &w.URL, &events, &w.Active, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return w, err
+	}
+
+	w.Events = make([]entities.EventType, 0, len(events))
+	for _, e := range events {
+		w.Events = append(w.Events, entities.EventType(e))
+	}
+	return w, nil
+}
+
+func eventTypeNames(events []entities.EventType) []string {
+	names := make([]string, 0, len(events))
+	for _, e := range events {
+		names = append(names, string(e))
+	}
+	return names
+}
+
+func (r *PGWebhookRepository) Create(ctx context.Context, data entities.NewWebhookData) (entities.WebhookData, error) {
+	stmt := psql.Insert(
+		im.Into("webhooks", "url", "secret", "events"),
+		im.Values(
+			psql.Arg(data.URL),
+			psql.Arg(data.Secret),
+			psql.Arg(eventTypeNames(data.Events)),
+		),
+		im.Returning(webhookColumns...),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing insert webhook query", "query", query, "url", data.URL)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "insert webhook"), query, args...)
+	if err != nil {
+		return entities.WebhookData{}, err
+	}
+
+	webhook, err := pgx.CollectExactlyOneRow(rows, scanWebhook)
+	if err != nil {
+		return entities.WebhookData{}, err
+	}
+
+	r.logger.DebugContext(ctx, "webhook inserted successfully", "id", webhook.ID)
+	return webhook, nil
+}
+
+func (r *PGWebhookRepository) Get(ctx context.Context, webhookID int) (entities.WebhookData, error) {
+	webhooks, err := r.getList(ctx, &webhookID)
+	if err != nil {
+		return entities.WebhookData{}, err
+	}
+
+	if len(webhooks) == 0 {
+		return entities.WebhookData{}, fmt.Errorf("%w no webhook with id %d", errs.ErrNotFound, webhookID)
+	}
+
+	return webhooks[0], nil
+}
+
+func (r *PGWebhookRepository) GetList(ctx context.Context) ([]entities.WebhookData, error) {
+	webhooks, err := r.getList(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, fmt.Errorf("%w webhooks not found", errs.ErrNotFound)
+	}
+
+	return webhooks, nil
+}
+
+func (r *PGWebhookRepository) getList(ctx context.Context, webhookID *int) ([]entities.WebhookData, error) {
+	stmt := psql.Select(
+		sm.Columns(webhookColumns...),
+		sm.From("webhooks"),
+		sm.OrderBy("id"),
+	)
+
+	if webhookID != nil {
+		stmt.Apply(sm.Where(psql.Quote("id").EQ(psql.Arg(*webhookID))))
+	}
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select webhooks query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select webhooks"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := pgx.CollectRows(rows, scanWebhook)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.DebugContext(ctx, "Successfully queried webhooks", "count", len(webhooks))
+	return webhooks, nil
+}
+
+func (r *PGWebhookRepository) Update(
+	ctx context.Context,
+	webhookID int,
+	data entities.UpdateWebhookData,
+) (entities.WebhookData, error) {
+
+	stmt := psql.Update(
+		um.Table("webhooks"),
+		um.SetCol("updated_at").To(psql.Raw("NOW()")),
+		um.Where(psql.Quote("id").EQ(psql.Arg(webhookID))),
+		um.Returning(webhookColumns...),
+	)
+
+	if data.URL != nil {
+		stmt.Apply(um.SetCol("url").ToArg(*data.URL))
+	}
+
+	if data.Events != nil {
+		stmt.Apply(um.SetCol("events").ToArg(eventTypeNames(data.Events)))
+	}
+
+	if data.Active != nil {
+		stmt.Apply(um.SetCol("active").ToArg(*data.Active))
+	}
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing update webhook query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "update webhook"), query, args...)
+	if err != nil {
+		return entities.WebhookData{}, err
+	}
+
+	webhooks, err := pgx.CollectRows(rows, scanWebhook)
+	if err != nil {
+		return entities.WebhookData{}, err
+	}
+
+	if len(webhooks) == 0 {
+		return entities.WebhookData{}, fmt.Errorf("%w no webhook with id %d", errs.ErrNotFound, webhookID)
+	}
+
+	r.logger.DebugContext(ctx, "webhook updated successfully", "id", webhookID)
+	return webhooks[0], nil
+}
+
+// Удаляет подписку вместе с журналом её доставок.
+func (r *PGWebhookRepository) Delete(ctx context.Context, webhookID int) error {
+	stmt := psql.Delete(
+		dm.From("webhooks"),
+		dm.Where(psql.Quote("id").EQ(psql.Arg(webhookID))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing delete webhook query", "query", query, "args", args)
+
+	ct, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "delete webhook"), query, args...)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("%w no webhook with id %d", errs.ErrNotFound, webhookID)
+	}
+
+	r.logger.DebugContext(ctx, "webhook deleted successfully", "id", webhookID)
+	return nil
+}
+
+func (r *PGWebhookRepository) GetDeliveries(
+	ctx context.Context,
+	filter entities.WebhookDeliveryFilterData,
+) ([]entities.WebhookDeliveryData, error) {
+
+	stmt := psql.Select(
+		sm.Columns(
+			"d.id", "d.webhook_id", "d.event_id", "e.type", "d.status", "d.attempts",
+			"d.last_status_code", "d.last_error", "d.next_attempt_at", "d.delivered_at", "d.created_at",
+		),
+		sm.From("webhook_deliveries").As("d"),
+		sm.InnerJoin("events").As("e").OnEQ(psql.Quote("e", "id"), psql.Quote("d", "event_id")),
+		sm.OrderBy("d.id").Desc(),
+	)
+
+	if filter.WebhookID != nil {
+		stmt.Apply(sm.Where(psql.Quote("d", "webhook_id").EQ(psql.Arg(*filter.WebhookID))))
+	}
+
+	if filter.Status != nil {
+		stmt.Apply(sm.Where(psql.Quote("d", "status").EQ(psql.Arg(*filter.Status))))
+	}
+
+	if filter.Offset != nil {
+		stmt.Apply(sm.Offset(*filter.Offset))
+	}
+
+	if filter.Limit != nil {
+		stmt.Apply(sm.Limit(*filter.Limit))
+	}
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing select webhook deliveries query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "select webhook deliveries"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.WebhookDeliveryData, error) {
+		var d entities.WebhookDeliveryData
+		err := row.Scan(
+			&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Status, &d.Attempts,
+			&d.LastStatusCode, &d.LastError, &d.NextAttemptAt, &d.DeliveredAt, &d.CreatedAt,
+		)
+		return d, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, fmt.Errorf("%w webhook deliveries not found", errs.ErrNotFound)
+	}
+
+	r.logger.DebugContext(ctx, "Successfully queried webhook deliveries", "count", len(deliveries))
+	return deliveries, nil
+}
+
+// Забирает доставки, которые пора отправить, и откладывает их на время lease,
+// чтобы другой экземпляр сервиса не отправил их параллельно.
+func (r *PGWebhookRepository) ClaimDeliveries(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]entities.PendingWebhookDeliveryData, error) {
+
+	due := psql.Select(
+		sm.Columns("d.id"),
+		sm.From("webhook_deliveries").As("d"),
+		sm.InnerJoin("webhooks").As("w").OnEQ(psql.Quote("w", "id"), psql.Quote("d", "webhook_id")),
+		sm.Where(psql.Quote("d", "status").EQ(psql.Arg(entities.DeliveryPending))),
+		sm.Where(psql.Quote("d", "next_attempt_at").LTE(psql.Raw("NOW()"))),
+		sm.Where(psql.Quote("w", "active")),
+		sm.OrderBy("d.next_attempt_at"),
+		sm.Limit(limit),
+		sm.ForUpdate("d").SkipLocked(),
+	)
+
+	stmt := psql.Update(
+		um.TableAs("webhook_deliveries", "d"),
+		um.SetCol("next_attempt_at").To(psql.Raw("NOW() + ?::bigint * INTERVAL '1 millisecond'", lease.Milliseconds())),
+		um.From("webhooks").As("w"),
+		um.CrossJoin("events").As("e"),
+		um.Where(psql.Quote("w", "id").EQ(psql.Quote("d", "webhook_id"))),
+		um.Where(psql.Quote("e", "id").EQ(psql.Quote("d", "event_id"))),
+		um.Where(psql.Quote("d", "id").In(due)),
+		um.Returning(
+			"d.id", "d.attempts", "w.id", "w.url", "w.secret",
+			"e.id", "e.type", "e.song_id", "e.payload", "e.request_id", "e.created_at",
+		),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing claim webhook deliveries query", "query", query, "args", args)
+
+	rows, err := r.db.Conn(ctx).Query(database.WithStatementName(ctx, "claim webhook deliveries"), query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.PendingWebhookDeliveryData, error) {
+		var d entities.PendingWebhookDeliveryData
+		err := row.Scan(
+			&d.ID, &d.Attempts, &d.WebhookID, &d.URL, &d.Secret,
+			&d.Event.ID, &d.Event.Type, &d.Event.SongID, &d.Event.Data, &d.Event.RequestID, &d.Event.CreatedAt,
+		)
+		d.Event.Deleted = d.Event.Type == entities.EventSongDeleted
+		return d, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.DebugContext(ctx, "Successfully claimed webhook deliveries", "count", len(deliveries))
+	return deliveries, nil
+}
+
+func (r *PGWebhookRepository) SaveAttempt(ctx context.Context, deliveryID int64, data entities.WebhookAttemptData) error {
+	stmt := psql.Update(
+		um.Table("webhook_deliveries"),
+		um.SetCol("attempts").To(psql.Raw("attempts + 1")),
+		um.SetCol("status").ToArg(data.Status),
+		um.SetCol("last_status_code").ToArg(data.StatusCode),
+		um.SetCol("last_error").ToArg(data.Error),
+		um.SetCol("next_attempt_at").To(psql.Raw("NOW() + ?::bigint * INTERVAL '1 millisecond'", data.RetryIn.Milliseconds())),
+		um.Where(psql.Quote("id").EQ(psql.Arg(deliveryID))),
+	)
+
+	if data.Status == entities.DeliveryDelivered {
+		stmt.Apply(um.SetCol("delivered_at").To(psql.Raw("NOW()")))
+	}
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing save webhook attempt query", "query", query, "args", args)
+
+	if _, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "save webhook attempt"), query, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Возвращает доставку из dead-letter в очередь, счётчик попыток начинается заново.
+func (r *PGWebhookRepository) Redeliver(ctx context.Context, deliveryID int64) error {
+	stmt := psql.Update(
+		um.Table("webhook_deliveries"),
+		um.SetCol("status").ToArg(entities.DeliveryPending),
+		um.SetCol("attempts").ToArg(0),
+		um.SetCol("next_attempt_at").To(psql.Raw("NOW()")),
+		um.Where(psql.Quote("id").EQ(psql.Arg(deliveryID))),
+		um.Where(psql.Quote("status").EQ(psql.Arg(entities.DeliveryDead))),
+	)
+
+	query, args := stmt.MustBuild(ctx)
+	r.logger.DebugContext(ctx, "executing redeliver webhook query", "query", query, "args", args)
+
+	ct, err := r.db.Conn(ctx).Exec(database.WithStatementName(ctx, "redeliver webhook"), query, args...)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("%w no dead webhook delivery with id %d", errs.ErrNotFound, deliveryID)
+	}
+
+	r.logger.DebugContext(ctx, "webhook delivery requeued", "id", deliveryID)
+	return nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"bufio"
+	"em-library/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Фильтр ненормативной лексики по спискам слов.
+// Списки лежат в каталоге WordListsDir в файлах вида <язык>.txt, по одному слову в строке.
+type WordListContentFilter struct {
+	logger config.Logger
+	words  map[string]struct{}
+}
+
+func NewWordListContentFilter(cfg config.ContentFilterConfig, logger config.Logger) *WordListContentFilter {
+	f := &WordListContentFilter{
+		logger: logger,
+		words:  make(map[string]struct{}),
+	}
+
+	files := make([]string, 0, len(cfg.Languages))
+	if len(cfg.Languages) == 0 {
+		found, err := filepath.Glob(filepath.Join(cfg.WordListsDir, "*.txt"))
+		if err != nil {
+			logger.Error("Failed to list content filter word lists", "dir", cfg.WordListsDir, "error", err)
+		}
+		files = append(files, found...)
+	} else {
+		for _, lang := range cfg.Languages {
+			files = append(files, filepath.Join(cfg.WordListsDir, lang+".txt"))
+		}
+	}
+
+	for _, file := range files {
+		count, err := f.loadWordList(file)
+		if err != nil {
+			logger.Error("Failed to load content filter word list", "file", file, "error", err)
+			continue
+		}
+		logger.Debug("Content filter word list loaded", "file", file, "words", count)
+	}
+
+	if len(f.words) == 0 {
+		logger.Warn("Content filter has no words loaded, all songs will be rated as clean")
+	}
+
+	return f
+}
+
+func (f *WordListContentFilter) loadWordList(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var count int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		f.words[strings.ToLower(word)] = struct{}{}
+		count++
+	}
+
+	return count, scanner.Err()
+}
+
+// Есть ли в тексте хотя бы одно слово из списков.
+func (f *WordListContentFilter) IsExplicit(text string) bool {
+	explicit := false
+	forEachWord(text, func(start, end int) bool {
+		if f.isExplicitWord(text[start:end]) {
+			explicit = true
+			return false
+		}
+		return true
+	})
+	return explicit
+}
+
+// Заменяет буквы найденных слов звёздочками, остальной текст не трогает.
+func (f *WordListContentFilter) Censor(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	last := 0
+	forEachWord(text, func(start, end int) bool {
+		word := text[start:end]
+		if !f.isExplicitWord(word) {
+			return true
+		}
+		b.WriteString(text[last:start])
+		for range word {
+			b.WriteRune('*')
+		}
+		last = end
+		return true
+	})
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+func (f *WordListContentFilter) isExplicitWord(word string) bool {
+	_, ok := f.words[strings.ToLower(word)]
+	return ok
+}
+
+// Вызывает fn для границ каждого слова в тексте, пока fn возвращает true.
+// Тексты хранятся с экранированными переводами строк (\n), поэтому
+// обратный слэш вместе со следующим символом считаем разделителем.
+func forEachWord(text string, fn func(start, end int) bool) {
+	start := -1
+	escaped := false
+
+	for idx, r := range text {
+		isLetter := !escaped && r != '\\' && (unicode.IsLetter(r) || unicode.IsDigit(r))
+
+		if isLetter {
+			if start < 0 {
+				start = idx
+			}
+		} else if start >= 0 {
+			if !fn(start, idx) {
+				return
+			}
+			start = -1
+		}
+
+		escaped = !escaped && r == '\\'
+	}
+
+	if start >= 0 {
+		fn(start, len(text))
+	}
+}
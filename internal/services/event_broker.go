@@ -0,0 +1,85 @@
+package services
+
+import (
+	"em-library/internal/entities"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	eventStreamSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "emlib_event_stream_subscribers",
+		Help: "Текущее число подписчиков потока событий.",
+	})
+	eventStreamLaggedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "emlib_event_stream_lagged_total",
+		Help: "Подписчики потока событий, отключённые из-за переполнения буфера.",
+	})
+)
+
+// Рассылает события подписчикам внутри процесса. Публикация не блокируется:
+// если буфер подписчика заполнен, его канал закрывается, и подписчик должен
+// переподключиться и догнать пропущенное по outbox.
+type EventBroker struct {
+	mu          sync.Mutex
+	bufferSize  int
+	subscribers map[chan entities.EventData]struct{}
+}
+
+func NewEventBroker(bufferSize int) *EventBroker {
+	return &EventBroker{
+		bufferSize:  bufferSize,
+		subscribers: make(map[chan entities.EventData]struct{}),
+	}
+}
+
+func (b *EventBroker) Publish(events []entities.EventData) {
+	if len(events) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		for _, e := range events {
+			select {
+			case ch <- e:
+				continue
+			default:
+			}
+
+			b.remove(ch)
+			eventStreamLaggedTotal.Inc()
+			break
+		}
+	}
+}
+
+func (b *EventBroker) Subscribe() (<-chan entities.EventData, func()) {
+	ch := make(chan entities.EventData, b.bufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	eventStreamSubscribers.Inc()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			b.remove(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// вызывается под мьютексом
+func (b *EventBroker) remove(ch chan entities.EventData) {
+	delete(b.subscribers, ch)
+	close(ch)
+	eventStreamSubscribers.Dec()
+}
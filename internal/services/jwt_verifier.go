@@ -0,0 +1,204 @@
+package services
+
+import (
+	"crypto/rsa"
+	"em-library/config"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Проверяет JWT, подписанные HS256 или RS256, по ключам из локального JWKS-файла.
+type JWKSTokenVerifier struct {
+	logger config.Logger
+	config config.AuthConfig
+	keys   map[string]verificationKey
+}
+
+type verificationKey struct {
+	alg string
+	key any
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+func NewJWKSTokenVerifier(cfg config.AuthConfig, logger config.Logger) *JWKSTokenVerifier {
+	v := &JWKSTokenVerifier{
+		logger: logger,
+		config: cfg,
+		keys:   make(map[string]verificationKey),
+	}
+
+	if cfg.JWKSFile == "" {
+		logger.Debug("JWKS file not set, JWT authentication is disabled")
+		return v
+	}
+
+	if err := v.loadJWKS(cfg.JWKSFile); err != nil {
+		logger.Error("Failed to load JWKS file", "file", cfg.JWKSFile, "error", err)
+		return v
+	}
+	logger.Debug("JWKS file loaded", "file", cfg.JWKSFile, "keys", len(v.keys))
+
+	return v
+}
+
+func (v *JWKSTokenVerifier) loadJWKS(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var set jwkSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return err
+	}
+
+	for idx, k := range set.Keys {
+		key, err := parseJWK(k)
+		if err != nil {
+			v.logger.Error("Skipping invalid JWK", "index", idx, "kid", k.Kid, "error", err)
+			continue
+		}
+		v.keys[k.Kid] = key
+	}
+
+	return nil
+}
+
+func parseJWK(k jwk) (verificationKey, error) {
+	switch k.Kty {
+	case "oct":
+		if k.Alg != "" && k.Alg != "HS256" {
+			return verificationKey{}, fmt.Errorf("unsupported alg %q for oct key", k.Alg)
+		}
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil || len(secret) == 0 {
+			return verificationKey{}, errors.New("invalid k")
+		}
+		return verificationKey{alg: "HS256", key: secret}, nil
+
+	case "RSA":
+		if k.Alg != "" && k.Alg != "RS256" {
+			return verificationKey{}, fmt.Errorf("unsupported alg %q for RSA key", k.Alg)
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil || len(n) == 0 {
+			return verificationKey{}, errors.New("invalid n")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 {
+			return verificationKey{}, errors.New("invalid e")
+		}
+		return verificationKey{alg: "RS256", key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+	}
+
+	return verificationKey{}, fmt.Errorf("unsupported kty %q", k.Kty)
+}
+
+// Ключ выбирается по kid из заголовка токена. Токен без kid принимается,
+// только если в JWKS ровно один ключ.
+func (v *JWKSTokenVerifier) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := v.keys[kid]
+	if !ok && kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			key, ok = k, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	if token.Method.Alg() != key.alg {
+		return nil, fmt.Errorf("algorithm %s does not match key %q", token.Method.Alg(), kid)
+	}
+
+	return key.key, nil
+}
+
+func (v *JWKSTokenVerifier) Verify(token string) (*entities.Principal, error) {
+	if len(v.keys) == 0 {
+		return nil, fmt.Errorf("%w JWT authentication is not configured", errs.ErrUnauthorized)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "RS256"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if v.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(v.config.Issuer))
+	}
+	if v.config.Audience != "" {
+		options = append(options, jwt.WithAudience(v.config.Audience))
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, v.keyFunc, options...); err != nil {
+		return nil, fmt.Errorf("%w %v", errs.ErrUnauthorized, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w token has no subject", errs.ErrUnauthorized)
+	}
+
+	name, _ := claims["name"].(string)
+
+	return &entities.Principal{
+		Subject: subject,
+		Name:    name,
+		Method:  entities.AuthJWT,
+		Role:    roleFromClaims(claims),
+	}, nil
+}
+
+// Роль берётся из claim role или из списка roles (выбирается старшая).
+// Без роли или с неизвестной ролью клиент получает права viewer.
+func roleFromClaims(claims jwt.MapClaims) entities.Role {
+	var candidates []entities.Role
+
+	if role, ok := claims["role"].(string); ok {
+		candidates = append(candidates, entities.Role(role))
+	}
+	if roles, ok := claims["roles"].([]any); ok {
+		for _, r := range roles {
+			if role, ok := r.(string); ok {
+				candidates = append(candidates, entities.Role(role))
+			}
+		}
+	}
+
+	result := entities.RoleViewer
+	for _, role := range candidates {
+		if role.Valid() && role.Allows(result) {
+			result = role
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"sync"
+	"time"
+)
+
+// Ведро, к которому не обращались дольше этого времени, уже заведомо полное,
+// и его можно удалить: при следующем запросе оно создастся заново.
+const rateLimitBucketTTL = time.Hour
+
+// Хранит ведра token bucket в памяти процесса. Подходит для одного экземпляра сервиса,
+// при нескольких экземплярах каждый считает лимит отдельно.
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (l *MemoryRateLimiter) Take(_ context.Context, key string, rule entities.RateLimitRule) (entities.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(rule.Burst)}
+		l.buckets[key] = bucket
+	} else {
+		bucket.tokens = rule.Refill(bucket.tokens, now.Sub(bucket.updatedAt))
+	}
+	bucket.updatedAt = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	return rule.Result(bucket.tokens, allowed), nil
+}
+
+func (l *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.updatedAt) > rateLimitBucketTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
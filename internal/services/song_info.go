@@ -6,21 +6,51 @@ import (
 	"em-library/internal/entities"
 	"em-library/internal/errs"
 	"fmt"
+	"net/http"
+	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"resty.dev/v3"
 )
 
+var tracer = otel.Tracer("em-library/internal/services")
+
+var (
+	infoServiceRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "emlib_infoservice_request_duration_seconds",
+		Help:    "Время ответа сервиса с данными песен.",
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"outcome"})
+
+	infoServiceErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "emlib_infoservice_errors_total",
+		Help: "Ошибки при обращении к сервису с данными песен.",
+	}, []string{"reason"})
+)
+
 type RESTSongInfoService struct {
 	logger config.Logger
-	config config.ServicesConfig
+	config atomic.Pointer[config.ServicesConfig]
 }
 
 func NewRESTSongInfoService(cfg config.ServicesConfig, logger config.Logger) *RESTSongInfoService {
-	return &RESTSongInfoService{
-		config: cfg,
+	s := &RESTSongInfoService{
 		logger: logger,
 	}
+	s.config.Store(&cfg)
+	return s
+}
+
+// Новые адрес и таймаут действуют для запросов, начатых после вызова
+func (s *RESTSongInfoService) Reload(cfg config.ServicesConfig) {
+	s.config.Store(&cfg)
 }
 
 type SongDetailResponse struct {
@@ -30,6 +60,23 @@ type SongDetailResponse struct {
 }
 
 func (s *RESTSongInfoService) GetInfo(ctx context.Context, band, song string) (*entities.SongDetail, error) {
+	start := time.Now()
+	cfg := s.config.Load()
+
+	url := cfg.InfoServiceURL + "/info"
+	ctx, span := tracer.Start(ctx, "GET /info",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodGet,
+			semconv.URLFull(url),
+		),
+	)
+	defer span.End()
+
+	// traceparent связывает трассу внешнего сервиса с нашей
+	headers := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(headers))
+
 	c := resty.New()
 	defer c.Close()
 
@@ -47,8 +94,9 @@ func (s *RESTSongInfoService) GetInfo(ctx context.Context, band, song string) (*
 			SetQueryParam("group", band).
 			SetQueryParam("song", song).
 			SetHeader("Accept", "application/json").
+			SetHeaderMultiValues(headers).
 			SetResult(&responseData).
-			Get(s.config.InfoServiceURL + "/info")
+			Get(url)
 
 		resultCh <- struct {
 			resp *resty.Response
@@ -60,24 +108,34 @@ func (s *RESTSongInfoService) GetInfo(ctx context.Context, band, song string) (*
 	case result := <-resultCh:
 		resp = result.resp
 		err = result.err
-	case <-time.After(time.Duration(s.config.Timeout) * time.Millisecond):
-		return nil, errs.ErrServiceProblem{Err: fmt.Errorf("SongDetailService timeout")}
+	case <-time.After(time.Duration(cfg.Timeout) * time.Millisecond):
+		err := fmt.Errorf("SongDetailService timeout")
+		observeInfoServiceError(span, start, "timeout", err)
+		return nil, errs.ErrServiceProblem{Err: err}
 	}
 
 	if err != nil {
+		observeInfoServiceError(span, start, "transport", err)
 		return nil, errs.ErrServiceProblem{Err: err}
 	}
 
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode()))
+
 	// если 400-ка или 500-ка
 	if resp.IsError() {
-		return nil, errs.ErrServiceProblem{Err: fmt.Errorf("SongDetailService fail HTTP status:%d Detail:%+v", resp.StatusCode(), resp)}
+		err := fmt.Errorf("SongDetailService fail HTTP status:%d Detail:%+v", resp.StatusCode(), resp)
+		observeInfoServiceError(span, start, "http_status", err)
+		return nil, errs.ErrServiceProblem{Err: err}
 	}
 
 	t, err := time.Parse("02.01.2006", responseData.ReleaseDate)
 	if err != nil {
+		observeInfoServiceError(span, start, "decode", err)
 		return nil, errs.ErrServiceProblem{Err: fmt.Errorf("failed parsing SongDetailService response %w", err)}
 	}
 
+	infoServiceRequestDuration.WithLabelValues("success").Observe(time.Since(start).Seconds())
+
 	songDetail := entities.SongDetail{
 		ReleaseDate: t,
 		Link:        responseData.Link,
@@ -86,3 +144,34 @@ func (s *RESTSongInfoService) GetInfo(ctx context.Context, band, song string) (*
 
 	return &songDetail, nil
 }
+
+func observeInfoServiceError(span trace.Span, start time.Time, reason string, err error) {
+	infoServiceRequestDuration.WithLabelValues("error").Observe(time.Since(start).Seconds())
+	infoServiceErrorsTotal.WithLabelValues(reason).Inc()
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, reason)
+}
+
+// Проверяет, что сервис с данными песен отвечает. Ответ 4xx на запрос без параметров
+// считается нормальным: сервис живой, просто не нашёл песню.
+func (s *RESTSongInfoService) CheckHealth(ctx context.Context) error {
+	cfg := s.config.Load()
+
+	c := resty.New()
+	defer c.Close()
+
+	resp, err := c.R().
+		SetContext(ctx).
+		SetTimeout(time.Duration(cfg.Timeout) * time.Millisecond).
+		Get(cfg.InfoServiceURL + "/info")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() >= http.StatusInternalServerError {
+		return fmt.Errorf("SongDetailService fail HTTP status:%d", resp.StatusCode())
+	}
+
+	return nil
+}
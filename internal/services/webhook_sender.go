@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"em-library/config"
+	"em-library/internal/entities"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"resty.dev/v3"
+)
+
+var webhookRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "emlib_webhook_requests_total",
+	Help: "Запросы к подписчикам вебхуков.",
+}, []string{"outcome"})
+
+// Отправляет события подписчикам POST-запросом с JSON события в теле.
+// Подпись в X-EMLib-Signature — HMAC-SHA256 от "<timestamp>.<тело>" на секрете подписки,
+// timestamp передаётся в X-EMLib-Timestamp, чтобы подписчик мог отбросить старые запросы.
+type HTTPWebhookSender struct {
+	logger config.Logger
+	config config.WebhooksConfig
+	client *resty.Client
+}
+
+func NewHTTPWebhookSender(cfg config.WebhooksConfig, logger config.Logger) *HTTPWebhookSender {
+	return &HTTPWebhookSender{
+		logger: logger,
+		config: cfg,
+		client: resty.New().
+			SetTimeout(time.Duration(cfg.Timeout) * time.Millisecond).
+			SetRedirectPolicy(resty.NoRedirectPolicy()),
+	}
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, delivery entities.PendingWebhookDeliveryData) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("User-Agent", "em-library-webhooks").
+		SetHeader("X-EMLib-Event", string(delivery.Event.Type)).
+		SetHeader("X-EMLib-Delivery", strconv.FormatInt(delivery.ID, 10)).
+		SetHeader("X-EMLib-Timestamp", timestamp).
+		SetHeader("X-EMLib-Signature", "sha256="+SignWebhook(delivery.Secret, timestamp, body)).
+		SetBody(body).
+		Post(delivery.URL)
+	if err != nil {
+		webhookRequestsTotal.WithLabelValues("transport").Inc()
+		s.logger.DebugContext(ctx, "Webhook request failed", "delivery_id", delivery.ID, "url", delivery.URL, "error", err)
+		return 0, err
+	}
+
+	// редиректы не выполняются: подписчик должен принимать запросы по указанному адресу
+	if resp.StatusCode() < 200 || resp.StatusCode() >= 300 {
+		webhookRequestsTotal.WithLabelValues("http_status").Inc()
+		return resp.StatusCode(), fmt.Errorf("webhook endpoint responded with HTTP status %d", resp.StatusCode())
+	}
+
+	webhookRequestsTotal.WithLabelValues("success").Inc()
+	return resp.StatusCode(), nil
+}
+
+// Подпись тела запроса в hex, так же её должен посчитать подписчик
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
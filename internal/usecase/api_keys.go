@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// длина видимой части ключа, по которой его можно узнать в списке
+const apiKeyVisiblePrefixLen = len(apiKeyPrefix) + 6
+
+type CreateAPIKeyUseCase interface {
+	Execute(ctx context.Context, name string, role entities.Role) (*entities.CreatedAPIKeyData, error)
+}
+
+type createAPIKeyUseCase struct {
+	apiKeyRepo APIKeyRepo
+}
+
+func NewCreateAPIKeyUseCase(kr APIKeyRepo) CreateAPIKeyUseCase {
+	return &createAPIKeyUseCase{
+		apiKeyRepo: kr,
+	}
+}
+
+// Выпускает новый ключ. В базе остаётся только SHA-256 хэш,
+// поэтому восстановить ключ после создания невозможно.
+func (u *createAPIKeyUseCase) Execute(
+	ctx context.Context,
+	name string,
+	role entities.Role,
+) (*entities.CreatedAPIKeyData, error) {
+
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w api key name is required", errs.ErrInvalidInput)
+	}
+
+	if role == "" {
+		role = entities.RoleViewer
+	}
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w unknown role %q", errs.ErrInvalidInput, role)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	data, err := u.apiKeyRepo.Create(ctx, entities.NewAPIKeyData{
+		Name:   name,
+		Prefix: key[:apiKeyVisiblePrefixLen],
+		Hash:   hashAPIKey(key),
+		Role:   role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.CreatedAPIKeyData{
+		APIKeyData: data,
+		Key:        key,
+	}, nil
+}
+
+type GetAPIKeysUseCase interface {
+	Execute(ctx context.Context) ([]entities.APIKeyData, error)
+}
+
+type getAPIKeysUseCase struct {
+	apiKeyRepo APIKeyRepo
+}
+
+func NewGetAPIKeysUseCase(kr APIKeyRepo) GetAPIKeysUseCase {
+	return &getAPIKeysUseCase{
+		apiKeyRepo: kr,
+	}
+}
+
+func (u *getAPIKeysUseCase) Execute(ctx context.Context) ([]entities.APIKeyData, error) {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return u.apiKeyRepo.GetList(ctx)
+}
+
+type RevokeAPIKeyUseCase interface {
+	Execute(ctx context.Context, keyID int) error
+}
+
+type revokeAPIKeyUseCase struct {
+	apiKeyRepo APIKeyRepo
+}
+
+func NewRevokeAPIKeyUseCase(kr APIKeyRepo) RevokeAPIKeyUseCase {
+	return &revokeAPIKeyUseCase{
+		apiKeyRepo: kr,
+	}
+}
+
+func (u *revokeAPIKeyUseCase) Execute(ctx context.Context, keyID int) error {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return err
+	}
+
+	return u.apiKeyRepo.Revoke(ctx, keyID)
+}
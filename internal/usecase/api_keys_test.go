@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// В репозиторий уходит только хэш, а сам ключ возвращается один раз
+func TestCreateAPIKeyUseCase_Execute_Success(t *testing.T) {
+	mockKeyRepo := new(MockAPIKeyRepo)
+	useCase := usecase.NewCreateAPIKeyUseCase(mockKeyRepo)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+
+	var saved entities.NewAPIKeyData
+	mockKeyRepo.On("Create", ctx, mock.AnythingOfType("entities.NewAPIKeyData")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(entities.NewAPIKeyData) }).
+		Return(entities.APIKeyData{ID: 1, Name: "importer"}, nil)
+
+	created, err := useCase.Execute(ctx, " importer ", entities.RoleEditor)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, "emlib_"))
+	assert.Equal(t, "importer", saved.Name)
+	assert.Equal(t, sha256Hex(created.Key), saved.Hash)
+	assert.True(t, strings.HasPrefix(created.Key, saved.Prefix))
+	assert.Equal(t, 1, created.ID)
+}
+
+func TestCreateAPIKeyUseCase_Execute_EmptyName(t *testing.T) {
+	mockKeyRepo := new(MockAPIKeyRepo)
+	useCase := usecase.NewCreateAPIKeyUseCase(mockKeyRepo)
+
+	created, err := useCase.Execute(contextWithRole(entities.RoleAdmin), "  ", "")
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+	assert.Nil(t, created)
+	mockKeyRepo.AssertNotCalled(t, "Create")
+}
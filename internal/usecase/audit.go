@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"errors"
+)
+
+// Пишет запись в журнал аудита от имени клиента из контекста.
+// Вызывается внутри транзакции изменения, чтобы запись не потерялась и не осталась без изменения.
+func writeAudit(
+	ctx context.Context,
+	repo AuditRepo,
+	action entities.AuditAction,
+	songID int,
+	before, after any,
+) error {
+	var actor string
+	if principal, ok := entities.PrincipalFromContext(ctx); ok {
+		actor = principal.Subject
+	}
+
+	return repo.Create(ctx, entities.NewAuditRecordData{
+		Actor:     actor,
+		Action:    action,
+		Entity:    entities.AuditEntitySong,
+		EntityID:  songID,
+		Before:    before,
+		After:     after,
+		RequestID: entities.RequestIDFromContext(ctx),
+	})
+}
+
+// Текущее состояние песни с текстом. Песня без текста считается песней с пустым текстом.
+func loadSongAuditData(ctx context.Context, sr SongRepo, lr LyricsRepo, songID int) (entities.SongAuditData, error) {
+	songs, err := sr.GetList(ctx, entities.SongFilterData{ID: &songID})
+	if err != nil {
+		return entities.SongAuditData{}, err
+	}
+
+	lyrics, err := lr.Get(ctx, songID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return entities.SongAuditData{}, err
+	}
+
+	return songAuditData(songs[0], lyrics.Content), nil
+}
+
+func songAuditData(song entities.SongData, lyrics string) entities.SongAuditData {
+	return entities.SongAuditData{
+		ID:          song.ID,
+		Band:        song.Band,
+		Song:        song.Song,
+		ReleaseDate: formatDate(song.ReleaseDate),
+		Link:        song.Link,
+		Explicit:    song.Explicit,
+		Lyrics:      lyrics,
+	}
+}
+
+// Состояние песни после применения изменений
+func applySongUpdate(state entities.SongAuditData, data entities.UpdateSongData) entities.SongAuditData {
+	if data.Band != nil {
+		state.Band = *data.Band
+	}
+	if data.Song != nil {
+		state.Song = *data.Song
+	}
+	if data.ReleaseDate != nil {
+		state.ReleaseDate = formatDate(*data.ReleaseDate)
+	}
+	if data.Link != nil {
+		state.Link = *data.Link
+	}
+	if data.Explicit != nil {
+		state.Explicit = data.Explicit
+	}
+	if data.Lyrics != nil {
+		state.Lyrics = *data.Lyrics
+	}
+	return state
+}
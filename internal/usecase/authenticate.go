@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Все выпущенные API-ключи начинаются с этого префикса, по нему ключ
+// в заголовке Authorization отличается от JWT.
+const apiKeyPrefix = "emlib_"
+
+type AuthenticateUseCase interface {
+	Execute(ctx context.Context, credentials entities.Credentials) (*entities.Principal, error)
+}
+
+type authenticateUseCase struct {
+	apiKeyRepo    APIKeyRepo
+	tokenVerifier TokenVerifier
+}
+
+func NewAuthenticateUseCase(kr APIKeyRepo, tv TokenVerifier) AuthenticateUseCase {
+	return &authenticateUseCase{
+		apiKeyRepo:    kr,
+		tokenVerifier: tv,
+	}
+}
+
+func (u *authenticateUseCase) Execute(ctx context.Context, credentials entities.Credentials) (*entities.Principal, error) {
+	apiKey := credentials.APIKey
+	if apiKey == "" && strings.HasPrefix(credentials.Bearer, apiKeyPrefix) {
+		apiKey = credentials.Bearer
+	}
+
+	if apiKey != "" {
+		key, err := u.apiKeyRepo.MarkUsed(ctx, hashAPIKey(apiKey))
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, fmt.Errorf("%w invalid or revoked api key", errs.ErrUnauthorized)
+			}
+			return nil, err
+		}
+
+		return &entities.Principal{
+			Subject: "api_key:" + strconv.Itoa(key.ID),
+			Name:    key.Name,
+			Method:  entities.AuthAPIKey,
+			Role:    key.Role,
+		}, nil
+	}
+
+	if credentials.Bearer != "" {
+		return u.tokenVerifier.Verify(credentials.Bearer)
+	}
+
+	return nil, fmt.Errorf("%w no credentials", errs.ErrUnauthorized)
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
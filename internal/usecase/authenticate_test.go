@@ -0,0 +1,96 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAuthenticateUseCase_Execute_APIKeyHeader(t *testing.T) {
+	mockKeyRepo := new(MockAPIKeyRepo)
+	mockVerifier := new(MockTokenVerifier)
+	useCase := usecase.NewAuthenticateUseCase(mockKeyRepo, mockVerifier)
+
+	ctx := context.Background()
+	key := "emlib_secret"
+
+	mockKeyRepo.On("MarkUsed", ctx, sha256Hex(key)).Return(entities.APIKeyData{ID: 7, Name: "importer"}, nil)
+
+	principal, err := useCase.Execute(ctx, entities.Credentials{APIKey: key})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.Principal{Subject: "api_key:7", Name: "importer", Method: entities.AuthAPIKey}, principal)
+	mockKeyRepo.AssertExpectations(t)
+	mockVerifier.AssertNotCalled(t, "Verify")
+}
+
+// API-ключ можно передать и в Authorization: Bearer, он узнаётся по префиксу
+func TestAuthenticateUseCase_Execute_APIKeyBearer(t *testing.T) {
+	mockKeyRepo := new(MockAPIKeyRepo)
+	mockVerifier := new(MockTokenVerifier)
+	useCase := usecase.NewAuthenticateUseCase(mockKeyRepo, mockVerifier)
+
+	ctx := context.Background()
+	key := "emlib_secret"
+
+	mockKeyRepo.On("MarkUsed", ctx, sha256Hex(key)).Return(entities.APIKeyData{ID: 7, Name: "importer"}, nil)
+
+	principal, err := useCase.Execute(ctx, entities.Credentials{Bearer: key})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.AuthAPIKey, principal.Method)
+	mockVerifier.AssertNotCalled(t, "Verify")
+}
+
+// Неизвестный или отозванный ключ
+func TestAuthenticateUseCase_Execute_InvalidAPIKey(t *testing.T) {
+	mockKeyRepo := new(MockAPIKeyRepo)
+	mockVerifier := new(MockTokenVerifier)
+	useCase := usecase.NewAuthenticateUseCase(mockKeyRepo, mockVerifier)
+
+	ctx := context.Background()
+
+	mockKeyRepo.On("MarkUsed", ctx, sha256Hex("emlib_revoked")).Return(entities.APIKeyData{}, errs.ErrNotFound)
+
+	principal, err := useCase.Execute(ctx, entities.Credentials{APIKey: "emlib_revoked"})
+
+	assert.ErrorIs(t, err, errs.ErrUnauthorized)
+	assert.Nil(t, principal)
+}
+
+func TestAuthenticateUseCase_Execute_JWT(t *testing.T) {
+	mockKeyRepo := new(MockAPIKeyRepo)
+	mockVerifier := new(MockTokenVerifier)
+	useCase := usecase.NewAuthenticateUseCase(mockKeyRepo, mockVerifier)
+
+	expected := &entities.Principal{Subject: "user-1", Method: entities.AuthJWT}
+	mockVerifier.On("Verify", "header.payload.signature").Return(expected, nil)
+
+	principal, err := useCase.Execute(context.Background(), entities.Credentials{Bearer: "header.payload.signature"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, principal)
+	mockKeyRepo.AssertNotCalled(t, "MarkUsed")
+}
+
+func TestAuthenticateUseCase_Execute_NoCredentials(t *testing.T) {
+	mockKeyRepo := new(MockAPIKeyRepo)
+	mockVerifier := new(MockTokenVerifier)
+	useCase := usecase.NewAuthenticateUseCase(mockKeyRepo, mockVerifier)
+
+	principal, err := useCase.Execute(context.Background(), entities.Credentials{})
+
+	assert.ErrorIs(t, err, errs.ErrUnauthorized)
+	assert.Nil(t, principal)
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"fmt"
+)
+
+// Проверяет, что у клиента из контекста есть права роли required.
+// Запрос без клиента в контексте считается неаутентифицированным.
+func authorize(ctx context.Context, required entities.Role) error {
+	principal, ok := entities.PrincipalFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("%w no principal in context", errs.ErrUnauthorized)
+	}
+
+	if !principal.Role.Allows(required) {
+		return fmt.Errorf("%w %s role required, %s has %q", errs.ErrForbidden, required, principal.Subject, principal.Role)
+	}
+
+	return nil
+}
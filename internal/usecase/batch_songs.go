@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"errors"
+	"fmt"
+)
+
+// столько песен можно изменить одним пакетом, списком или фильтром
+const maxBatchSize = 500
+
+type BatchSongsUseCase interface {
+	Execute(ctx context.Context, data entities.BatchSongsData) (*entities.BatchResultData, error)
+}
+
+type batchSongsUseCase struct {
+	transactionManager TransactionManager
+	songRepo           SongRepo
+	lyricsRepo         LyricsRepo
+	auditRepo          AuditRepo
+	eventRepo          EventRepo
+	eventPublisher     EventPublisher
+	contentFilter      ContentFilter
+}
+
+func NewBatchSongsUseCase(
+	tm TransactionManager,
+	sr SongRepo,
+	lr LyricsRepo,
+	ar AuditRepo,
+	er EventRepo,
+	ep EventPublisher,
+	cf ContentFilter,
+) BatchSongsUseCase {
+	return &batchSongsUseCase{
+		transactionManager: tm,
+		songRepo:           sr,
+		lyricsRepo:         lr,
+		auditRepo:          ar,
+		eventRepo:          er,
+		eventPublisher:     ep,
+		contentFilter:      cf,
+	}
+}
+
+// Выполняет операции над песнями атомарно или по отдельности. Ошибки отдельных операций
+// возвращаются в результате, ошибкой юзкейса завершаются только неверный запрос и отказ в доступе.
+// При пробном запуске песни только проверяются, и в результате показывается, какими они станут.
+func (u *batchSongsUseCase) Execute(ctx context.Context, data entities.BatchSongsData) (*entities.BatchResultData, error) {
+	if err := validateBatch(data); err != nil {
+		return nil, err
+	}
+
+	// удаление песни требует больших прав, чем изменение
+	role := entities.RoleEditor
+	if data.Action == entities.BatchDelete {
+		role = entities.RoleAdmin
+	}
+	for _, op := range data.Operations {
+		if op.Action == entities.BatchDelete {
+			role = entities.RoleAdmin
+		}
+	}
+	if err := authorize(ctx, role); err != nil {
+		return nil, err
+	}
+
+	ops, err := u.operations(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entities.BatchResultData{
+		Mode:   data.Mode,
+		DryRun: data.DryRun,
+		Items:  make([]entities.BatchItemResultData, len(ops)),
+	}
+	if result.Mode == "" {
+		result.Mode = entities.BatchAtomic
+	}
+	for i, op := range ops {
+		result.Items[i] = entities.BatchItemResultData{Index: i, Action: op.Action, SongID: op.SongID}
+	}
+
+	switch {
+	case data.DryRun:
+		u.preview(ctx, ops, result.Items)
+	case result.Mode == entities.BatchBestEffort:
+		u.applyEach(ctx, ops, result.Items)
+	default:
+		u.applyAll(ctx, ops, result.Items)
+	}
+
+	for _, item := range result.Items {
+		switch item.Status {
+		case entities.BatchItemApplied, entities.BatchItemPlanned:
+			result.Succeeded++
+		case entities.BatchItemFailed:
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+func validateBatch(data entities.BatchSongsData) error {
+	switch data.Mode {
+	case "", entities.BatchAtomic, entities.BatchBestEffort:
+	default:
+		return fmt.Errorf("%w unknown batch mode %q", errs.ErrInvalidInput, data.Mode)
+	}
+
+	if data.Filter != nil {
+		if len(data.Operations) > 0 {
+			return fmt.Errorf("%w operations and filter cannot be combined", errs.ErrInvalidInput)
+		}
+		f := data.Filter
+		if f.ID == nil && f.Band == nil && f.Song == nil && f.ReleaseDateFrom == nil &&
+			f.ReleaseDateTo == nil && f.Explicit == nil {
+			return fmt.Errorf("%w filter must not be empty", errs.ErrInvalidInput)
+		}
+		return validateBatchAction(data.Action, data.Update)
+	}
+
+	if len(data.Operations) == 0 {
+		return fmt.Errorf("%w operations or filter is required", errs.ErrInvalidInput)
+	}
+	if len(data.Operations) > maxBatchSize {
+		return fmt.Errorf("%w batch must not contain more than %d operations", errs.ErrInvalidInput, maxBatchSize)
+	}
+	for i, op := range data.Operations {
+		if op.SongID <= 0 {
+			return fmt.Errorf("%w operation %d: invalid song ID %d", errs.ErrInvalidInput, i, op.SongID)
+		}
+		if err := validateBatchAction(op.Action, op.Update); err != nil {
+			return fmt.Errorf("%w (operation %d)", err, i)
+		}
+	}
+	return nil
+}
+
+func validateBatchAction(action entities.BatchAction, update entities.UpdateSongData) error {
+	switch action {
+	case entities.BatchDelete:
+		return nil
+	case entities.BatchUpdate:
+		if update.Band == nil && update.Song == nil && update.ReleaseDate == nil && update.Link == nil && update.Lyrics == nil {
+			return fmt.Errorf("%w nothing to update", errs.ErrInvalidInput)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w unknown action %q", errs.ErrInvalidInput, action)
+	}
+}
+
+// Операции пакета. Для фильтра это одно действие над каждой найденной песней.
+func (u *batchSongsUseCase) operations(ctx context.Context, data entities.BatchSongsData) ([]entities.BatchOperationData, error) {
+	ops := data.Operations
+
+	if data.Filter != nil {
+		filter := *data.Filter
+		offset, limit := 0, maxBatchSize+1
+		filter.Offset = &offset
+		filter.Limit = &limit
+
+		songs, err := u.songRepo.GetList(ctx, filter)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+		if len(songs) > maxBatchSize {
+			return nil, fmt.Errorf("%w filter matches more than %d songs", errs.ErrInvalidInput, maxBatchSize)
+		}
+
+		ops = make([]entities.BatchOperationData, len(songs))
+		for i, song := range songs {
+			ops[i] = entities.BatchOperationData{Action: data.Action, SongID: song.ID, Update: data.Update}
+		}
+	}
+
+	// рейтинг пересчитываем только при изменении текста, как и при обычном изменении
+	result := make([]entities.BatchOperationData, len(ops))
+	for i, op := range ops {
+		if op.Action == entities.BatchUpdate && op.Update.Lyrics != nil {
+			explicit := u.contentFilter.IsExplicit(*op.Update.Lyrics)
+			op.Update.Explicit = &explicit
+		}
+		result[i] = op
+	}
+	return result, nil
+}
+
+// Все операции в одной транзакции. На первой ошибке транзакция откатывается,
+// выполненные до неё операции помечаются откаченными, а оставшиеся — пропущенными.
+func (u *batchSongsUseCase) applyAll(ctx context.Context, ops []entities.BatchOperationData, items []entities.BatchItemResultData) {
+	var events []entities.EventData
+	failed := -1
+
+	err := u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		events = nil
+		for i, op := range ops {
+			song, opEvents, err := u.apply(ctx, op)
+			if err != nil {
+				failed = i
+				setBatchItemError(&items[i], err)
+				return err
+			}
+
+			items[i].Status = entities.BatchItemApplied
+			items[i].Song = &song
+			events = append(events, opEvents...)
+		}
+		return nil
+	})
+
+	if err == nil {
+		u.eventPublisher.Publish(events)
+		return
+	}
+
+	// транзакция могла не дойти до операций, тогда ошибка у всех
+	if failed < 0 {
+		for i := range items {
+			setBatchItemError(&items[i], err)
+		}
+		return
+	}
+
+	for i := range items {
+		switch {
+		case i < failed:
+			items[i].Status = entities.BatchItemRolledBack
+			items[i].Song = nil
+		case i > failed:
+			items[i].Status = entities.BatchItemSkipped
+		}
+	}
+}
+
+// Каждая операция в своей транзакции
+func (u *batchSongsUseCase) applyEach(ctx context.Context, ops []entities.BatchOperationData, items []entities.BatchItemResultData) {
+	for i, op := range ops {
+		var song entities.SongAuditData
+		var events []entities.EventData
+
+		err := u.transactionManager.Do(ctx, func(ctx context.Context) error {
+			var err error
+			song, events, err = u.apply(ctx, op)
+			return err
+		})
+
+		if err != nil {
+			setBatchItemError(&items[i], err)
+			continue
+		}
+
+		u.eventPublisher.Publish(events)
+		items[i].Status = entities.BatchItemApplied
+		items[i].Song = &song
+	}
+}
+
+// Проверяет, что песни есть, и показывает их состояние после операций, ничего не меняя
+func (u *batchSongsUseCase) preview(ctx context.Context, ops []entities.BatchOperationData, items []entities.BatchItemResultData) {
+	for i, op := range ops {
+		song, err := loadSongAuditData(ctx, u.songRepo, u.lyricsRepo, op.SongID)
+		if err != nil {
+			setBatchItemError(&items[i], err)
+			continue
+		}
+
+		if op.Action == entities.BatchUpdate {
+			song = applySongUpdate(song, op.Update)
+		}
+
+		items[i].Status = entities.BatchItemPlanned
+		items[i].Song = &song
+	}
+}
+
+// Выполняет операцию в текущей транзакции и возвращает состояние песни после изменения
+// или до удаления
+func (u *batchSongsUseCase) apply(ctx context.Context, op entities.BatchOperationData) (entities.SongAuditData, []entities.EventData, error) {
+	before, err := loadSongAuditData(ctx, u.songRepo, u.lyricsRepo, op.SongID)
+	if err != nil {
+		return entities.SongAuditData{}, nil, err
+	}
+
+	if op.Action == entities.BatchDelete {
+		if err := u.lyricsRepo.Delete(ctx, op.SongID); err != nil {
+			return entities.SongAuditData{}, nil, err
+		}
+		if err := u.songRepo.Delete(ctx, op.SongID); err != nil {
+			return entities.SongAuditData{}, nil, err
+		}
+		if err := writeAudit(ctx, u.auditRepo, entities.AuditDelete, op.SongID, before, nil); err != nil {
+			return entities.SongAuditData{}, nil, err
+		}
+
+		events, err := writeEvents(ctx, u.eventRepo, op.SongID, &before, nil)
+		return before, events, err
+	}
+
+	if err := u.songRepo.Update(ctx, op.SongID, op.Update); err != nil {
+		return entities.SongAuditData{}, nil, err
+	}
+	if err := updateLyrics(ctx, u.lyricsRepo, op.SongID, op.Update); err != nil {
+		return entities.SongAuditData{}, nil, err
+	}
+
+	after := applySongUpdate(before, op.Update)
+	if err := writeAudit(ctx, u.auditRepo, entities.AuditUpdate, op.SongID, before, after); err != nil {
+		return entities.SongAuditData{}, nil, err
+	}
+
+	events, err := writeEvents(ctx, u.eventRepo, op.SongID, &before, &after)
+	return after, events, err
+}
+
+// Описание ошибки операции для клиента. Внутренние ошибки остаются в Err для лога.
+func setBatchItemError(item *entities.BatchItemResultData, err error) {
+	item.Status = entities.BatchItemFailed
+	item.Err = err
+
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+		item.Error = "not found"
+	case errors.Is(err, errs.ErrAlreadyExists):
+		item.Error = "already exists"
+	case errors.Is(err, errs.ErrInvalidInput):
+		item.Error = err.Error()
+	default:
+		item.Error = "server error"
+	}
+}
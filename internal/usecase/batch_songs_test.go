@@ -0,0 +1,228 @@
+package usecase_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type batchMocks struct {
+	tm          *MockTransactionManager
+	songRepo    *MockSongRepo
+	lyricsRepo  *MockLyricsRepo
+	auditRepo   *MockAuditRepo
+	eventRepo   *MockEventRepo
+	eventBroker *MockEventBroker
+}
+
+func newBatchSongsUseCase() (usecase.BatchSongsUseCase, batchMocks) {
+	m := batchMocks{
+		tm:          new(MockTransactionManager),
+		songRepo:    new(MockSongRepo),
+		lyricsRepo:  new(MockLyricsRepo),
+		auditRepo:   new(MockAuditRepo),
+		eventRepo:   new(MockEventRepo),
+		eventBroker: new(MockEventBroker),
+	}
+	useCase := usecase.NewBatchSongsUseCase(m.tm, m.songRepo, m.lyricsRepo, m.auditRepo, m.eventRepo, m.eventBroker, new(MockContentFilter))
+	return useCase, m
+}
+
+func (m batchMocks) expectUpdate(ctx context.Context, songID int, data entities.UpdateSongData) {
+	expectSongAuditState(ctx, m.songRepo, m.lyricsRepo, songID)
+	m.songRepo.On("Update", ctx, songID, data).Return(nil)
+	m.lyricsRepo.On("Update", ctx, songID, data).Return(nil)
+	m.auditRepo.On("Create", ctx, auditRecordWith(entities.AuditUpdate, songID)).Return(nil)
+	m.eventRepo.On("Create", ctx, eventsOf(songID, entities.EventSongUpdated)).Return([]entities.EventData{{ID: int64(songID)}}, nil)
+}
+
+func (m batchMocks) expectDelete(ctx context.Context, songID int) {
+	expectSongAuditState(ctx, m.songRepo, m.lyricsRepo, songID)
+	m.lyricsRepo.On("Delete", ctx, songID).Return(nil)
+	m.songRepo.On("Delete", ctx, songID).Return(nil)
+	m.auditRepo.On("Create", ctx, auditRecordWith(entities.AuditDelete, songID)).Return(nil)
+	m.eventRepo.On("Create", ctx, eventsOf(songID, entities.EventSongDeleted)).Return([]entities.EventData{{ID: int64(songID)}}, nil)
+}
+
+func (m batchMocks) expectMissing(ctx context.Context, songID int) {
+	m.songRepo.On("GetList", ctx, entities.SongFilterData{ID: &songID}).
+		Return(nil, fmt.Errorf("%w songs not found", errs.ErrNotFound))
+}
+
+func statuses(result *entities.BatchResultData) []entities.BatchItemStatus {
+	s := make([]entities.BatchItemStatus, len(result.Items))
+	for i, item := range result.Items {
+		s[i] = item.Status
+	}
+	return s
+}
+
+// Все операции выполняются в одной транзакции, события публикуются после неё
+func TestBatchSongsUseCase_Execute_Atomic(t *testing.T) {
+	useCase, m := newBatchSongsUseCase()
+
+	ctx := contextWithRole(entities.RoleAdmin)
+	band := "New Group"
+	update := entities.UpdateSongData{Band: &band}
+
+	m.expectUpdate(ctx, 1, update)
+	m.expectDelete(ctx, 2)
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Once()
+	m.eventBroker.On("Publish", []entities.EventData{{ID: 1}, {ID: 2}}).Once()
+
+	result, err := useCase.Execute(ctx, entities.BatchSongsData{
+		Operations: []entities.BatchOperationData{
+			{Action: entities.BatchUpdate, SongID: 1, Update: update},
+			{Action: entities.BatchDelete, SongID: 2},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.BatchAtomic, result.Mode)
+	assert.Equal(t, []entities.BatchItemStatus{entities.BatchItemApplied, entities.BatchItemApplied}, statuses(result))
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, "New Group", result.Items[0].Song.Band)
+	m.tm.AssertExpectations(t)
+	m.songRepo.AssertExpectations(t)
+	m.eventBroker.AssertExpectations(t)
+}
+
+// Ошибка одной операции откатывает весь атомарный пакет
+func TestBatchSongsUseCase_Execute_AtomicRollback(t *testing.T) {
+	useCase, m := newBatchSongsUseCase()
+
+	ctx := contextWithRole(entities.RoleEditor)
+	band := "New Group"
+	update := entities.UpdateSongData{Band: &band}
+
+	m.expectUpdate(ctx, 1, update)
+	m.expectMissing(ctx, 2)
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(errs.ErrNotFound).Once()
+
+	result, err := useCase.Execute(ctx, entities.BatchSongsData{
+		Operations: []entities.BatchOperationData{
+			{Action: entities.BatchUpdate, SongID: 1, Update: update},
+			{Action: entities.BatchUpdate, SongID: 2, Update: update},
+			{Action: entities.BatchUpdate, SongID: 3, Update: update},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.BatchItemStatus{
+		entities.BatchItemRolledBack, entities.BatchItemFailed, entities.BatchItemSkipped,
+	}, statuses(result))
+	assert.Equal(t, "not found", result.Items[1].Error)
+	assert.Nil(t, result.Items[0].Song)
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	m.eventBroker.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+// В режиме best_effort ошибка одной операции не мешает остальным
+func TestBatchSongsUseCase_Execute_BestEffort(t *testing.T) {
+	useCase, m := newBatchSongsUseCase()
+
+	ctx := contextWithRole(entities.RoleAdmin)
+
+	m.expectMissing(ctx, 1)
+	m.expectDelete(ctx, 2)
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(errs.ErrNotFound).Once()
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Once()
+	m.eventBroker.On("Publish", []entities.EventData{{ID: 2}}).Once()
+
+	result, err := useCase.Execute(ctx, entities.BatchSongsData{
+		Mode: entities.BatchBestEffort,
+		Operations: []entities.BatchOperationData{
+			{Action: entities.BatchDelete, SongID: 1},
+			{Action: entities.BatchDelete, SongID: 2},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.BatchItemStatus{entities.BatchItemFailed, entities.BatchItemApplied}, statuses(result))
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	m.tm.AssertExpectations(t)
+	m.eventBroker.AssertExpectations(t)
+}
+
+// Пробный запуск по фильтру ничего не меняет и показывает будущее состояние песен
+func TestBatchSongsUseCase_Execute_DryRunFilter(t *testing.T) {
+	useCase, m := newBatchSongsUseCase()
+
+	ctx := contextWithRole(entities.RoleEditor)
+	band, newBand := "Old Group", "New Group"
+	offset, limit := 0, 501
+
+	m.songRepo.On("GetList", ctx, entities.SongFilterData{Band: &band, Offset: &offset, Limit: &limit}).
+		Return([]entities.SongData{{ID: 1}, {ID: 2}}, nil)
+	expectSongAuditState(ctx, m.songRepo, m.lyricsRepo, 1)
+	expectSongAuditState(ctx, m.songRepo, m.lyricsRepo, 2)
+
+	result, err := useCase.Execute(ctx, entities.BatchSongsData{
+		Filter: &entities.SongFilterData{Band: &band},
+		Action: entities.BatchUpdate,
+		Update: entities.UpdateSongData{Band: &newBand},
+		DryRun: true,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, []entities.BatchItemStatus{entities.BatchItemPlanned, entities.BatchItemPlanned}, statuses(result))
+	assert.Equal(t, 2, result.Items[1].SongID)
+	assert.Equal(t, "New Group", result.Items[1].Song.Band)
+	m.tm.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+	m.songRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Удаление требует роли admin
+func TestBatchSongsUseCase_Execute_DeleteForbidden(t *testing.T) {
+	useCase, m := newBatchSongsUseCase()
+
+	result, err := useCase.Execute(contextWithRole(entities.RoleEditor), entities.BatchSongsData{
+		Operations: []entities.BatchOperationData{{Action: entities.BatchDelete, SongID: 1}},
+	})
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	assert.Nil(t, result)
+	m.tm.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
+
+func TestBatchSongsUseCase_Execute_InvalidInput(t *testing.T) {
+	band := "Group"
+
+	tests := []struct {
+		name string
+		data entities.BatchSongsData
+	}{
+		{"empty", entities.BatchSongsData{}},
+		{"operations and filter", entities.BatchSongsData{
+			Operations: []entities.BatchOperationData{{Action: entities.BatchDelete, SongID: 1}},
+			Filter:     &entities.SongFilterData{Band: &band},
+			Action:     entities.BatchDelete,
+		}},
+		{"empty filter", entities.BatchSongsData{Filter: &entities.SongFilterData{}, Action: entities.BatchDelete}},
+		{"nothing to update", entities.BatchSongsData{
+			Operations: []entities.BatchOperationData{{Action: entities.BatchUpdate, SongID: 1}},
+		}},
+		{"unknown action", entities.BatchSongsData{
+			Operations: []entities.BatchOperationData{{Action: "tag", SongID: 1}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase, _ := newBatchSongsUseCase()
+
+			_, err := useCase.Execute(contextWithRole(entities.RoleAdmin), tt.data)
+
+			assert.ErrorIs(t, err, errs.ErrInvalidInput)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"sync/atomic"
+)
+
+type CheckRateLimitUseCase interface {
+	Execute(
+		ctx context.Context,
+		group entities.RateLimitGroup,
+		clientIP string,
+	) (*entities.RateLimitResult, error)
+}
+
+// Правила лимитов по группам маршрутов. Их можно заменить на лету
+// при перезагрузке конфигурации, запросы продолжат пользоваться старыми или новыми.
+type RateLimitRules struct {
+	rules atomic.Pointer[map[entities.RateLimitGroup]entities.RateLimitRule]
+}
+
+func NewRateLimitRules(rules map[entities.RateLimitGroup]entities.RateLimitRule) *RateLimitRules {
+	r := &RateLimitRules{}
+	r.Set(rules)
+	return r
+}
+
+func (r *RateLimitRules) Set(rules map[entities.RateLimitGroup]entities.RateLimitRule) {
+	r.rules.Store(&rules)
+}
+
+func (r *RateLimitRules) Get(group entities.RateLimitGroup) (entities.RateLimitRule, bool) {
+	rule, ok := (*r.rules.Load())[group]
+	return rule, ok
+}
+
+type checkRateLimitUseCase struct {
+	rateLimiter RateLimiter
+	rules       *RateLimitRules
+}
+
+func NewCheckRateLimitUseCase(rl RateLimiter, rules *RateLimitRules) CheckRateLimitUseCase {
+	return &checkRateLimitUseCase{
+		rateLimiter: rl,
+		rules:       rules,
+	}
+}
+
+// Списывает один запрос из лимита клиента для группы маршрутов. Аутентифицированные
+// клиенты считаются по API-ключу или subject из JWT, анонимные — по IP.
+// Если для группы лимит не задан, возвращает nil.
+func (u *checkRateLimitUseCase) Execute(
+	ctx context.Context,
+	group entities.RateLimitGroup,
+	clientIP string,
+) (*entities.RateLimitResult, error) {
+
+	rule, ok := u.rules.Get(group)
+	if !ok || !rule.Enabled() {
+		return nil, nil
+	}
+
+	client := "ip:" + clientIP
+	if principal, ok := entities.PrincipalFromContext(ctx); ok && principal.Method != entities.AuthNone {
+		client = string(principal.Method) + ":" + principal.Subject
+	}
+
+	result, err := u.rateLimiter.Take(ctx, string(group)+":"+client, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rateLimitRules = map[entities.RateLimitGroup]entities.RateLimitRule{
+	entities.RateLimitUpstream: {PerMinute: 30, Burst: 10},
+	entities.RateLimitRead:     {PerMinute: 0, Burst: 0},
+}
+
+func TestCheckRateLimitUseCase_Execute_ByPrincipal(t *testing.T) {
+	mockRateLimiter := new(MockRateLimiter)
+	useCase := usecase.NewCheckRateLimitUseCase(mockRateLimiter, usecase.NewRateLimitRules(rateLimitRules))
+
+	ctx := entities.ContextWithPrincipal(context.Background(), &entities.Principal{
+		Subject: "api_key:1",
+		Method:  entities.AuthAPIKey,
+		Role:    entities.RoleEditor,
+	})
+	expected := entities.RateLimitResult{Allowed: true, Limit: 10, Remaining: 9, Reset: 2 * time.Second}
+
+	mockRateLimiter.On("Take", ctx, "upstream:api_key:api_key:1", rateLimitRules[entities.RateLimitUpstream]).Return(expected, nil)
+
+	result, err := useCase.Execute(ctx, entities.RateLimitUpstream, "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, result)
+	mockRateLimiter.AssertExpectations(t)
+}
+
+// При выключенной аутентификации все клиенты анонимные, и лимит считается по IP
+func TestCheckRateLimitUseCase_Execute_AnonymousByIP(t *testing.T) {
+	mockRateLimiter := new(MockRateLimiter)
+	useCase := usecase.NewCheckRateLimitUseCase(mockRateLimiter, usecase.NewRateLimitRules(rateLimitRules))
+
+	ctx := entities.ContextWithPrincipal(context.Background(), entities.AnonymousPrincipal)
+	expected := entities.RateLimitResult{Allowed: false, Limit: 10, RetryAfter: time.Second}
+
+	mockRateLimiter.On("Take", ctx, "upstream:ip:10.0.0.1", rateLimitRules[entities.RateLimitUpstream]).Return(expected, nil)
+
+	result, err := useCase.Execute(ctx, entities.RateLimitUpstream, "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	mockRateLimiter.AssertExpectations(t)
+}
+
+func TestCheckRateLimitUseCase_Execute_NoRule(t *testing.T) {
+	mockRateLimiter := new(MockRateLimiter)
+	useCase := usecase.NewCheckRateLimitUseCase(mockRateLimiter, usecase.NewRateLimitRules(rateLimitRules))
+
+	ctx := contextWithRole(entities.RoleViewer)
+
+	for _, group := range []entities.RateLimitGroup{entities.RateLimitRead, entities.RateLimitWrite} {
+		result, err := useCase.Execute(ctx, group, "10.0.0.1")
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	}
+	mockRateLimiter.AssertNotCalled(t, "Take")
+}
+
+func TestCheckRateLimitUseCase_Execute_LimiterError(t *testing.T) {
+	mockRateLimiter := new(MockRateLimiter)
+	useCase := usecase.NewCheckRateLimitUseCase(mockRateLimiter, usecase.NewRateLimitRules(rateLimitRules))
+
+	ctx := contextWithRole(entities.RoleViewer)
+	expectedError := errors.New("connection refused")
+
+	mockRateLimiter.On("Take", ctx, "upstream::test", rateLimitRules[entities.RateLimitUpstream]).Return(entities.RateLimitResult{}, expectedError)
+
+	result, err := useCase.Execute(ctx, entities.RateLimitUpstream, "10.0.0.1")
+
+	assert.ErrorIs(t, err, expectedError)
+	assert.Nil(t, result)
+}
+
+// Новые правила действуют сразу после перезагрузки конфигурации
+func TestCheckRateLimitUseCase_Execute_ReloadedRules(t *testing.T) {
+	mockRateLimiter := new(MockRateLimiter)
+	rules := usecase.NewRateLimitRules(rateLimitRules)
+	useCase := usecase.NewCheckRateLimitUseCase(mockRateLimiter, rules)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	readRule := entities.RateLimitRule{PerMinute: 60, Burst: 5}
+	expected := entities.RateLimitResult{Allowed: true, Limit: 5, Remaining: 4}
+
+	mockRateLimiter.On("Take", ctx, "read::test", readRule).Return(expected, nil)
+
+	result, err := useCase.Execute(ctx, entities.RateLimitRead, "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	rules.Set(map[entities.RateLimitGroup]entities.RateLimitRule{entities.RateLimitRead: readRule})
+
+	result, err = useCase.Execute(ctx, entities.RateLimitRead, "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, result)
+	mockRateLimiter.AssertExpectations(t)
+}
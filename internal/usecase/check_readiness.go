@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"sync"
+	"time"
+)
+
+type CheckReadinessUseCase interface {
+	Execute(ctx context.Context) *entities.HealthReport
+}
+
+type checkReadinessUseCase struct {
+	checks  []HealthCheck
+	timeout time.Duration
+}
+
+func NewCheckReadinessUseCase(checks []HealthCheck, timeout time.Duration) CheckReadinessUseCase {
+	return &checkReadinessUseCase{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Параллельно проверяет все зависимости, каждую не дольше timeout.
+// Права не проверяются: эндпойнт дёргают балансировщик и Kubernetes.
+func (u *checkReadinessUseCase) Execute(ctx context.Context) *entities.HealthReport {
+	report := &entities.HealthReport{
+		Status:       entities.HealthOK,
+		Dependencies: make(map[string]entities.DependencyHealth, len(u.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, check := range u.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			dependency := u.runCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Dependencies[check.Name] = dependency
+			if dependency.Status == entities.HealthOK {
+				return
+			}
+			if check.Critical {
+				report.Status = entities.HealthFail
+			} else if report.Status == entities.HealthOK {
+				report.Status = entities.HealthDegraded
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (u *checkReadinessUseCase) runCheck(ctx context.Context, check HealthCheck) entities.DependencyHealth {
+	if u.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, u.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := check.Checker.CheckHealth(ctx)
+
+	dependency := entities.DependencyHealth{
+		Status:    entities.HealthOK,
+		Critical:  check.Critical,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		dependency.Status = entities.HealthFail
+		dependency.Error = err.Error()
+	}
+
+	return dependency
+}
@@ -0,0 +1,76 @@
+package usecase_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newHealthChecks(pgErr, infoErr error) []usecase.HealthCheck {
+	mockPostgres := new(MockHealthChecker)
+	mockPostgres.On("CheckHealth", mock.Anything).Return(pgErr)
+
+	mockInfoService := new(MockHealthChecker)
+	mockInfoService.On("CheckHealth", mock.Anything).Return(infoErr)
+
+	return []usecase.HealthCheck{
+		{Name: "postgres", Checker: mockPostgres, Critical: true},
+		{Name: "infoservice", Checker: mockInfoService},
+	}
+}
+
+func TestCheckReadinessUseCase_Execute_OK(t *testing.T) {
+	useCase := usecase.NewCheckReadinessUseCase(newHealthChecks(nil, nil), time.Second)
+
+	report := useCase.Execute(context.Background())
+
+	assert.Equal(t, entities.HealthOK, report.Status)
+	assert.Len(t, report.Dependencies, 2)
+	assert.Equal(t, entities.HealthOK, report.Dependencies["postgres"].Status)
+	assert.True(t, report.Dependencies["postgres"].Critical)
+	assert.Empty(t, report.Dependencies["postgres"].Error)
+}
+
+func TestCheckReadinessUseCase_Execute_CriticalFail(t *testing.T) {
+	useCase := usecase.NewCheckReadinessUseCase(newHealthChecks(errors.New("connection refused"), nil), time.Second)
+
+	report := useCase.Execute(context.Background())
+
+	assert.Equal(t, entities.HealthFail, report.Status)
+	assert.Equal(t, entities.HealthFail, report.Dependencies["postgres"].Status)
+	assert.Equal(t, "connection refused", report.Dependencies["postgres"].Error)
+}
+
+// Недоступность некритичной зависимости не делает сервис неготовым
+func TestCheckReadinessUseCase_Execute_Degraded(t *testing.T) {
+	useCase := usecase.NewCheckReadinessUseCase(newHealthChecks(nil, errors.New("timeout")), time.Second)
+
+	report := useCase.Execute(context.Background())
+
+	assert.Equal(t, entities.HealthDegraded, report.Status)
+	assert.Equal(t, entities.HealthOK, report.Dependencies["postgres"].Status)
+	assert.Equal(t, entities.HealthFail, report.Dependencies["infoservice"].Status)
+	assert.False(t, report.Dependencies["infoservice"].Critical)
+}
+
+func TestCheckReadinessUseCase_Execute_Timeout(t *testing.T) {
+	mockPostgres := new(MockHealthChecker)
+	mockPostgres.On("CheckHealth", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	})
+	checks := []usecase.HealthCheck{{Name: "postgres", Checker: mockPostgres, Critical: true}}
+
+	useCase := usecase.NewCheckReadinessUseCase(checks, 10*time.Millisecond)
+
+	start := time.Now()
+	report := useCase.Execute(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Len(t, report.Dependencies, 1)
+}
@@ -1,19 +1,141 @@
 package usecase
 
+import (
+	"em-library/internal/entities"
+	"time"
+)
+
+// Настройки поведения юзкейсов, не зависящие от репозиториев и сервисов
+type Options struct {
+	RefreshPolicy     entities.RefreshPolicy
+	DuplicateMinScore float64
+	RateLimits        *RateLimitRules
+	HealthTimeout     time.Duration
+
+	WebhookBatchSize   int
+	WebhookLease       time.Duration
+	WebhookRetryPolicy entities.WebhookRetryPolicy
+
+	ChangesPollInterval time.Duration
+
+	IdempotencyTTL time.Duration
+}
+
 type UseCases struct {
-	CreateSong    CreateSongUseCase
-	GetSongList   GetSongListUseCase
-	GetSongLyrics GetSongLyricsUseCase
-	DeleteSong    DeleteSongUseCase
-	UpdateSong    UpdateSongUseCase
+	CreateSong     CreateSongUseCase
+	GetSongList    GetSongListUseCase
+	GetSongLyrics  GetSongLyricsUseCase
+	GetSongsLyrics GetSongsLyricsUseCase
+	DeleteSong     DeleteSongUseCase
+	UpdateSong     UpdateSongUseCase
+	BatchSongs     BatchSongsUseCase
+	GetLyricsDiff  GetLyricsDiffUseCase
+	RefreshSong    RefreshSongUseCase
+	RefreshSongs   RefreshSongsUseCase
+
+	GetDrafts   GetDraftsUseCase
+	AcceptDraft AcceptDraftUseCase
+	RejectDraft RejectDraftUseCase
+
+	DetectDuplicates DetectDuplicatesUseCase
+	GetDuplicates    GetDuplicatesUseCase
+	MergeSongs       MergeSongsUseCase
+	ReindexSongs     ReindexSongsUseCase
+
+	Authenticate AuthenticateUseCase
+	CreateAPIKey CreateAPIKeyUseCase
+	GetAPIKeys   GetAPIKeysUseCase
+	RevokeAPIKey RevokeAPIKeyUseCase
+
+	GetAuditLog  GetAuditLogUseCase
+	GetChanges   GetChangesUseCase
+	StreamEvents StreamEventsUseCase
+
+	CreateWebhook        CreateWebhookUseCase
+	GetWebhooks          GetWebhooksUseCase
+	GetWebhook           GetWebhookUseCase
+	UpdateWebhook        UpdateWebhookUseCase
+	DeleteWebhook        DeleteWebhookUseCase
+	GetWebhookDeliveries GetWebhookDeliveriesUseCase
+	RedeliverWebhook     RedeliverWebhookUseCase
+	DispatchWebhooks     DispatchWebhooksUseCase
+
+	CheckRateLimit CheckRateLimitUseCase
+
+	StartIdempotentRequest  StartIdempotentRequestUseCase
+	FinishIdempotentRequest FinishIdempotentRequestUseCase
+
+	CheckReadiness CheckReadinessUseCase
+	GetConfig      GetConfigUseCase
 }
 
-func NewUseCases(r Repos, s Services) UseCases {
+func NewUseCases(r Repos, s Services, o Options) UseCases {
+	refreshSong := NewRefreshSongUseCase(
+		r.TransactionManager,
+		r.SongRepo,
+		r.LyricsRepo,
+		r.DraftRepo,
+		r.AuditRepo,
+		r.EventRepo,
+		s.EventBroker,
+		s.SongInfoService,
+		s.ContentFilter,
+		o.RefreshPolicy,
+	)
+
+	updateSong := NewUpdateSongUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo, r.AuditRepo, r.EventRepo, s.EventBroker, s.ContentFilter)
+
 	return UseCases{
-		CreateSong:    NewCreateSongUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo, s.SongInfoService),
-		GetSongList:   NewGetSongListUseCase(r.SongRepo),
-		GetSongLyrics: NewGetSongLyricsUsecase(r.LyricsRepo),
-		DeleteSong:    NewDeleteSongUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo),
-		UpdateSong:    NewUpdateSongUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo),
+		CreateSong:     NewCreateSongUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo, r.AuditRepo, r.EventRepo, s.EventBroker, s.SongInfoService, s.ContentFilter),
+		GetSongList:    NewGetSongListUseCase(r.SongRepo),
+		GetSongLyrics:  NewGetSongLyricsUsecase(r.LyricsRepo, s.ContentFilter),
+		GetSongsLyrics: NewGetSongsLyricsUseCase(r.LyricsRepo, s.ContentFilter),
+		DeleteSong:     NewDeleteSongUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo, r.AuditRepo, r.EventRepo, s.EventBroker),
+		UpdateSong:     updateSong,
+		BatchSongs:     NewBatchSongsUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo, r.AuditRepo, r.EventRepo, s.EventBroker, s.ContentFilter),
+		GetLyricsDiff:  NewGetLyricsDiffUseCase(r.SongRepo, r.LyricsRepo, s.SongInfoService),
+		RefreshSong:    refreshSong,
+		RefreshSongs:   NewRefreshSongsUseCase(r.SongRepo, refreshSong),
+
+		GetDrafts:   NewGetDraftsUseCase(r.DraftRepo),
+		AcceptDraft: NewAcceptDraftUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo, r.DraftRepo, r.AuditRepo, r.EventRepo, s.EventBroker, s.ContentFilter),
+		RejectDraft: NewRejectDraftUseCase(r.DraftRepo),
+
+		DetectDuplicates: NewDetectDuplicatesUseCase(r.TransactionManager, r.DuplicateRepo, o.DuplicateMinScore),
+		GetDuplicates:    NewGetDuplicatesUseCase(r.DuplicateRepo),
+		MergeSongs:       NewMergeSongsUseCase(r.TransactionManager, r.SongRepo, r.LyricsRepo, r.AuditRepo, r.EventRepo, s.EventBroker, s.ContentFilter),
+		ReindexSongs:     NewReindexSongsUseCase(r.DuplicateRepo, s.ContentFilter, updateSong),
+
+		Authenticate: NewAuthenticateUseCase(r.APIKeyRepo, s.TokenVerifier),
+		CreateAPIKey: NewCreateAPIKeyUseCase(r.APIKeyRepo),
+		GetAPIKeys:   NewGetAPIKeysUseCase(r.APIKeyRepo),
+		RevokeAPIKey: NewRevokeAPIKeyUseCase(r.APIKeyRepo),
+
+		GetAuditLog:  NewGetAuditLogUseCase(r.AuditRepo),
+		GetChanges:   NewGetChangesUseCase(r.EventRepo, o.ChangesPollInterval),
+		StreamEvents: NewStreamEventsUseCase(r.EventRepo, s.EventBroker),
+
+		CreateWebhook:        NewCreateWebhookUseCase(r.WebhookRepo),
+		GetWebhooks:          NewGetWebhooksUseCase(r.WebhookRepo),
+		GetWebhook:           NewGetWebhookUseCase(r.WebhookRepo),
+		UpdateWebhook:        NewUpdateWebhookUseCase(r.WebhookRepo),
+		DeleteWebhook:        NewDeleteWebhookUseCase(r.WebhookRepo),
+		GetWebhookDeliveries: NewGetWebhookDeliveriesUseCase(r.WebhookRepo),
+		RedeliverWebhook:     NewRedeliverWebhookUseCase(r.WebhookRepo),
+		DispatchWebhooks: NewDispatchWebhooksUseCase(
+			r.WebhookRepo,
+			s.WebhookSender,
+			o.WebhookBatchSize,
+			o.WebhookLease,
+			o.WebhookRetryPolicy,
+		),
+
+		CheckRateLimit: NewCheckRateLimitUseCase(s.RateLimiter, o.RateLimits),
+
+		StartIdempotentRequest:  NewStartIdempotentRequestUseCase(r.IdempotencyRepo, o.IdempotencyTTL),
+		FinishIdempotentRequest: NewFinishIdempotentRequestUseCase(r.IdempotencyRepo),
+
+		CheckReadiness: NewCheckReadinessUseCase(s.HealthChecks, o.HealthTimeout),
+		GetConfig:      NewGetConfigUseCase(s.ConfigSource),
 	}
 }
@@ -13,58 +13,120 @@ type createSongUseCase struct {
 	transactionManager TransactionManager
 	songRepo           SongRepo
 	lyricsRepo         LyricsRepo
+	auditRepo          AuditRepo
+	eventRepo          EventRepo
+	eventPublisher     EventPublisher
 	songInfoService    SongInfoService
+	contentFilter      ContentFilter
 }
 
 func NewCreateSongUseCase(
 	tm TransactionManager,
 	sr SongRepo,
 	lr LyricsRepo,
+	ar AuditRepo,
+	er EventRepo,
+	ep EventPublisher,
 	s SongInfoService,
+	cf ContentFilter,
 ) CreateSongUseCase {
 	return &createSongUseCase{
 		transactionManager: tm,
 		songRepo:           sr,
 		lyricsRepo:         lr,
+		auditRepo:          ar,
+		eventRepo:          er,
+		eventPublisher:     ep,
 		songInfoService:    s,
+		contentFilter:      cf,
 	}
 }
 
 func (u *createSongUseCase) Execute(ctx context.Context, data entities.NewSongData) (*entities.SongData, error) {
-	info, err := u.songInfoService.GetInfo(ctx, data.Band, data.Song)
-	if err != nil {
+	if err := authorize(ctx, entities.RoleEditor); err != nil {
 		return nil, err
 	}
 
-	data.ReleaseDate = info.ReleaseDate
-	data.Link = info.Link
+	lyrics := data.Lyrics
+
+	if u.needsEnrichment(data) {
+		info, err := u.songInfoService.GetInfo(ctx, data.Band, data.Song)
+
+		switch {
+		case err == nil:
+			// переданные в запросе поля важнее данных внешнего сервиса
+			if data.ReleaseDate.IsZero() {
+				data.ReleaseDate = info.ReleaseDate
+			}
+			if data.Link == "" {
+				data.Link = info.Link
+			}
+			if lyrics == "" {
+				lyrics = info.Lyrics
+			}
+		case data.Enrich == entities.EnrichFillMissing:
+			// песни может не быть во внешнем сервисе, тогда сохраняем то, что передали
+		default:
+			return nil, err
+		}
+	}
 
-	var id int
+	data.Explicit = u.contentFilter.IsExplicit(lyrics)
 
-	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
-		id, err = u.songRepo.Create(ctx, data)
+	var song entities.SongData
+	var events []entities.EventData
+
+	err := u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		id, err := u.songRepo.Create(ctx, data)
 		if err != nil {
 			return err
 		}
 
-		err = u.lyricsRepo.Create(ctx, entities.NewLyricsData{
-			SongID:  id,
-			Content: info.Lyrics,
-		})
+		// у песни без текста нет записи в lyrics, её создаст первое изменение текста
+		if lyrics != "" {
+			err = u.lyricsRepo.Create(ctx, entities.NewLyricsData{
+				SongID:  id,
+				Content: lyrics,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		song = entities.SongData{
+			ID:          id,
+			Band:        data.Band,
+			Song:        data.Song,
+			ReleaseDate: data.ReleaseDate,
+			Link:        data.Link,
+			Explicit:    &data.Explicit,
+		}
 
-		return nil
+		after := songAuditData(song, lyrics)
+		if err := writeAudit(ctx, u.auditRepo, entities.AuditCreate, id, nil, after); err != nil {
+			return err
+		}
+
+		events, err = writeEvents(ctx, u.eventRepo, id, nil, &after)
+		return err
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	song := entities.SongData{
-		ID:          id,
-		Band:        data.Band,
-		Song:        data.Song,
-		ReleaseDate: info.ReleaseDate,
-		Link:        info.Link,
-	}
+	u.eventPublisher.Publish(events)
+
 	return &song, nil
 }
+
+func (u *createSongUseCase) needsEnrichment(data entities.NewSongData) bool {
+	switch data.Enrich {
+	case entities.EnrichNever:
+		return false
+	case entities.EnrichFillMissing:
+		return data.ReleaseDate.IsZero() || data.Link == "" || data.Lyrics == ""
+	default:
+		return true
+	}
+}
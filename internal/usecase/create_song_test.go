@@ -1,9 +1,10 @@
 package usecase_test
 
 import (
-	"context"
 	"em-library/internal/entities"
+	"em-library/internal/errs"
 	"em-library/internal/usecase"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -17,9 +18,13 @@ func TestCreateSongUseCase_Execute_Success(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
 	mockInfoService := new(MockSongInfoService)
-	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleEditor)
 	expectedID := 123
 	releaseDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
 
@@ -39,12 +44,16 @@ func TestCreateSongUseCase_Execute_Success(t *testing.T) {
 	expectedData.Link = "https://example.com/song"
 
 	mockInfoService.On("GetInfo", ctx, inputData.Band, inputData.Song).Return(songDetail, nil)
+	mockContentFilter.On("IsExplicit", songDetail.Lyrics).Return(false)
 	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
 	mockSongRepo.On("Create", ctx, expectedData).Return(expectedID, nil)
 	mockLyricsRepo.On("Create", ctx, entities.NewLyricsData{
 		SongID:  expectedID,
 		Content: songDetail.Lyrics,
 	}).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditCreate, expectedID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(expectedID, entities.EventSongCreated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
 
 	result, err := useCase.Execute(ctx, inputData)
 
@@ -67,9 +76,13 @@ func TestCreateSongUseCase_Execute_InfoServiceError(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
 	mockInfoService := new(MockSongInfoService)
-	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleEditor)
 	inputData := entities.NewSongData{
 		Band: "Test Group",
 		Song: "Test Song",
@@ -88,6 +101,7 @@ func TestCreateSongUseCase_Execute_InfoServiceError(t *testing.T) {
 	mockTM.AssertNotCalled(t, "Do")
 	mockSongRepo.AssertNotCalled(t, "Create")
 	mockLyricsRepo.AssertNotCalled(t, "Create")
+	mockContentFilter.AssertNotCalled(t, "IsExplicit")
 }
 
 func TestCreateSongUseCase_Execute_SongRepoError(t *testing.T) {
@@ -95,9 +109,13 @@ func TestCreateSongUseCase_Execute_SongRepoError(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
 	mockInfoService := new(MockSongInfoService)
-	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleEditor)
 	inputData := entities.NewSongData{
 		Band: "Test Group",
 		Song: "Test Song",
@@ -117,6 +135,7 @@ func TestCreateSongUseCase_Execute_SongRepoError(t *testing.T) {
 	expectedError := errors.New("repository error")
 
 	mockInfoService.On("GetInfo", ctx, inputData.Band, inputData.Song).Return(songDetail, nil)
+	mockContentFilter.On("IsExplicit", songDetail.Lyrics).Return(false)
 	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(expectedError)
 	mockSongRepo.On("Create", ctx, expectedData).Return(0, expectedError)
 
@@ -135,9 +154,13 @@ func TestCreateSongUseCase_Execute_LyricsRepoError(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
 	mockInfoService := new(MockSongInfoService)
-	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleEditor)
 	expectedID := 123
 	releaseDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
 
@@ -159,6 +182,7 @@ func TestCreateSongUseCase_Execute_LyricsRepoError(t *testing.T) {
 	expectedError := errors.New("lyrics repository error")
 
 	mockInfoService.On("GetInfo", ctx, inputData.Band, inputData.Song).Return(songDetail, nil)
+	mockContentFilter.On("IsExplicit", songDetail.Lyrics).Return(false)
 	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(expectedError)
 	mockSongRepo.On("Create", ctx, expectedData).Return(expectedID, nil)
 	mockLyricsRepo.On("Create", ctx, entities.NewLyricsData{
@@ -177,3 +201,242 @@ func TestCreateSongUseCase_Execute_LyricsRepoError(t *testing.T) {
 	mockSongRepo.AssertExpectations(t)
 	mockLyricsRepo.AssertExpectations(t)
 }
+
+func TestCreateSongUseCase_Execute_ExplicitLyrics(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockInfoService := new(MockSongInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	expectedID := 123
+	releaseDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	inputData := entities.NewSongData{
+		Band: "Test Group",
+		Song: "Test Song",
+	}
+
+	songDetail := &entities.SongDetail{
+		ReleaseDate: releaseDate,
+		Link:        "https://example.com/song",
+		Lyrics:      "Explicit lyrics",
+	}
+
+	expectedData := inputData
+	expectedData.ReleaseDate = releaseDate
+	expectedData.Link = "https://example.com/song"
+	expectedData.Explicit = true
+
+	mockInfoService.On("GetInfo", ctx, inputData.Band, inputData.Song).Return(songDetail, nil)
+	mockContentFilter.On("IsExplicit", songDetail.Lyrics).Return(true)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Create", ctx, expectedData).Return(expectedID, nil)
+	mockLyricsRepo.On("Create", ctx, entities.NewLyricsData{
+		SongID:  expectedID,
+		Content: songDetail.Lyrics,
+	}).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditCreate, expectedID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(expectedID, entities.EventSongCreated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	result, err := useCase.Execute(ctx, inputData)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, true, *result.Explicit)
+
+	mockContentFilter.AssertExpectations(t)
+	mockSongRepo.AssertExpectations(t)
+}
+
+func TestCreateSongUseCase_Execute_EnrichNever(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockInfoService := new(MockSongInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	expectedID := 123
+
+	inputData := entities.NewSongData{
+		Band:   "Curated Group",
+		Song:   "Curated Song",
+		Link:   "https://example.com/curated",
+		Lyrics: "Curated lyrics",
+		Enrich: entities.EnrichNever,
+	}
+
+	mockContentFilter.On("IsExplicit", "Curated lyrics").Return(false)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Create", ctx, inputData).Return(expectedID, nil)
+	mockLyricsRepo.On("Create", ctx, entities.NewLyricsData{
+		SongID:  expectedID,
+		Content: "Curated lyrics",
+	}).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditCreate, expectedID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(expectedID, entities.EventSongCreated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	result, err := useCase.Execute(ctx, inputData)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedID, result.ID)
+	assert.Equal(t, "https://example.com/curated", result.Link)
+	assert.True(t, result.ReleaseDate.IsZero())
+
+	mockInfoService.AssertNotCalled(t, "GetInfo")
+	mockSongRepo.AssertExpectations(t)
+	mockLyricsRepo.AssertExpectations(t)
+}
+
+// Без даты релиза и без обращения к внешнему сервису дата остаётся неизвестной,
+// а не превращается в 0001-01-01
+func TestCreateSongUseCase_Execute_EnrichNeverWithoutDate(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockInfoService := new(MockSongInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	expectedID := 123
+
+	inputData := entities.NewSongData{
+		Band:   "Curated Group",
+		Song:   "Curated Song",
+		Lyrics: "Curated lyrics",
+		Enrich: entities.EnrichNever,
+	}
+
+	withoutDate := mock.MatchedBy(func(data entities.NewSongData) bool {
+		return data.ReleaseDate.IsZero()
+	})
+
+	mockContentFilter.On("IsExplicit", "Curated lyrics").Return(false)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Create", ctx, withoutDate).Return(expectedID, nil)
+	mockLyricsRepo.On("Create", ctx, mock.Anything).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditCreate, expectedID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(expectedID, entities.EventSongCreated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	result, err := useCase.Execute(ctx, inputData)
+
+	assert.NoError(t, err)
+	assert.True(t, result.ReleaseDate.IsZero())
+
+	body, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"release_date":null`)
+
+	mockInfoService.AssertNotCalled(t, "GetInfo")
+	mockSongRepo.AssertExpectations(t)
+}
+
+func TestCreateSongUseCase_Execute_FillMissing(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockInfoService := new(MockSongInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	expectedID := 123
+	releaseDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	inputData := entities.NewSongData{
+		Band:   "Test Group",
+		Song:   "Test Song",
+		Lyrics: "Curated lyrics",
+		Enrich: entities.EnrichFillMissing,
+	}
+
+	songDetail := &entities.SongDetail{
+		ReleaseDate: releaseDate,
+		Link:        "https://example.com/song",
+		Lyrics:      "Upstream lyrics",
+	}
+
+	expectedData := inputData
+	expectedData.ReleaseDate = releaseDate
+	expectedData.Link = "https://example.com/song"
+
+	mockInfoService.On("GetInfo", ctx, inputData.Band, inputData.Song).Return(songDetail, nil)
+	mockContentFilter.On("IsExplicit", "Curated lyrics").Return(false)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Create", ctx, expectedData).Return(expectedID, nil)
+	mockLyricsRepo.On("Create", ctx, entities.NewLyricsData{
+		SongID:  expectedID,
+		Content: "Curated lyrics",
+	}).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditCreate, expectedID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(expectedID, entities.EventSongCreated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	result, err := useCase.Execute(ctx, inputData)
+
+	assert.NoError(t, err)
+	assert.Equal(t, releaseDate, result.ReleaseDate)
+	assert.Equal(t, "https://example.com/song", result.Link)
+
+	mockInfoService.AssertExpectations(t)
+	mockSongRepo.AssertExpectations(t)
+	mockLyricsRepo.AssertExpectations(t)
+}
+
+// Без текста запись в lyrics не создаётся
+func TestCreateSongUseCase_Execute_FillMissingServiceError(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockInfoService := new(MockSongInfoService)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewCreateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockInfoService, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	expectedID := 123
+
+	inputData := entities.NewSongData{
+		Band:   "Unknown Group",
+		Song:   "Unknown Song",
+		Enrich: entities.EnrichFillMissing,
+	}
+
+	mockInfoService.On("GetInfo", ctx, inputData.Band, inputData.Song).Return(nil, errs.ErrServiceProblem{Err: errors.New("not found upstream")})
+	mockContentFilter.On("IsExplicit", "").Return(false)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Create", ctx, inputData).Return(expectedID, nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditCreate, expectedID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(expectedID, entities.EventSongCreated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	result, err := useCase.Execute(ctx, inputData)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedID, result.ID)
+
+	mockSongRepo.AssertExpectations(t)
+	mockLyricsRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -1,6 +1,9 @@
 package usecase
 
-import "context"
+import (
+	"context"
+	"em-library/internal/entities"
+)
 
 type DeleteSongUseCase interface {
 	Execute(ctx context.Context, songID int) error
@@ -10,19 +13,35 @@ type deleteSongUseCase struct {
 	transactionManager TransactionManager
 	songRepo           SongRepo
 	lyricsRepo         LyricsRepo
+	auditRepo          AuditRepo
+	eventRepo          EventRepo
+	eventPublisher     EventPublisher
 }
 
-func NewDeleteSongUseCase(tm TransactionManager, sr SongRepo, lr LyricsRepo) DeleteSongUseCase {
+func NewDeleteSongUseCase(tm TransactionManager, sr SongRepo, lr LyricsRepo, ar AuditRepo, er EventRepo, ep EventPublisher) DeleteSongUseCase {
 	return &deleteSongUseCase{
 		transactionManager: tm,
 		songRepo:           sr,
 		lyricsRepo:         lr,
+		auditRepo:          ar,
+		eventRepo:          er,
+		eventPublisher:     ep,
 	}
 }
 
 func (u *deleteSongUseCase) Execute(ctx context.Context, songID int) error {
 
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return err
+	}
+
+	var events []entities.EventData
+
 	err := u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		before, err := loadSongAuditData(ctx, u.songRepo, u.lyricsRepo, songID)
+		if err != nil {
+			return err
+		}
 
 		if err := u.lyricsRepo.Delete(ctx, songID); err != nil {
 			return err
@@ -32,12 +51,19 @@ func (u *deleteSongUseCase) Execute(ctx context.Context, songID int) error {
 			return err
 		}
 
-		return nil
+		if err := writeAudit(ctx, u.auditRepo, entities.AuditDelete, songID, before, nil); err != nil {
+			return err
+		}
+
+		events, err = writeEvents(ctx, u.eventRepo, songID, &before, nil)
+		return err
 	})
 
 	if err != nil {
 		return err
 	}
 
+	u.eventPublisher.Publish(events)
+
 	return nil
 }
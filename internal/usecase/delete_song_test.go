@@ -2,6 +2,8 @@ package usecase_test
 
 import (
 	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
 	"em-library/internal/usecase"
 	"errors"
 	"testing"
@@ -14,14 +16,21 @@ func TestDeleteSongUseCase_Execute_Success(t *testing.T) {
 	mockTransactionManager := new(MockTransactionManager)
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewDeleteSongUseCase(mockTransactionManager, mockSongRepo, mockLyricsRepo)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewDeleteSongUseCase(mockTransactionManager, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleAdmin)
 	songID := 1
+	expectSongAuditState(ctx, mockSongRepo, mockLyricsRepo, songID)
 
 	mockTransactionManager.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
 	mockLyricsRepo.On("Delete", ctx, songID).Return(nil)
 	mockSongRepo.On("Delete", ctx, songID).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditDelete, songID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(songID, entities.EventSongDeleted)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
 
 	err := useCase.Execute(ctx, songID)
 
@@ -29,16 +38,23 @@ func TestDeleteSongUseCase_Execute_Success(t *testing.T) {
 	mockTransactionManager.AssertExpectations(t)
 	mockLyricsRepo.AssertExpectations(t)
 	mockSongRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockEventBroker.AssertExpectations(t)
 }
 
 func TestDeleteSongUseCase_Execute_LyricsDeleteError(t *testing.T) {
 	mockTransactionManager := new(MockTransactionManager)
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewDeleteSongUseCase(mockTransactionManager, mockSongRepo, mockLyricsRepo)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewDeleteSongUseCase(mockTransactionManager, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleAdmin)
 	songID := 1
+	expectSongAuditState(ctx, mockSongRepo, mockLyricsRepo, songID)
 	expectedError := errors.New("lyrics delete error")
 
 	mockLyricsRepo.On("Delete", ctx, songID).Return(expectedError)
@@ -60,10 +76,14 @@ func TestDeleteSongUseCase_Execute_SongDeleteError(t *testing.T) {
 	mockTransactionManager := new(MockTransactionManager)
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewDeleteSongUseCase(mockTransactionManager, mockSongRepo, mockLyricsRepo)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewDeleteSongUseCase(mockTransactionManager, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleAdmin)
 	songID := 1
+	expectSongAuditState(ctx, mockSongRepo, mockLyricsRepo, songID)
 	expectedError := errors.New("song delete error")
 
 	mockLyricsRepo.On("Delete", ctx, songID).Return(nil)
@@ -80,4 +100,37 @@ func TestDeleteSongUseCase_Execute_SongDeleteError(t *testing.T) {
 	mockTransactionManager.AssertExpectations(t)
 	mockLyricsRepo.AssertExpectations(t)
 	mockSongRepo.AssertExpectations(t)
+	mockAuditRepo.AssertNotCalled(t, "Create")
+	mockEventRepo.AssertNotCalled(t, "Create")
+}
+
+// Удалять песни может только admin
+func TestDeleteSongUseCase_Execute_Forbidden(t *testing.T) {
+	mockTransactionManager := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewDeleteSongUseCase(mockTransactionManager, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker)
+
+	err := useCase.Execute(contextWithRole(entities.RoleEditor), 1)
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	mockTransactionManager.AssertNotCalled(t, "Do")
+}
+
+func TestDeleteSongUseCase_Execute_NoPrincipal(t *testing.T) {
+	mockTransactionManager := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewDeleteSongUseCase(mockTransactionManager, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker)
+
+	err := useCase.Execute(context.Background(), 1)
+
+	assert.ErrorIs(t, err, errs.ErrUnauthorized)
+	mockTransactionManager.AssertNotCalled(t, "Do")
 }
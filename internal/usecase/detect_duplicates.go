@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/pkg/textsim"
+)
+
+// Веса похожести названия и текста в итоговой оценке пары
+const (
+	titleWeight  = 0.7
+	lyricsWeight = 0.3
+)
+
+type DetectDuplicatesUseCase interface {
+	Execute(ctx context.Context) (int, error)
+}
+
+type detectDuplicatesUseCase struct {
+	transactionManager TransactionManager
+	duplicateRepo      DuplicateRepo
+	minScore           float64
+}
+
+func NewDetectDuplicatesUseCase(tm TransactionManager, dr DuplicateRepo, minScore float64) DetectDuplicatesUseCase {
+	return &detectDuplicatesUseCase{
+		transactionManager: tm,
+		duplicateRepo:      dr,
+		minScore:           minScore,
+	}
+}
+
+// Попарно сравнивает песни одной группы и сохраняет пары с оценкой не ниже minScore.
+// Возвращает количество найденных пар.
+func (u *detectDuplicatesUseCase) Execute(ctx context.Context) (int, error) {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return 0, err
+	}
+
+	songs, err := u.duplicateRepo.GetSongsWithLyrics(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// сравниваем только внутри группы, иначе число пар растёт квадратично от размера библиотеки
+	byBand := make(map[string][]entities.SongWithLyricsData)
+	var bands []string
+	for _, song := range songs {
+		band := textsim.NormalizeTitle(song.Band)
+		if _, ok := byBand[band]; !ok {
+			bands = append(bands, band)
+		}
+		byBand[band] = append(byBand[band], song)
+	}
+
+	duplicates := []entities.NewDuplicateData{}
+	for _, band := range bands {
+		group := byBand[band]
+		for i := 0; i < len(group); i++ {
+			for j := i + 1; j < len(group); j++ {
+				candidate := scoreDuplicate(group[i], group[j])
+				if candidate.Score >= u.minScore {
+					duplicates = append(duplicates, candidate)
+				}
+			}
+		}
+	}
+
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		return u.duplicateRepo.Replace(ctx, duplicates)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(duplicates), nil
+}
+
+// Если у одной из песен нет текста, оценка считается только по названию.
+func scoreDuplicate(a, b entities.SongWithLyricsData) entities.NewDuplicateData {
+	titleScore := textsim.Similarity(textsim.NormalizeTitle(a.Song), textsim.NormalizeTitle(b.Song))
+
+	result := entities.NewDuplicateData{
+		SongID:      a.ID,
+		DuplicateID: b.ID,
+		TitleScore:  titleScore,
+		Score:       titleScore,
+	}
+
+	if a.Lyrics != "" && b.Lyrics != "" {
+		lyricsScore := textsim.WordSetSimilarity(a.Lyrics, b.Lyrics)
+		result.LyricsScore = &lyricsScore
+		result.Score = titleWeight*titleScore + lyricsWeight*lyricsScore
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDetectDuplicatesUseCase_Execute_Success(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockDuplicateRepo := new(MockDuplicateRepo)
+	useCase := usecase.NewDetectDuplicatesUseCase(mockTM, mockDuplicateRepo, 0.85)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+
+	songs := []entities.SongWithLyricsData{
+		{SongData: entities.SongData{ID: 1, Band: "The Beatles", Song: "Yesterday"}, Lyrics: "Yesterday\\nAll my troubles seemed so far away"},
+		{SongData: entities.SongData{ID: 2, Band: "the beatles", Song: "Yesterday (Remastered 2009)"}, Lyrics: "Yesterday\\nAll my troubles seemed so far away"},
+		{SongData: entities.SongData{ID: 3, Band: "The Beatles", Song: "Let It Be"}, Lyrics: "When I find myself in times of trouble"},
+		{SongData: entities.SongData{ID: 4, Band: "Other Band", Song: "Yesterday"}},
+	}
+
+	mockDuplicateRepo.On("GetSongsWithLyrics", ctx).Return(songs, nil)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockDuplicateRepo.On("Replace", ctx, mock.MatchedBy(func(duplicates []entities.NewDuplicateData) bool {
+		return len(duplicates) == 1 &&
+			duplicates[0].SongID == 1 &&
+			duplicates[0].DuplicateID == 2 &&
+			duplicates[0].Score == 1 &&
+			*duplicates[0].LyricsScore == 1
+	})).Return(nil)
+
+	count, err := useCase.Execute(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockDuplicateRepo.AssertExpectations(t)
+	mockTM.AssertExpectations(t)
+}
+
+func TestDetectDuplicatesUseCase_Execute_TitleOnlyWithoutLyrics(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockDuplicateRepo := new(MockDuplicateRepo)
+	useCase := usecase.NewDetectDuplicatesUseCase(mockTM, mockDuplicateRepo, 0.85)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+
+	songs := []entities.SongWithLyricsData{
+		{SongData: entities.SongData{ID: 1, Band: "Muse", Song: "Supermassive Black Hole"}},
+		{SongData: entities.SongData{ID: 2, Band: "Muse", Song: "Supermassive Black Hole - Live"}, Lyrics: "Oh baby"},
+	}
+
+	mockDuplicateRepo.On("GetSongsWithLyrics", ctx).Return(songs, nil)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockDuplicateRepo.On("Replace", ctx, mock.MatchedBy(func(duplicates []entities.NewDuplicateData) bool {
+		return len(duplicates) == 1 && duplicates[0].LyricsScore == nil && duplicates[0].TitleScore == 1
+	})).Return(nil)
+
+	count, err := useCase.Execute(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockDuplicateRepo.AssertExpectations(t)
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"errors"
+	"sync"
+	"time"
+)
+
+type DispatchWebhooksUseCase interface {
+	Execute(ctx context.Context) (*entities.WebhookDispatchResult, error)
+}
+
+type dispatchWebhooksUseCase struct {
+	webhookRepo   WebhookRepo
+	webhookSender WebhookSender
+	batchSize     int
+	lease         time.Duration
+	retryPolicy   entities.WebhookRetryPolicy
+}
+
+func NewDispatchWebhooksUseCase(
+	wr WebhookRepo,
+	ws WebhookSender,
+	batchSize int,
+	lease time.Duration,
+	retryPolicy entities.WebhookRetryPolicy,
+) DispatchWebhooksUseCase {
+	return &dispatchWebhooksUseCase{
+		webhookRepo:   wr,
+		webhookSender: ws,
+		batchSize:     batchSize,
+		lease:         lease,
+		retryPolicy:   retryPolicy,
+	}
+}
+
+// Отправляет одну пачку доставок, которым подошло время. Доставки пачки отправляются параллельно,
+// результат каждой попытки сохраняется, даже если сохранить другие не удалось.
+func (u *dispatchWebhooksUseCase) Execute(ctx context.Context) (*entities.WebhookDispatchResult, error) {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := u.webhookRepo.ClaimDeliveries(ctx, u.batchSize, u.lease)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]entities.WebhookAttemptData, len(deliveries))
+
+	var wg sync.WaitGroup
+	for i, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attempts[i] = u.send(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+
+	var result entities.WebhookDispatchResult
+	var saveErrs []error
+
+	for i, delivery := range deliveries {
+		if err := u.webhookRepo.SaveAttempt(ctx, delivery.ID, attempts[i]); err != nil {
+			saveErrs = append(saveErrs, err)
+			continue
+		}
+
+		switch attempts[i].Status {
+		case entities.DeliveryDelivered:
+			result.Delivered++
+		case entities.DeliveryDead:
+			result.Dead++
+		default:
+			result.Retried++
+		}
+	}
+
+	return &result, errors.Join(saveErrs...)
+}
+
+func (u *dispatchWebhooksUseCase) send(
+	ctx context.Context,
+	delivery entities.PendingWebhookDeliveryData,
+) entities.WebhookAttemptData {
+
+	statusCode, err := u.webhookSender.Send(ctx, delivery)
+
+	var attempt entities.WebhookAttemptData
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+
+	if err == nil {
+		attempt.Status = entities.DeliveryDelivered
+		return attempt
+	}
+
+	attempt.Error = err.Error()
+
+	number := delivery.Attempts + 1
+	if number >= u.retryPolicy.MaxAttempts {
+		attempt.Status = entities.DeliveryDead
+		return attempt
+	}
+
+	attempt.Status = entities.DeliveryPending
+	attempt.RetryIn = u.retryPolicy.Delay(number)
+	return attempt
+}
@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var webhookRetryPolicy = entities.WebhookRetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     10 * time.Second,
+	MaxBackoff:  time.Minute,
+}
+
+func setupDispatchWebhooks() (usecase.DispatchWebhooksUseCase, *MockWebhookRepo, *MockWebhookSender) {
+	mockWebhookRepo := new(MockWebhookRepo)
+	mockSender := new(MockWebhookSender)
+	useCase := usecase.NewDispatchWebhooksUseCase(mockWebhookRepo, mockSender, 10, 5*time.Second, webhookRetryPolicy)
+	return useCase, mockWebhookRepo, mockSender
+}
+
+func TestDispatchWebhooksUseCase_Execute(t *testing.T) {
+	useCase, mockWebhookRepo, mockSender := setupDispatchWebhooks()
+	ctx := contextWithRole(entities.RoleAdmin)
+
+	delivered := entities.PendingWebhookDeliveryData{ID: 1, URL: "https://a.example.com"}
+	retried := entities.PendingWebhookDeliveryData{ID: 2, URL: "https://b.example.com", Attempts: 1}
+	dead := entities.PendingWebhookDeliveryData{ID: 3, URL: "https://c.example.com", Attempts: 2}
+
+	mockWebhookRepo.On("ClaimDeliveries", ctx, 10, 5*time.Second).
+		Return([]entities.PendingWebhookDeliveryData{delivered, retried, dead}, nil)
+	mockSender.On("Send", ctx, delivered).Return(204, nil)
+	mockSender.On("Send", ctx, retried).Return(500, errors.New("webhook endpoint responded with HTTP status 500"))
+	mockSender.On("Send", ctx, dead).Return(0, errors.New("connection refused"))
+
+	ok, failed := 204, 500
+	mockWebhookRepo.On("SaveAttempt", ctx, int64(1), entities.WebhookAttemptData{
+		Status:     entities.DeliveryDelivered,
+		StatusCode: &ok,
+	}).Return(nil)
+	// вторая неудачная попытка ждёт вдвое дольше первой
+	mockWebhookRepo.On("SaveAttempt", ctx, int64(2), entities.WebhookAttemptData{
+		Status:     entities.DeliveryPending,
+		StatusCode: &failed,
+		Error:      "webhook endpoint responded with HTTP status 500",
+		RetryIn:    20 * time.Second,
+	}).Return(nil)
+	mockWebhookRepo.On("SaveAttempt", ctx, int64(3), entities.WebhookAttemptData{
+		Status: entities.DeliveryDead,
+		Error:  "connection refused",
+	}).Return(nil)
+
+	result, err := useCase.Execute(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.WebhookDispatchResult{Delivered: 1, Retried: 1, Dead: 1}, result)
+	mockWebhookRepo.AssertExpectations(t)
+	mockSender.AssertExpectations(t)
+}
+
+// результат остальных доставок сохраняется, даже если одну сохранить не удалось
+func TestDispatchWebhooksUseCase_Execute_SaveError(t *testing.T) {
+	useCase, mockWebhookRepo, mockSender := setupDispatchWebhooks()
+	ctx := contextWithRole(entities.RoleAdmin)
+
+	first := entities.PendingWebhookDeliveryData{ID: 1}
+	second := entities.PendingWebhookDeliveryData{ID: 2}
+
+	mockWebhookRepo.On("ClaimDeliveries", ctx, 10, 5*time.Second).
+		Return([]entities.PendingWebhookDeliveryData{first, second}, nil)
+	mockSender.On("Send", ctx, mock.Anything).Return(200, nil)
+	mockWebhookRepo.On("SaveAttempt", ctx, int64(1), mock.Anything).Return(errors.New("connection lost"))
+	mockWebhookRepo.On("SaveAttempt", ctx, int64(2), mock.Anything).Return(nil)
+
+	result, err := useCase.Execute(ctx)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, result.Delivered)
+	mockWebhookRepo.AssertExpectations(t)
+}
+
+func TestDispatchWebhooksUseCase_Execute_Forbidden(t *testing.T) {
+	useCase, mockWebhookRepo, _ := setupDispatchWebhooks()
+
+	_, err := useCase.Execute(contextWithRole(entities.RoleEditor))
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	mockWebhookRepo.AssertNotCalled(t, "ClaimDeliveries")
+}
+
+func TestWebhookRetryPolicy_Delay(t *testing.T) {
+	assert.Equal(t, 10*time.Second, webhookRetryPolicy.Delay(1))
+	assert.Equal(t, 20*time.Second, webhookRetryPolicy.Delay(2))
+	assert.Equal(t, 40*time.Second, webhookRetryPolicy.Delay(3))
+	assert.Equal(t, time.Minute, webhookRetryPolicy.Delay(4))
+	assert.Equal(t, time.Minute, webhookRetryPolicy.Delay(100))
+}
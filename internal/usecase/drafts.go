@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"errors"
+)
+
+type GetDraftsUseCase interface {
+	Execute(ctx context.Context, filter entities.DraftFilterData) ([]entities.SongDraftData, error)
+}
+
+type getDraftsUseCase struct {
+	draftRepo SongDraftRepo
+}
+
+func NewGetDraftsUseCase(dr SongDraftRepo) GetDraftsUseCase {
+	return &getDraftsUseCase{
+		draftRepo: dr,
+	}
+}
+
+func (u *getDraftsUseCase) Execute(
+	ctx context.Context,
+	filter entities.DraftFilterData,
+) ([]entities.SongDraftData, error) {
+
+	if err := authorize(ctx, entities.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == nil {
+		limit := 50
+		filter.Limit = &limit
+	}
+
+	if filter.Offset == nil {
+		offset := 0
+		filter.Offset = &offset
+	}
+
+	return u.draftRepo.GetList(ctx, filter)
+}
+
+type AcceptDraftUseCase interface {
+	Execute(ctx context.Context, songID int) (*entities.SongData, error)
+}
+
+type acceptDraftUseCase struct {
+	transactionManager TransactionManager
+	songRepo           SongRepo
+	lyricsRepo         LyricsRepo
+	draftRepo          SongDraftRepo
+	auditRepo          AuditRepo
+	eventRepo          EventRepo
+	eventPublisher     EventPublisher
+	contentFilter      ContentFilter
+}
+
+func NewAcceptDraftUseCase(
+	tm TransactionManager,
+	sr SongRepo,
+	lr LyricsRepo,
+	dr SongDraftRepo,
+	ar AuditRepo,
+	er EventRepo,
+	ep EventPublisher,
+	cf ContentFilter,
+) AcceptDraftUseCase {
+	return &acceptDraftUseCase{
+		transactionManager: tm,
+		songRepo:           sr,
+		lyricsRepo:         lr,
+		draftRepo:          dr,
+		auditRepo:          ar,
+		eventRepo:          er,
+		eventPublisher:     ep,
+		contentFilter:      cf,
+	}
+}
+
+// Применяет к песне черновик, сохранённый при обновлении с политикой draft, и удаляет его.
+// В журнал аудита изменение попадает как обновление из внешнего сервиса.
+func (u *acceptDraftUseCase) Execute(ctx context.Context, songID int) (*entities.SongData, error) {
+	if err := authorize(ctx, entities.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	var accepted entities.SongData
+	var events []entities.EventData
+
+	err := u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		drafts, err := u.draftRepo.GetList(ctx, entities.DraftFilterData{SongID: &songID})
+		if err != nil {
+			return err
+		}
+		draft := drafts[0]
+
+		songs, err := u.songRepo.GetList(ctx, entities.SongFilterData{ID: &songID})
+		if err != nil {
+			return err
+		}
+		song := songs[0]
+
+		hasLyrics := true
+		lyrics, err := u.lyricsRepo.Get(ctx, songID)
+		if err != nil {
+			if !errors.Is(err, errs.ErrNotFound) {
+				return err
+			}
+			hasLyrics = false
+		}
+
+		before := songAuditData(song, lyrics.Content)
+
+		update := entities.UpdateSongData{
+			ReleaseDate: draft.ReleaseDate,
+			Link:        draft.Link,
+			Lyrics:      draft.Lyrics,
+		}
+		if update.ReleaseDate != nil {
+			song.ReleaseDate = *update.ReleaseDate
+		}
+		if update.Link != nil {
+			song.Link = *update.Link
+		}
+		if update.Lyrics != nil {
+			explicit := u.contentFilter.IsExplicit(*update.Lyrics)
+			update.Explicit = &explicit
+			song.Explicit = &explicit
+		}
+
+		// черновик удаляется первым: если его одновременно принял или отклонил
+		// другой запрос, удаление не найдёт черновик и песня не изменится
+		if err := u.draftRepo.Delete(ctx, songID); err != nil {
+			return err
+		}
+
+		if err := u.songRepo.Update(ctx, songID, update); err != nil {
+			return err
+		}
+
+		if update.Lyrics != nil {
+			if err := u.saveLyrics(ctx, songID, hasLyrics, update); err != nil {
+				return err
+			}
+		}
+
+		after := applySongUpdate(before, update)
+		if err := writeAudit(ctx, u.auditRepo, entities.AuditRefresh, songID, before, after); err != nil {
+			return err
+		}
+
+		events, err = writeEvents(ctx, u.eventRepo, songID, &before, &after)
+		if err != nil {
+			return err
+		}
+
+		accepted = song
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	u.eventPublisher.Publish(events)
+
+	return &accepted, nil
+}
+
+func (u *acceptDraftUseCase) saveLyrics(
+	ctx context.Context,
+	songID int,
+	hasLyrics bool,
+	update entities.UpdateSongData,
+) error {
+	if hasLyrics {
+		return u.lyricsRepo.Update(ctx, songID, update)
+	}
+
+	return u.lyricsRepo.Create(ctx, entities.NewLyricsData{
+		SongID:  songID,
+		Content: *update.Lyrics,
+	})
+}
+
+type RejectDraftUseCase interface {
+	Execute(ctx context.Context, songID int) error
+}
+
+type rejectDraftUseCase struct {
+	draftRepo SongDraftRepo
+}
+
+func NewRejectDraftUseCase(dr SongDraftRepo) RejectDraftUseCase {
+	return &rejectDraftUseCase{
+		draftRepo: dr,
+	}
+}
+
+// Удаляет черновик, не меняя песню. Следующее обновление с политикой draft сохранит новый.
+func (u *rejectDraftUseCase) Execute(ctx context.Context, songID int) error {
+	if err := authorize(ctx, entities.RoleEditor); err != nil {
+		return err
+	}
+
+	return u.draftRepo.Delete(ctx, songID)
+}
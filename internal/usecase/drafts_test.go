@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAcceptDraft() (usecase.AcceptDraftUseCase, refreshMocks) {
+	m := refreshMocks{
+		tm:            new(MockTransactionManager),
+		songRepo:      new(MockSongRepo),
+		lyricsRepo:    new(MockLyricsRepo),
+		draftRepo:     new(MockSongDraftRepo),
+		auditRepo:     new(MockAuditRepo),
+		eventRepo:     new(MockEventRepo),
+		eventBroker:   new(MockEventBroker),
+		contentFilter: new(MockContentFilter),
+	}
+	useCase := usecase.NewAcceptDraftUseCase(m.tm, m.songRepo, m.lyricsRepo, m.draftRepo, m.auditRepo, m.eventRepo, m.eventBroker, m.contentFilter)
+	return useCase, m
+}
+
+func TestGetDraftsUseCase_Execute_DefaultPaging(t *testing.T) {
+	mockDraftRepo := new(MockSongDraftRepo)
+	useCase := usecase.NewGetDraftsUseCase(mockDraftRepo)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	offset, limit := 0, 50
+	link := "https://new.example.com"
+	drafts := []entities.SongDraftData{{SongID: refreshSongID, Link: &link}}
+
+	mockDraftRepo.On("GetList", ctx, entities.DraftFilterData{Offset: &offset, Limit: &limit}).Return(drafts, nil)
+
+	result, err := useCase.Execute(ctx, entities.DraftFilterData{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, drafts, result)
+	mockDraftRepo.AssertExpectations(t)
+}
+
+// Черновик применяется к песне как обновление из внешнего сервиса и удаляется
+func TestAcceptDraftUseCase_Execute_Success(t *testing.T) {
+	useCase, m := setupAcceptDraft()
+	ctx := contextWithRole(entities.RoleEditor)
+
+	link, lyrics := "https://new.example.com", "New lyrics"
+	explicit := false
+	draft := entities.SongDraftData{SongID: refreshSongID, ReleaseDate: &upstreamRelease, Link: &link, Lyrics: &lyrics}
+	update := entities.UpdateSongData{ReleaseDate: &upstreamRelease, Link: &link, Lyrics: &lyrics, Explicit: &explicit}
+
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	m.draftRepo.On("GetList", ctx, entities.DraftFilterData{SongID: &refreshSongID}).Return([]entities.SongDraftData{draft}, nil)
+	m.songRepo.On("GetList", ctx, entities.SongFilterData{ID: &refreshSongID}).Return([]entities.SongData{storedSong}, nil)
+	m.lyricsRepo.On("Get", ctx, refreshSongID).Return(entities.LyricsData{SongID: refreshSongID, Content: "Old lyrics"}, nil)
+	m.contentFilter.On("IsExplicit", lyrics).Return(false)
+	m.draftRepo.On("Delete", ctx, refreshSongID).Return(nil).Once()
+	m.songRepo.On("Update", ctx, refreshSongID, update).Return(nil).Once()
+	m.lyricsRepo.On("Update", ctx, refreshSongID, update).Return(nil).Once()
+	m.auditRepo.On("Create", ctx, auditRecordWith(entities.AuditRefresh, refreshSongID)).Return(nil).Once()
+	m.eventRepo.On("Create", ctx, eventsOf(refreshSongID, entities.EventSongUpdated, entities.EventLyricsUpdated)).
+		Return([]entities.EventData{{ID: 1}, {ID: 2}}, nil)
+	m.eventBroker.On("Publish", []entities.EventData{{ID: 1}, {ID: 2}}).Once()
+
+	result, err := useCase.Execute(ctx, refreshSongID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://new.example.com", result.Link)
+	assert.Equal(t, upstreamRelease, result.ReleaseDate)
+	assert.False(t, *result.Explicit)
+	m.draftRepo.AssertExpectations(t)
+	m.songRepo.AssertExpectations(t)
+	m.lyricsRepo.AssertExpectations(t)
+	m.eventBroker.AssertExpectations(t)
+}
+
+// Без черновика песня не меняется
+func TestAcceptDraftUseCase_Execute_NoDraft(t *testing.T) {
+	useCase, m := setupAcceptDraft()
+	ctx := contextWithRole(entities.RoleEditor)
+
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(errs.ErrNotFound)
+	m.draftRepo.On("GetList", ctx, entities.DraftFilterData{SongID: &refreshSongID}).
+		Return(nil, fmt.Errorf("%w song drafts not found", errs.ErrNotFound))
+
+	result, err := useCase.Execute(ctx, refreshSongID)
+
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.Nil(t, result)
+	m.songRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	m.eventBroker.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+// Отклонить черновик может только editor
+func TestRejectDraftUseCase_Execute_Forbidden(t *testing.T) {
+	mockDraftRepo := new(MockSongDraftRepo)
+	useCase := usecase.NewRejectDraftUseCase(mockDraftRepo)
+
+	err := useCase.Execute(contextWithRole(entities.RoleViewer), refreshSongID)
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	mockDraftRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+)
+
+// Пишет события об изменении песни в outbox. Вызывается в той же транзакции, что и изменение,
+// поэтому подписчики узнают только о зафиксированных изменениях и ни одно не пропустят.
+// Возвращает сохранённые события, их публикуют подписчикам после коммита транзакции.
+func writeEvents(ctx context.Context, repo EventRepo, songID int, before, after *entities.SongAuditData) ([]entities.EventData, error) {
+	events := songEvents(before, after)
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	requestID := entities.RequestIDFromContext(ctx)
+	for i := range events {
+		events[i].SongID = songID
+		events[i].RequestID = requestID
+	}
+
+	return repo.Create(ctx, events)
+}
+
+// События, которые описывают переход песни из состояния before в after.
+// Изменение текста публикуется отдельным событием, чтобы на него можно было подписаться отдельно.
+func songEvents(before, after *entities.SongAuditData) []entities.NewEventData {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		return []entities.NewEventData{{Type: entities.EventSongCreated, Data: *after}}
+	case after == nil:
+		return []entities.NewEventData{{Type: entities.EventSongDeleted, Data: *before}}
+	}
+
+	var events []entities.NewEventData
+
+	songBefore, songAfter := *before, *after
+	songBefore.Lyrics, songAfter.Lyrics = "", ""
+	// explicit сравнивается по значению, а не по указателю
+	songBefore.Explicit, songAfter.Explicit = nil, nil
+	if songBefore != songAfter || !equalExplicit(before.Explicit, after.Explicit) {
+		events = append(events, entities.NewEventData{Type: entities.EventSongUpdated, Data: *after})
+	}
+
+	if before.Lyrics != after.Lyrics {
+		events = append(events, entities.NewEventData{Type: entities.EventLyricsUpdated, Data: *after})
+	}
+
+	return events
+}
+
+func equalExplicit(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
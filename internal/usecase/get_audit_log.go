@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+)
+
+type GetAuditLogUseCase interface {
+	Execute(
+		ctx context.Context,
+		filter entities.AuditFilterData,
+	) ([]entities.AuditRecordData, error)
+}
+
+type getAuditLogUseCase struct {
+	auditRepo AuditRepo
+}
+
+func NewGetAuditLogUseCase(ar AuditRepo) GetAuditLogUseCase {
+	return &getAuditLogUseCase{
+		auditRepo: ar,
+	}
+}
+
+// Журнал доступен только администраторам: в нём хранятся полные тексты
+// и данные всех клиентов, вносивших изменения.
+func (u *getAuditLogUseCase) Execute(
+	ctx context.Context,
+	filter entities.AuditFilterData,
+) ([]entities.AuditRecordData, error) {
+
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == nil {
+		limit := 50
+		filter.Limit = &limit
+	}
+
+	if filter.Offset == nil {
+		offset := 0
+		filter.Offset = &offset
+	}
+
+	records, err := u.auditRepo.GetList(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
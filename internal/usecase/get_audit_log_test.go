@@ -0,0 +1,43 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditLogUseCase_Execute_DefaultPagination(t *testing.T) {
+	mockAuditRepo := new(MockAuditRepo)
+	useCase := usecase.NewGetAuditLogUseCase(mockAuditRepo)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+	songID := 1
+	limit := 50
+	offset := 0
+	expectedRecords := []entities.AuditRecordData{
+		{ID: 2, Actor: "api_key:1", Action: entities.AuditUpdate, Entity: entities.AuditEntitySong, EntityID: songID},
+	}
+
+	mockAuditRepo.On("GetList", ctx, entities.AuditFilterData{EntityID: &songID, Limit: &limit, Offset: &offset}).Return(expectedRecords, nil)
+
+	records, err := useCase.Execute(ctx, entities.AuditFilterData{EntityID: &songID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRecords, records)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+// Журнал содержит данные всех клиентов, editor его не видит
+func TestGetAuditLogUseCase_Execute_Forbidden(t *testing.T) {
+	mockAuditRepo := new(MockAuditRepo)
+	useCase := usecase.NewGetAuditLogUseCase(mockAuditRepo)
+
+	records, err := useCase.Execute(contextWithRole(entities.RoleEditor), entities.AuditFilterData{})
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	assert.Nil(t, records)
+	mockAuditRepo.AssertNotCalled(t, "GetList")
+}
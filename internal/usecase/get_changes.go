@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"time"
+)
+
+type GetChangesUseCase interface {
+	Execute(ctx context.Context, filter entities.ChangesFilterData) (*entities.ChangesData, error)
+}
+
+type getChangesUseCase struct {
+	eventRepo    EventRepo
+	pollInterval time.Duration
+}
+
+func NewGetChangesUseCase(er EventRepo, pollInterval time.Duration) GetChangesUseCase {
+	return &getChangesUseCase{
+		eventRepo:    er,
+		pollInterval: pollInterval,
+	}
+}
+
+// Возвращает события после filter.Since. Если их нет и задано ожидание, повторяет запрос
+// раз в pollInterval, пока не появятся события, не истечёт ожидание или клиент не отключится.
+func (u *getChangesUseCase) Execute(ctx context.Context, filter entities.ChangesFilterData) (*entities.ChangesData, error) {
+	if err := authorize(ctx, entities.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(filter.Wait)
+
+	for {
+		events, err := u.eventRepo.GetList(ctx, entities.EventFilterData{
+			Since: filter.Since,
+			Limit: filter.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(events) > 0 {
+			return &entities.ChangesData{Changes: events, Next: events[len(events)-1].ID}, nil
+		}
+
+		wait := min(u.pollInterval, time.Until(deadline))
+		if wait <= 0 {
+			return &entities.ChangesData{Changes: []entities.EventData{}, Next: filter.Since}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &entities.ChangesData{Changes: []entities.EventData{}, Next: filter.Since}, nil
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package usecase_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChangesUseCase_Execute_ReturnsNext(t *testing.T) {
+	mockEventRepo := new(MockEventRepo)
+	useCase := usecase.NewGetChangesUseCase(mockEventRepo, time.Millisecond)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	events := []entities.EventData{
+		{ID: 11, Type: entities.EventSongUpdated, SongID: 1},
+		{ID: 12, Type: entities.EventSongDeleted, SongID: 2, Deleted: true},
+	}
+	mockEventRepo.On("GetList", ctx, entities.EventFilterData{Since: 10, Limit: 2}).Return(events, nil).Once()
+
+	changes, err := useCase.Execute(ctx, entities.ChangesFilterData{Since: 10, Limit: 2, Wait: time.Minute})
+
+	assert.NoError(t, err)
+	assert.Equal(t, events, changes.Changes)
+	assert.Equal(t, int64(12), changes.Next)
+	mockEventRepo.AssertExpectations(t)
+}
+
+// long-poll возвращает события, как только они появились
+func TestGetChangesUseCase_Execute_WaitsForEvents(t *testing.T) {
+	mockEventRepo := new(MockEventRepo)
+	useCase := usecase.NewGetChangesUseCase(mockEventRepo, time.Millisecond)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	filter := entities.EventFilterData{Since: 5, Limit: 100}
+	mockEventRepo.On("GetList", ctx, filter).Return([]entities.EventData{}, nil).Twice()
+	mockEventRepo.On("GetList", ctx, filter).Return([]entities.EventData{{ID: 6}}, nil).Once()
+
+	changes, err := useCase.Execute(ctx, entities.ChangesFilterData{Since: 5, Limit: 100, Wait: time.Minute})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), changes.Next)
+	mockEventRepo.AssertExpectations(t)
+}
+
+// без новых событий номер не меняется
+func TestGetChangesUseCase_Execute_NoEvents(t *testing.T) {
+	mockEventRepo := new(MockEventRepo)
+	useCase := usecase.NewGetChangesUseCase(mockEventRepo, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(contextWithRole(entities.RoleViewer))
+	cancel()
+	mockEventRepo.On("GetList", ctx, entities.EventFilterData{Since: 5, Limit: 100}).Return([]entities.EventData{}, nil)
+
+	changes, err := useCase.Execute(ctx, entities.ChangesFilterData{Since: 5, Limit: 100, Wait: time.Minute})
+
+	assert.NoError(t, err)
+	assert.Empty(t, changes.Changes)
+	assert.Equal(t, int64(5), changes.Next)
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+)
+
+type GetConfigUseCase interface {
+	Execute(ctx context.Context) (*entities.ConfigState, error)
+}
+
+type getConfigUseCase struct {
+	configSource ConfigSource
+}
+
+func NewGetConfigUseCase(cs ConfigSource) GetConfigUseCase {
+	return &getConfigUseCase{
+		configSource: cs,
+	}
+}
+
+// Конфигурация раскрывает устройство сервиса, поэтому доступна только администраторам.
+// Пароли и секреты в ней уже скрыты.
+func (u *getConfigUseCase) Execute(ctx context.Context) (*entities.ConfigState, error) {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	state := u.configSource.ConfigState()
+	return &state, nil
+}
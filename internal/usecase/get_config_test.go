@@ -0,0 +1,41 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConfigUseCase_Execute_Success(t *testing.T) {
+	mockConfigSource := new(MockConfigSource)
+	useCase := usecase.NewGetConfigUseCase(mockConfigSource)
+
+	expected := entities.ConfigState{
+		Settings: []entities.ConfigSetting{
+			{Key: "EMLIB_INFOSERVICE_TIMEOUT", Value: "800", Source: "file"},
+		},
+		LastReload: &entities.ConfigReloadResult{Trigger: "signal", Success: true, Applied: []string{"EMLIB_INFOSERVICE_TIMEOUT"}},
+	}
+
+	mockConfigSource.On("ConfigState").Return(expected)
+
+	state, err := useCase.Execute(contextWithRole(entities.RoleAdmin))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, state)
+	mockConfigSource.AssertExpectations(t)
+}
+
+func TestGetConfigUseCase_Execute_Forbidden(t *testing.T) {
+	mockConfigSource := new(MockConfigSource)
+	useCase := usecase.NewGetConfigUseCase(mockConfigSource)
+
+	state, err := useCase.Execute(contextWithRole(entities.RoleEditor))
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	assert.Nil(t, state)
+	mockConfigSource.AssertNotCalled(t, "ConfigState")
+}
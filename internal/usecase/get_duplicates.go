@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+)
+
+type GetDuplicatesUseCase interface {
+	Execute(
+		ctx context.Context,
+		filter entities.DuplicateFilterData,
+	) ([]entities.DuplicateData, error)
+}
+
+type getDuplicatesUseCase struct {
+	duplicateRepo DuplicateRepo
+}
+
+func NewGetDuplicatesUseCase(dr DuplicateRepo) GetDuplicatesUseCase {
+	return &getDuplicatesUseCase{
+		duplicateRepo: dr,
+	}
+}
+
+func (u *getDuplicatesUseCase) Execute(
+	ctx context.Context,
+	filter entities.DuplicateFilterData,
+) ([]entities.DuplicateData, error) {
+
+	if err := authorize(ctx, entities.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == nil {
+		limit := 50
+		filter.Limit = &limit
+	}
+
+	if filter.Offset == nil {
+		offset := 0
+		filter.Offset = &offset
+	}
+
+	duplicates, err := u.duplicateRepo.GetList(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicates, nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/pkg/textdiff"
+	"fmt"
+	"strings"
+)
+
+const diffContextLines = 3
+
+type GetLyricsDiffUseCase interface {
+	Execute(
+		ctx context.Context,
+		songID int,
+		target entities.LyricsDiffTarget,
+	) (*entities.LyricsDiffData, error)
+}
+
+type getLyricsDiffUseCase struct {
+	songRepo        SongRepo
+	lyricsRepo      LyricsRepo
+	songInfoService SongInfoService
+}
+
+func NewGetLyricsDiffUseCase(sr SongRepo, lr LyricsRepo, s SongInfoService) GetLyricsDiffUseCase {
+	return &getLyricsDiffUseCase{
+		songRepo:        sr,
+		lyricsRepo:      lr,
+		songInfoService: s,
+	}
+}
+
+// Сравнение всегда идёт от более старого текста к более новому:
+// при сравнении с внешним сервисом — от сохранённого к полученному,
+// при сравнении с ревизией — от ревизии к сохранённому.
+func (u *getLyricsDiffUseCase) Execute(
+	ctx context.Context,
+	songID int,
+	target entities.LyricsDiffTarget,
+) (*entities.LyricsDiffData, error) {
+
+	if err := authorize(ctx, entities.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	current, err := u.lyricsRepo.Get(ctx, songID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromName, toName, from, to string
+
+	if target.Upstream {
+		songs, err := u.songRepo.GetList(ctx, entities.SongFilterData{ID: &songID})
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := u.songInfoService.GetInfo(ctx, songs[0].Band, songs[0].Song)
+		if err != nil {
+			return nil, err
+		}
+
+		fromName, from = "current", current.Content
+		toName, to = "upstream", info.Lyrics
+	} else {
+		revision, err := u.lyricsRepo.GetRevision(ctx, songID, target.Revision)
+		if err != nil {
+			return nil, err
+		}
+
+		fromName, from = fmt.Sprintf("revision:%d", revision.Revision), revision.Content
+		toName, to = "current", current.Content
+	}
+
+	return &entities.LyricsDiffData{
+		From: fromName,
+		To:   toName,
+		Unified: textdiff.Unified(
+			fromName,
+			toName,
+			strings.Split(from, lineSeparator),
+			strings.Split(to, lineSeparator),
+			diffContextLines,
+		),
+		Verses: diffVerses(strings.Split(from, verseSeparator), strings.Split(to, verseSeparator)),
+	}, nil
+}
+
+// Выравнивает куплеты двух текстов. Подряд идущие удаление и добавление
+// считаются изменением куплета, лишние куплеты — удалёнными или добавленными.
+func diffVerses(from, to []string) []entities.VerseDiffData {
+	ops := textdiff.Diff(from, to)
+	result := make([]entities.VerseDiffData, 0, len(ops))
+
+	for idx := 0; idx < len(ops); {
+		if ops[idx].Kind == textdiff.Equal {
+			op := ops[idx]
+			result = append(result, entities.VerseDiffData{
+				Op:        "equal",
+				FromIndex: &op.AIndex,
+				ToIndex:   &op.BIndex,
+				From:      &op.Text,
+				To:        &op.Text,
+			})
+			idx++
+			continue
+		}
+
+		var deleted, inserted []textdiff.Op
+		for ; idx < len(ops) && ops[idx].Kind != textdiff.Equal; idx++ {
+			if ops[idx].Kind == textdiff.Delete {
+				deleted = append(deleted, ops[idx])
+			} else {
+				inserted = append(inserted, ops[idx])
+			}
+		}
+
+		paired := min(len(deleted), len(inserted))
+		for i := range paired {
+			result = append(result, entities.VerseDiffData{
+				Op:        "changed",
+				FromIndex: &deleted[i].AIndex,
+				ToIndex:   &inserted[i].BIndex,
+				From:      &deleted[i].Text,
+				To:        &inserted[i].Text,
+			})
+		}
+		for i := paired; i < len(deleted); i++ {
+			result = append(result, entities.VerseDiffData{
+				Op:        "removed",
+				FromIndex: &deleted[i].AIndex,
+				From:      &deleted[i].Text,
+			})
+		}
+		for i := paired; i < len(inserted); i++ {
+			result = append(result, entities.VerseDiffData{
+				Op:      "added",
+				ToIndex: &inserted[i].BIndex,
+				To:      &inserted[i].Text,
+			})
+		}
+	}
+
+	return result
+}
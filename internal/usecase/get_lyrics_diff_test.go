@@ -0,0 +1,109 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLyricsDiffUseCase_Execute_Upstream(t *testing.T) {
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockInfoService := new(MockSongInfoService)
+	useCase := usecase.NewGetLyricsDiffUseCase(mockSongRepo, mockLyricsRepo, mockInfoService)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	songID := 123
+
+	mockLyricsRepo.On("Get", ctx, songID).Return(entities.LyricsData{
+		SongID:  songID,
+		Content: "Line 1\\nLine 2\\n\\nChorus\\n\\nOld verse",
+	}, nil)
+	mockSongRepo.On("GetList", ctx, entities.SongFilterData{ID: &songID}).Return([]entities.SongData{
+		{ID: songID, Band: "Test Group", Song: "Test Song"},
+	}, nil)
+	mockInfoService.On("GetInfo", ctx, "Test Group", "Test Song").Return(&entities.SongDetail{
+		Lyrics: "Line 1\\nLine 2 fixed\\n\\nChorus\\n\\nOld verse\\n\\nNew verse",
+	}, nil)
+
+	result, err := useCase.Execute(ctx, songID, entities.LyricsDiffTarget{Upstream: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "current", result.From)
+	assert.Equal(t, "upstream", result.To)
+	assert.Equal(t,
+		"--- current\n+++ upstream\n@@ -1,6 +1,8 @@\n Line 1\n-Line 2\n+Line 2 fixed\n \n Chorus\n \n Old verse\n+\n+New verse\n",
+		result.Unified,
+	)
+
+	assert.Len(t, result.Verses, 4)
+	assert.Equal(t, "changed", result.Verses[0].Op)
+	assert.Equal(t, "Line 1\\nLine 2", *result.Verses[0].From)
+	assert.Equal(t, "Line 1\\nLine 2 fixed", *result.Verses[0].To)
+	assert.Equal(t, "equal", result.Verses[1].Op)
+	assert.Equal(t, "equal", result.Verses[2].Op)
+	assert.Equal(t, "added", result.Verses[3].Op)
+	assert.Nil(t, result.Verses[3].FromIndex)
+	assert.Equal(t, 3, *result.Verses[3].ToIndex)
+
+	mockLyricsRepo.AssertExpectations(t)
+	mockSongRepo.AssertExpectations(t)
+	mockInfoService.AssertExpectations(t)
+}
+
+func TestGetLyricsDiffUseCase_Execute_Revision(t *testing.T) {
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockInfoService := new(MockSongInfoService)
+	useCase := usecase.NewGetLyricsDiffUseCase(mockSongRepo, mockLyricsRepo, mockInfoService)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	songID := 123
+
+	mockLyricsRepo.On("Get", ctx, songID).Return(entities.LyricsData{
+		SongID:  songID,
+		Content: "Verse 1",
+	}, nil)
+	mockLyricsRepo.On("GetRevision", ctx, songID, 2).Return(entities.LyricsRevisionData{
+		SongID:   songID,
+		Revision: 2,
+		Content:  "Verse 1\\n\\nVerse 2",
+	}, nil)
+
+	result, err := useCase.Execute(ctx, songID, entities.LyricsDiffTarget{Revision: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "revision:2", result.From)
+	assert.Equal(t, "current", result.To)
+	assert.Len(t, result.Verses, 2)
+	assert.Equal(t, "equal", result.Verses[0].Op)
+	assert.Equal(t, "removed", result.Verses[1].Op)
+	assert.Equal(t, "Verse 2", *result.Verses[1].From)
+
+	mockLyricsRepo.AssertExpectations(t)
+	mockSongRepo.AssertNotCalled(t, "GetList")
+	mockInfoService.AssertNotCalled(t, "GetInfo")
+}
+
+func TestGetLyricsDiffUseCase_Execute_RevisionNotFound(t *testing.T) {
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockInfoService := new(MockSongInfoService)
+	useCase := usecase.NewGetLyricsDiffUseCase(mockSongRepo, mockLyricsRepo, mockInfoService)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	songID := 123
+
+	mockLyricsRepo.On("Get", ctx, songID).Return(entities.LyricsData{SongID: songID, Content: "Verse 1"}, nil)
+	mockLyricsRepo.On("GetRevision", ctx, songID, 5).Return(nil, errs.ErrNotFound)
+
+	result, err := useCase.Execute(ctx, songID, entities.LyricsDiffTarget{Revision: 5})
+
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.Nil(t, result)
+
+	mockLyricsRepo.AssertExpectations(t)
+}
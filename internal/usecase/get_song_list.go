@@ -27,6 +27,10 @@ func (u *getSongListUseCase) Execute(
 	filter entities.SongFilterData,
 ) ([]entities.SongData, error) {
 
+	if err := authorize(ctx, entities.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	if filter.Limit == nil {
 		limit := 50
 		filter.Limit = &limit
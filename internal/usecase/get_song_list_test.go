@@ -1,7 +1,6 @@
 package usecase_test
 
 import (
-	"context"
 	"em-library/internal/entities"
 	"em-library/internal/usecase"
 	"errors"
@@ -16,7 +15,7 @@ func TestGetSongListUseCase_Execute_Success(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	useCase := usecase.NewGetSongListUseCase(mockSongRepo)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 
 	mockSongs := []entities.SongData{
@@ -53,7 +52,7 @@ func TestGetSongListUseCase_Execute_WithDefaultLimitAndOffset(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	useCase := usecase.NewGetSongListUseCase(mockSongRepo)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 
 	mockSongs := []entities.SongData{
@@ -85,7 +84,7 @@ func TestGetSongListUseCase_Execute_WithDefaultLimit(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	useCase := usecase.NewGetSongListUseCase(mockSongRepo)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 
 	mockSongs := []entities.SongData{
@@ -114,7 +113,7 @@ func TestGetSongListUseCase_Execute_WithDefaultOffset(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	useCase := usecase.NewGetSongListUseCase(mockSongRepo)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 
 	mockSongs := []entities.SongData{
@@ -143,7 +142,7 @@ func TestGetSongListUseCase_Execute_WithComplexFilter(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	useCase := usecase.NewGetSongListUseCase(mockSongRepo)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	releaseDate := time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC)
 	releaseDateFrom := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 	releaseDateTo := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
@@ -181,7 +180,7 @@ func TestGetSongListUseCase_Execute_EmptyResult(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	useCase := usecase.NewGetSongListUseCase(mockSongRepo)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	mockSongs := []entities.SongData{}
 
 	band := "NonexistentBand"
@@ -206,7 +205,7 @@ func TestGetSongListUseCase_Execute_RepoError(t *testing.T) {
 	mockSongRepo := new(MockSongRepo)
 	useCase := usecase.NewGetSongListUseCase(mockSongRepo)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	expectedError := errors.New("database error")
 
 	limit := 10
@@ -6,6 +6,12 @@ import (
 	"strings"
 )
 
+// Тексты хранятся с экранированными переводами строк, куплеты разделены пустой строкой.
+const (
+	lineSeparator  = "\\n"
+	verseSeparator = "\\n\\n"
+)
+
 type GetSongLyricsUseCase interface {
 	Execute(
 		ctx context.Context,
@@ -14,12 +20,14 @@ type GetSongLyricsUseCase interface {
 }
 
 type getSongLyricsUseCase struct {
-	lyricsRepo LyricsRepo
+	lyricsRepo    LyricsRepo
+	contentFilter ContentFilter
 }
 
-func NewGetSongLyricsUsecase(lr LyricsRepo) GetSongLyricsUseCase {
+func NewGetSongLyricsUsecase(lr LyricsRepo, cf ContentFilter) GetSongLyricsUseCase {
 	return &getSongLyricsUseCase{
-		lyricsRepo: lr,
+		lyricsRepo:    lr,
+		contentFilter: cf,
 	}
 }
 
@@ -28,12 +36,26 @@ func (u *getSongLyricsUseCase) Execute(
 	songID int,
 	filter entities.LyricsFilterData) ([]entities.LyricsVerseData, error) {
 
+	if err := authorize(ctx, entities.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	lyrics, err := u.lyricsRepo.Get(ctx, songID)
 	if err != nil {
 		return nil, err
 	}
 
-	verses := strings.Split(lyrics.Content, "\\n\\n")
+	content := lyrics.Content
+	if filter.Censor {
+		content = u.contentFilter.Censor(content)
+	}
+
+	return splitVerses(content, filter), nil
+}
+
+// Куплеты текста с учётом Offset и Limit из фильтра
+func splitVerses(content string, filter entities.LyricsFilterData) []entities.LyricsVerseData {
+	verses := strings.Split(content, verseSeparator)
 
 	result := make([]entities.LyricsVerseData, len(verses))
 
@@ -61,5 +83,5 @@ func (u *getSongLyricsUseCase) Execute(
 		lastVerse = len(verses)
 	}
 
-	return result[firstVerse:lastVerse], nil
+	return result[firstVerse:lastVerse]
 }
@@ -1,7 +1,6 @@
 package usecase_test
 
 import (
-	"context"
 	"em-library/internal/entities"
 	"em-library/internal/usecase"
 	"errors"
@@ -12,9 +11,9 @@ import (
 
 func TestGetSongLyricsUseCase_Execute_Success(t *testing.T) {
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, new(MockContentFilter))
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	songID := 123
 
 	mockLyrics := entities.LyricsData{
@@ -40,9 +39,9 @@ func TestGetSongLyricsUseCase_Execute_Success(t *testing.T) {
 
 func TestGetSongLyricsUseCase_Execute_WithFilter(t *testing.T) {
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, new(MockContentFilter))
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	songID := 123
 
 	mockLyrics := entities.LyricsData{
@@ -73,9 +72,9 @@ func TestGetSongLyricsUseCase_Execute_WithFilter(t *testing.T) {
 
 func TestGetSongLyricsUseCase_Execute_OffsetBeyondLength(t *testing.T) {
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, new(MockContentFilter))
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	songID := 123
 
 	mockLyrics := entities.LyricsData{
@@ -100,9 +99,9 @@ func TestGetSongLyricsUseCase_Execute_OffsetBeyondLength(t *testing.T) {
 
 func TestGetSongLyricsUseCase_Execute_LimitBeyondLength(t *testing.T) {
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, new(MockContentFilter))
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	songID := 123
 
 	mockLyrics := entities.LyricsData{
@@ -131,9 +130,9 @@ func TestGetSongLyricsUseCase_Execute_LimitBeyondLength(t *testing.T) {
 
 func TestGetSongLyricsUseCase_Execute_RepoError(t *testing.T) {
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, new(MockContentFilter))
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	songID := 123
 
 	expectedError := errors.New("repository error")
@@ -150,9 +149,9 @@ func TestGetSongLyricsUseCase_Execute_RepoError(t *testing.T) {
 
 func TestGetSongLyricsUseCase_Execute_EmptyContent(t *testing.T) {
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, new(MockContentFilter))
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	songID := 123
 
 	mockLyrics := entities.LyricsData{
@@ -173,9 +172,9 @@ func TestGetSongLyricsUseCase_Execute_EmptyContent(t *testing.T) {
 
 func TestGetSongLyricsUseCase_Execute_NilOffsetAndLimit(t *testing.T) {
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, new(MockContentFilter))
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	songID := 123
 
 	mockLyrics := entities.LyricsData{
@@ -200,9 +199,9 @@ func TestGetSongLyricsUseCase_Execute_NilOffsetAndLimit(t *testing.T) {
 
 func TestGetSongLyricsUseCase_Execute_LimitOnly(t *testing.T) {
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, new(MockContentFilter))
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleViewer)
 	songID := 123
 
 	mockLyrics := entities.LyricsData{
@@ -226,3 +225,30 @@ func TestGetSongLyricsUseCase_Execute_LimitOnly(t *testing.T) {
 
 	mockLyricsRepo.AssertExpectations(t)
 }
+
+func TestGetSongLyricsUseCase_Execute_Censored(t *testing.T) {
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	useCase := usecase.NewGetSongLyricsUsecase(mockLyricsRepo, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	songID := 123
+
+	mockLyrics := entities.LyricsData{
+		SongID:  songID,
+		Content: "Clean verse\\n\\nDirty verse",
+	}
+
+	mockLyricsRepo.On("Get", ctx, songID).Return(mockLyrics, nil)
+	mockContentFilter.On("Censor", mockLyrics.Content).Return("Clean verse\\n\\n***** verse")
+
+	result, err := useCase.Execute(ctx, songID, entities.LyricsFilterData{Censor: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "Clean verse", result[0].Content)
+	assert.Equal(t, "***** verse", result[1].Content)
+
+	mockLyricsRepo.AssertExpectations(t)
+	mockContentFilter.AssertExpectations(t)
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+)
+
+type GetSongsLyricsUseCase interface {
+	Execute(
+		ctx context.Context,
+		songIDs []int,
+		filter entities.LyricsFilterData) (map[int][]entities.LyricsVerseData, error)
+}
+
+type getSongsLyricsUseCase struct {
+	lyricsRepo    LyricsRepo
+	contentFilter ContentFilter
+}
+
+func NewGetSongsLyricsUseCase(lr LyricsRepo, cf ContentFilter) GetSongsLyricsUseCase {
+	return &getSongsLyricsUseCase{
+		lyricsRepo:    lr,
+		contentFilter: cf,
+	}
+}
+
+// Куплеты текстов нескольких песен одним запросом к базе, фильтр применяется к каждому тексту.
+// Песен без текста нет в результате.
+func (u *getSongsLyricsUseCase) Execute(
+	ctx context.Context,
+	songIDs []int,
+	filter entities.LyricsFilterData) (map[int][]entities.LyricsVerseData, error) {
+
+	if err := authorize(ctx, entities.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	lyrics, err := u.lyricsRepo.GetList(ctx, songIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int][]entities.LyricsVerseData, len(lyrics))
+	for _, l := range lyrics {
+		content := l.Content
+		if filter.Censor {
+			content = u.contentFilter.Censor(content)
+		}
+		result[l.SongID] = splitVerses(content, filter)
+	}
+
+	return result, nil
+}
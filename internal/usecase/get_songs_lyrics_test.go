@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// фильтр применяется к каждому тексту, песни без текста пропускаются
+func TestGetSongsLyricsUseCase_Execute_Success(t *testing.T) {
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	useCase := usecase.NewGetSongsLyricsUseCase(mockLyricsRepo, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleViewer)
+	songIDs := []int{1, 2, 3}
+
+	mockLyricsRepo.On("GetList", ctx, songIDs).Return([]entities.LyricsData{
+		{SongID: 1, Content: "Verse 1\\n\\nVerse 2\\n\\nVerse 3"},
+		{SongID: 3, Content: "Damn verse\\n\\nClean verse"},
+	}, nil)
+	mockContentFilter.On("Censor", "Verse 1\\n\\nVerse 2\\n\\nVerse 3").Return("Verse 1\\n\\nVerse 2\\n\\nVerse 3")
+	mockContentFilter.On("Censor", "Damn verse\\n\\nClean verse").Return("**** verse\\n\\nClean verse")
+
+	offset := 1
+	result, err := useCase.Execute(ctx, songIDs, entities.LyricsFilterData{Offset: &offset, Censor: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, []entities.LyricsVerseData{{Index: 1, Content: "Verse 2"}, {Index: 2, Content: "Verse 3"}}, result[1])
+	assert.Equal(t, []entities.LyricsVerseData{{Index: 1, Content: "Clean verse"}}, result[3])
+	assert.NotContains(t, result, 2)
+	mockLyricsRepo.AssertExpectations(t)
+}
+
+func TestGetSongsLyricsUseCase_Execute_Unauthenticated(t *testing.T) {
+	mockLyricsRepo := new(MockLyricsRepo)
+	useCase := usecase.NewGetSongsLyricsUseCase(mockLyricsRepo, new(MockContentFilter))
+
+	result, err := useCase.Execute(context.Background(), []int{1}, entities.LyricsFilterData{})
+
+	assert.ErrorIs(t, err, errs.ErrUnauthorized)
+	assert.Nil(t, result)
+	mockLyricsRepo.AssertNotCalled(t, "GetList")
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	maxIdempotencyKeyLen = 255
+	// ключ, запрос с которым так долго не завершился, считается брошенным, например после падения сервиса
+	idempotencyLockTimeout = 5 * time.Minute
+)
+
+type StartIdempotentRequestUseCase interface {
+	Execute(ctx context.Context, request entities.IdempotentRequest) (*entities.IdempotentResponse, error)
+}
+
+type startIdempotentRequestUseCase struct {
+	idempotencyRepo IdempotencyRepo
+	ttl             time.Duration
+}
+
+func NewStartIdempotentRequestUseCase(ir IdempotencyRepo, ttl time.Duration) StartIdempotentRequestUseCase {
+	return &startIdempotentRequestUseCase{
+		idempotencyRepo: ir,
+		ttl:             ttl,
+	}
+}
+
+// Занимает ключ идемпотентности перед выполнением запроса. Если запрос с этим ключом
+// уже выполнен, возвращает сохранённый ответ, и выполнять запрос повторно не нужно.
+// Ключи у каждого клиента свои. Тот же ключ с другим запросом — ErrIdempotencyKeyReused,
+// пока первый запрос с ключом выполняется — ErrRequestInProgress.
+func (u *startIdempotentRequestUseCase) Execute(
+	ctx context.Context,
+	request entities.IdempotentRequest,
+) (*entities.IdempotentResponse, error) {
+
+	if request.Key == "" || len(request.Key) > maxIdempotencyKeyLen {
+		return nil, fmt.Errorf("%w idempotency key must be 1 to %d characters long", errs.ErrInvalidInput, maxIdempotencyKeyLen)
+	}
+
+	key := idempotencyKey(ctx, request.Key)
+	fingerprint := requestFingerprint(request)
+
+	record, err := u.idempotencyRepo.Acquire(ctx, key, fingerprint, u.ttl, idempotencyLockTimeout)
+	// ключ освободили, пока мы его читали, теперь его можно занять
+	if errors.Is(err, errs.ErrNotFound) {
+		record, err = u.idempotencyRepo.Acquire(ctx, key, fingerprint, u.ttl, idempotencyLockTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if record == nil {
+		return nil, nil
+	}
+
+	if record.Fingerprint != fingerprint {
+		return nil, fmt.Errorf("%w key %q was used for a different request", errs.ErrIdempotencyKeyReused, request.Key)
+	}
+
+	if record.Response == nil {
+		return nil, fmt.Errorf("%w key %q", errs.ErrRequestInProgress, request.Key)
+	}
+
+	return record.Response, nil
+}
+
+type FinishIdempotentRequestUseCase interface {
+	Execute(ctx context.Context, request entities.IdempotentRequest, response *entities.IdempotentResponse) error
+}
+
+type finishIdempotentRequestUseCase struct {
+	idempotencyRepo IdempotencyRepo
+}
+
+func NewFinishIdempotentRequestUseCase(ir IdempotencyRepo) FinishIdempotentRequestUseCase {
+	return &finishIdempotentRequestUseCase{
+		idempotencyRepo: ir,
+	}
+}
+
+// Сохраняет ответ на запрос, занявший ключ, чтобы отдавать его при повторах.
+// Без ответа ключ освобождается: запрос не выполнился, и его можно повторить с тем же ключом.
+func (u *finishIdempotentRequestUseCase) Execute(
+	ctx context.Context,
+	request entities.IdempotentRequest,
+	response *entities.IdempotentResponse,
+) error {
+
+	key := idempotencyKey(ctx, request.Key)
+
+	if response == nil {
+		return u.idempotencyRepo.Delete(ctx, key)
+	}
+
+	return u.idempotencyRepo.SaveResponse(ctx, key, *response)
+}
+
+// Ключ в хранилище: клиент и его ключ. Хэш, чтобы длина не зависела от subject клиента.
+func idempotencyKey(ctx context.Context, key string) string {
+	client := string(entities.AuthNone)
+	if principal, ok := entities.PrincipalFromContext(ctx); ok {
+		client = string(principal.Method) + ":" + principal.Subject
+	}
+
+	sum := sha256.Sum256([]byte(client + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Отпечаток запроса: повтор должен совпадать с исходным запросом методом, путём,
+// строкой запроса и телом. Без строки запроса отпечаток тот же, что у уже сохранённых ключей.
+func requestFingerprint(request entities.IdempotentRequest) string {
+	target := request.Path
+	if request.Query != "" {
+		target += "?" + request.Query
+	}
+
+	h := sha256.New()
+	h.Write([]byte(request.Method + "\n" + target + "\n"))
+	h.Write(request.Body)
+	return hex.EncodeToString(h.Sum(nil))
+}
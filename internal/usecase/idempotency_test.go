@@ -0,0 +1,187 @@
+package usecase_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const idempotencyTTL = 24 * time.Hour
+
+var idempotentRequest = entities.IdempotentRequest{
+	Key:    "3f1c9a52",
+	Method: "POST",
+	Path:   "/api/v1/song",
+	Body:   []byte(`{"group":"Muse","song":"Uprising"}`),
+}
+
+// Ключ и отпечаток, с которыми запрос занимает ключ в хранилище
+func acquiredWith(t *testing.T, ctx context.Context, request entities.IdempotentRequest) (string, string) {
+	mockRepo := new(MockIdempotencyRepo)
+	useCase := usecase.NewStartIdempotentRequestUseCase(mockRepo, idempotencyTTL)
+
+	var key, fingerprint string
+	mockRepo.On("Acquire", ctx, mock.Anything, mock.Anything, idempotencyTTL, mock.Anything).
+		Run(func(args mock.Arguments) {
+			key, fingerprint = args.String(1), args.String(2)
+		}).
+		Return(nil, nil)
+
+	response, err := useCase.Execute(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	return key, fingerprint
+}
+
+// Ключи разных клиентов не пересекаются, а отпечаток зависит от тела запроса
+func TestStartIdempotentRequestUseCase_Execute_Acquired(t *testing.T) {
+	ctx := contextWithRole(entities.RoleEditor)
+	otherCtx := entities.ContextWithPrincipal(context.Background(), &entities.Principal{Subject: "other", Role: entities.RoleEditor})
+
+	key, fingerprint := acquiredWith(t, ctx, idempotentRequest)
+	otherKey, _ := acquiredWith(t, otherCtx, idempotentRequest)
+
+	changed := idempotentRequest
+	changed.Body = []byte(`{"group":"Muse","song":"Hysteria"}`)
+	sameKey, otherFingerprint := acquiredWith(t, ctx, changed)
+
+	assert.NotEqual(t, key, otherKey)
+	assert.Equal(t, key, sameKey)
+	assert.NotEqual(t, fingerprint, otherFingerprint)
+}
+
+// Тот же ключ с другой строкой запроса — другой запрос, например обновление с другой политикой
+func TestStartIdempotentRequestUseCase_Execute_ChangedQuery(t *testing.T) {
+	ctx := contextWithRole(entities.RoleEditor)
+
+	request := idempotentRequest
+	request.Path = "/api/v1/song/1/refresh"
+	request.Query = "policy=draft"
+	request.Body = nil
+
+	key, fingerprint := acquiredWith(t, ctx, request)
+
+	changed := request
+	changed.Query = "policy=overwrite"
+	sameKey, otherFingerprint := acquiredWith(t, ctx, changed)
+
+	withoutQuery := request
+	withoutQuery.Query = ""
+	_, bareFingerprint := acquiredWith(t, ctx, withoutQuery)
+
+	assert.Equal(t, key, sameKey)
+	assert.NotEqual(t, fingerprint, otherFingerprint)
+	assert.NotEqual(t, fingerprint, bareFingerprint)
+}
+
+// Повтор выполненного запроса получает сохранённый ответ
+func TestStartIdempotentRequestUseCase_Execute_Replay(t *testing.T) {
+	ctx := contextWithRole(entities.RoleEditor)
+	key, fingerprint := acquiredWith(t, ctx, idempotentRequest)
+
+	mockRepo := new(MockIdempotencyRepo)
+	useCase := usecase.NewStartIdempotentRequestUseCase(mockRepo, idempotencyTTL)
+
+	saved := &entities.IdempotentResponse{Status: 201, ContentType: "application/json", Body: []byte(`{"id":1}`)}
+	mockRepo.On("Acquire", ctx, key, fingerprint, idempotencyTTL, mock.Anything).
+		Return(&entities.IdempotencyKeyData{Key: key, Fingerprint: fingerprint, Response: saved}, nil)
+
+	response, err := useCase.Execute(ctx, idempotentRequest)
+
+	assert.NoError(t, err)
+	assert.Equal(t, saved, response)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStartIdempotentRequestUseCase_Execute_Conflicts(t *testing.T) {
+	ctx := contextWithRole(entities.RoleEditor)
+	key, fingerprint := acquiredWith(t, ctx, idempotentRequest)
+
+	tests := []struct {
+		name     string
+		record   *entities.IdempotencyKeyData
+		expected error
+	}{
+		{
+			name:     "different request",
+			record:   &entities.IdempotencyKeyData{Key: key, Fingerprint: "other", Response: &entities.IdempotentResponse{Status: 201}},
+			expected: errs.ErrIdempotencyKeyReused,
+		},
+		{
+			name:     "in progress",
+			record:   &entities.IdempotencyKeyData{Key: key, Fingerprint: fingerprint},
+			expected: errs.ErrRequestInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIdempotencyRepo)
+			useCase := usecase.NewStartIdempotentRequestUseCase(mockRepo, idempotencyTTL)
+
+			mockRepo.On("Acquire", ctx, key, fingerprint, idempotencyTTL, mock.Anything).Return(tt.record, nil)
+
+			response, err := useCase.Execute(ctx, idempotentRequest)
+
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, response)
+		})
+	}
+}
+
+// Ключ, освобождённый между попыткой занять его и чтением, занимается повторно
+func TestStartIdempotentRequestUseCase_Execute_ReleasedConcurrently(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepo)
+	useCase := usecase.NewStartIdempotentRequestUseCase(mockRepo, idempotencyTTL)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	mockRepo.On("Acquire", ctx, mock.Anything, mock.Anything, idempotencyTTL, mock.Anything).
+		Return(nil, fmt.Errorf("%w no idempotency key", errs.ErrNotFound)).Once()
+	mockRepo.On("Acquire", ctx, mock.Anything, mock.Anything, idempotencyTTL, mock.Anything).
+		Return(nil, nil).Once()
+
+	response, err := useCase.Execute(ctx, idempotentRequest)
+
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStartIdempotentRequestUseCase_Execute_InvalidKey(t *testing.T) {
+	mockRepo := new(MockIdempotencyRepo)
+	useCase := usecase.NewStartIdempotentRequestUseCase(mockRepo, idempotencyTTL)
+
+	request := idempotentRequest
+	request.Key = strings.Repeat("k", 256)
+
+	_, err := useCase.Execute(contextWithRole(entities.RoleEditor), request)
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Acquire", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Ответ сохраняется под тем же ключом, под которым запрос его занял, а без ответа ключ освобождается
+func TestFinishIdempotentRequestUseCase_Execute(t *testing.T) {
+	ctx := contextWithRole(entities.RoleEditor)
+	key, _ := acquiredWith(t, ctx, idempotentRequest)
+
+	mockRepo := new(MockIdempotencyRepo)
+	useCase := usecase.NewFinishIdempotentRequestUseCase(mockRepo)
+
+	response := entities.IdempotentResponse{Status: 201, ContentType: "application/json", Body: []byte(`{"id":1}`)}
+	mockRepo.On("SaveResponse", ctx, key, response).Return(nil)
+	mockRepo.On("Delete", ctx, key).Return(nil)
+
+	assert.NoError(t, useCase.Execute(ctx, idempotentRequest, &response))
+	assert.NoError(t, useCase.Execute(ctx, idempotentRequest, nil))
+	mockRepo.AssertExpectations(t)
+}
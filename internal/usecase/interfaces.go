@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"em-library/internal/entities"
+	"time"
 )
 
 type TransactionManager interface {
@@ -13,10 +14,24 @@ type Repos struct {
 	TransactionManager TransactionManager
 	SongRepo           SongRepo
 	LyricsRepo         LyricsRepo
+	DraftRepo          SongDraftRepo
+	DuplicateRepo      DuplicateRepo
+	APIKeyRepo         APIKeyRepo
+	AuditRepo          AuditRepo
+	EventRepo          EventRepo
+	WebhookRepo        WebhookRepo
+	IdempotencyRepo    IdempotencyRepo
 }
 
 type Services struct {
 	SongInfoService SongInfoService
+	ContentFilter   ContentFilter
+	TokenVerifier   TokenVerifier
+	RateLimiter     RateLimiter
+	HealthChecks    []HealthCheck
+	ConfigSource    ConfigSource
+	WebhookSender   WebhookSender
+	EventBroker     EventBroker
 }
 
 type SongRepo interface {
@@ -29,10 +44,107 @@ type SongRepo interface {
 type LyricsRepo interface {
 	Create(ctx context.Context, data entities.NewLyricsData) error
 	Get(ctx context.Context, songID int) (entities.LyricsData, error)
+	GetList(ctx context.Context, songIDs []int) ([]entities.LyricsData, error)
 	Update(ctx context.Context, songID int, data entities.UpdateSongData) error
 	Delete(ctx context.Context, songID int) error
+	GetRevision(ctx context.Context, songID, revision int) (entities.LyricsRevisionData, error)
+}
+
+type SongDraftRepo interface {
+	Save(ctx context.Context, data entities.SongDraftData) error
+	GetList(ctx context.Context, filter entities.DraftFilterData) ([]entities.SongDraftData, error)
+	Delete(ctx context.Context, songID int) error
+}
+
+type DuplicateRepo interface {
+	GetSongsWithLyrics(ctx context.Context) ([]entities.SongWithLyricsData, error)
+	Replace(ctx context.Context, duplicates []entities.NewDuplicateData) error
+	GetList(ctx context.Context, filter entities.DuplicateFilterData) ([]entities.DuplicateData, error)
+}
+
+type AuditRepo interface {
+	Create(ctx context.Context, data entities.NewAuditRecordData) error
+	GetList(ctx context.Context, filter entities.AuditFilterData) ([]entities.AuditRecordData, error)
+}
+
+type EventRepo interface {
+	Create(ctx context.Context, events []entities.NewEventData) ([]entities.EventData, error)
+	GetList(ctx context.Context, filter entities.EventFilterData) ([]entities.EventData, error)
+	GetLastID(ctx context.Context) (int64, error)
+}
+
+type WebhookRepo interface {
+	Create(ctx context.Context, data entities.NewWebhookData) (entities.WebhookData, error)
+	Get(ctx context.Context, webhookID int) (entities.WebhookData, error)
+	GetList(ctx context.Context) ([]entities.WebhookData, error)
+	Update(ctx context.Context, webhookID int, data entities.UpdateWebhookData) (entities.WebhookData, error)
+	Delete(ctx context.Context, webhookID int) error
+	GetDeliveries(ctx context.Context, filter entities.WebhookDeliveryFilterData) ([]entities.WebhookDeliveryData, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entities.PendingWebhookDeliveryData, error)
+	SaveAttempt(ctx context.Context, deliveryID int64, data entities.WebhookAttemptData) error
+	Redeliver(ctx context.Context, deliveryID int64) error
+}
+
+type APIKeyRepo interface {
+	Create(ctx context.Context, data entities.NewAPIKeyData) (entities.APIKeyData, error)
+	MarkUsed(ctx context.Context, hash string) (entities.APIKeyData, error)
+	GetList(ctx context.Context) ([]entities.APIKeyData, error)
+	Revoke(ctx context.Context, keyID int) error
+}
+
+type IdempotencyRepo interface {
+	Acquire(ctx context.Context, key, fingerprint string, ttl, lockTimeout time.Duration) (*entities.IdempotencyKeyData, error)
+	SaveResponse(ctx context.Context, key string, response entities.IdempotentResponse) error
+	Delete(ctx context.Context, key string) error
 }
 
 type SongInfoService interface {
 	GetInfo(ctx context.Context, group, song string) (*entities.SongDetail, error)
 }
+
+type ContentFilter interface {
+	IsExplicit(text string) bool
+	Censor(text string) string
+}
+
+type TokenVerifier interface {
+	Verify(token string) (*entities.Principal, error)
+}
+
+type RateLimiter interface {
+	Take(ctx context.Context, key string, rule entities.RateLimitRule) (entities.RateLimitResult, error)
+}
+
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// Зависимость, которую проверяет readiness. Если некритичная зависимость
+// недоступна, сервис считается работающим с ограничениями.
+type HealthCheck struct {
+	Name     string
+	Checker  HealthChecker
+	Critical bool
+}
+
+type ConfigSource interface {
+	ConfigState() entities.ConfigState
+}
+
+// Отправляет событие подписчику. Возвращает HTTP-статус ответа,
+// ошибка означает, что доставка не удалась и её нужно повторить.
+type WebhookSender interface {
+	Send(ctx context.Context, delivery entities.PendingWebhookDeliveryData) (int, error)
+}
+
+// Рассылает зафиксированные события подписчикам внутри процесса
+type EventPublisher interface {
+	Publish(events []entities.EventData)
+}
+
+// Подписка на события, опубликованные в этом процессе. Канал закрывается, если подписчик
+// не успевает забирать события, или после вызова возвращённой функции отписки.
+type EventBroker interface {
+	EventPublisher
+	Subscribe() (<-chan entities.EventData, func())
+}
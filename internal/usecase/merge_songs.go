@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"errors"
+	"fmt"
+)
+
+type MergeSongsUseCase interface {
+	Execute(ctx context.Context, data entities.MergeSongsData) (*entities.SongData, error)
+}
+
+type mergeSongsUseCase struct {
+	transactionManager TransactionManager
+	songRepo           SongRepo
+	lyricsRepo         LyricsRepo
+	auditRepo          AuditRepo
+	eventRepo          EventRepo
+	eventPublisher     EventPublisher
+	contentFilter      ContentFilter
+}
+
+func NewMergeSongsUseCase(
+	tm TransactionManager,
+	sr SongRepo,
+	lr LyricsRepo,
+	ar AuditRepo,
+	er EventRepo,
+	ep EventPublisher,
+	cf ContentFilter,
+) MergeSongsUseCase {
+	return &mergeSongsUseCase{
+		transactionManager: tm,
+		songRepo:           sr,
+		lyricsRepo:         lr,
+		auditRepo:          ar,
+		eventRepo:          er,
+		eventPublisher:     ep,
+		contentFilter:      cf,
+	}
+}
+
+// Сливает песню MergeID в KeepID: пустые поля оставляемой песни заполняются
+// данными дубликата, после чего дубликат удаляется вместе с его текстом.
+// Удалять песни может только admin, поэтому и сливать их тоже.
+func (u *mergeSongsUseCase) Execute(ctx context.Context, data entities.MergeSongsData) (*entities.SongData, error) {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if data.KeepID == data.MergeID {
+		return nil, fmt.Errorf("%w song cannot be merged into itself", errs.ErrInvalidInput)
+	}
+
+	var merged entities.SongData
+	var events []entities.EventData
+
+	err := u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		keep, keepLyrics, err := u.getSong(ctx, data.KeepID)
+		if err != nil {
+			return err
+		}
+
+		duplicate, duplicateLyrics, err := u.getSong(ctx, data.MergeID)
+		if err != nil {
+			return err
+		}
+
+		keepBefore := songAuditData(keep, keepLyrics.Content)
+		duplicateBefore := songAuditData(duplicate, duplicateLyrics.Content)
+
+		var update entities.UpdateSongData
+		if keep.ReleaseDate.IsZero() && !duplicate.ReleaseDate.IsZero() {
+			update.ReleaseDate = &duplicate.ReleaseDate
+			keep.ReleaseDate = duplicate.ReleaseDate
+		}
+		if keep.Link == "" && duplicate.Link != "" {
+			update.Link = &duplicate.Link
+			keep.Link = duplicate.Link
+		}
+		takeLyrics := keepLyrics.Content == "" && duplicateLyrics.Content != ""
+		if takeLyrics {
+			explicit := u.contentFilter.IsExplicit(duplicateLyrics.Content)
+			update.Lyrics = &duplicateLyrics.Content
+			update.Explicit = &explicit
+			keep.Explicit = &explicit
+		}
+
+		// дубликат удаляем до обновления, чтобы каскадно удалились и ссылки на него
+		if err := u.lyricsRepo.Delete(ctx, duplicate.ID); err != nil {
+			return err
+		}
+		if err := u.songRepo.Delete(ctx, duplicate.ID); err != nil {
+			return err
+		}
+
+		if err := u.songRepo.Update(ctx, keep.ID, update); err != nil {
+			return err
+		}
+
+		if takeLyrics {
+			if err := u.saveLyrics(ctx, keep.ID, keepLyrics, update); err != nil {
+				return err
+			}
+		}
+
+		err = writeAudit(ctx, u.auditRepo, entities.AuditDelete, duplicate.ID, duplicateBefore, nil)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := writeEvents(ctx, u.eventRepo, duplicate.ID, &duplicateBefore, nil)
+		if err != nil {
+			return err
+		}
+
+		keepAfter := applySongUpdate(keepBefore, update)
+		err = writeAudit(ctx, u.auditRepo, entities.AuditMerge, keep.ID, keepBefore, keepAfter)
+		if err != nil {
+			return err
+		}
+
+		updated, err := writeEvents(ctx, u.eventRepo, keep.ID, &keepBefore, &keepAfter)
+		if err != nil {
+			return err
+		}
+
+		merged = keep
+		events = append(deleted, updated...)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	u.eventPublisher.Publish(events)
+
+	return &merged, nil
+}
+
+// Песня и её текст. Если текста нет, возвращается пустой LyricsData с нулевым SongID.
+func (u *mergeSongsUseCase) getSong(ctx context.Context, songID int) (entities.SongData, entities.LyricsData, error) {
+	songs, err := u.songRepo.GetList(ctx, entities.SongFilterData{ID: &songID})
+	if err != nil {
+		return entities.SongData{}, entities.LyricsData{}, err
+	}
+
+	lyrics, err := u.lyricsRepo.Get(ctx, songID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return entities.SongData{}, entities.LyricsData{}, err
+	}
+
+	return songs[0], lyrics, nil
+}
+
+func (u *mergeSongsUseCase) saveLyrics(
+	ctx context.Context,
+	songID int,
+	current entities.LyricsData,
+	update entities.UpdateSongData,
+) error {
+	if current.SongID != 0 {
+		return u.lyricsRepo.Update(ctx, songID, update)
+	}
+
+	return u.lyricsRepo.Create(ctx, entities.NewLyricsData{
+		SongID:  songID,
+		Content: *update.Lyrics,
+	})
+}
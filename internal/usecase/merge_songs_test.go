@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMergeSongsUseCase_Execute_FillsEmptyFields(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewMergeSongsUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+	keepID, mergeID := 1, 2
+	releaseDate := time.Date(1965, 8, 6, 0, 0, 0, 0, time.UTC)
+
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("GetList", ctx, entities.SongFilterData{ID: &keepID}).Return([]entities.SongData{
+		{ID: keepID, Band: "The Beatles", Song: "Yesterday", Link: "https://example.com/keep"},
+	}, nil)
+	mockSongRepo.On("GetList", ctx, entities.SongFilterData{ID: &mergeID}).Return([]entities.SongData{
+		{ID: mergeID, Band: "The Beatles", Song: "Yesterday (Remastered 2009)", ReleaseDate: releaseDate, Link: "https://example.com/merge"},
+	}, nil)
+	mockLyricsRepo.On("Get", ctx, keepID).Return(nil, errs.ErrNotFound)
+	mockLyricsRepo.On("Get", ctx, mergeID).Return(entities.LyricsData{SongID: mergeID, Content: "Yesterday"}, nil)
+	mockContentFilter.On("IsExplicit", "Yesterday").Return(false)
+	mockLyricsRepo.On("Delete", ctx, mergeID).Return(nil)
+	mockSongRepo.On("Delete", ctx, mergeID).Return(nil)
+	mockSongRepo.On("Update", ctx, keepID, mock.MatchedBy(func(data entities.UpdateSongData) bool {
+		return data.ReleaseDate.Equal(releaseDate) && data.Link == nil && *data.Lyrics == "Yesterday"
+	})).Return(nil)
+	mockLyricsRepo.On("Create", ctx, entities.NewLyricsData{SongID: keepID, Content: "Yesterday"}).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditDelete, mergeID)).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditMerge, keepID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(mergeID, entities.EventSongDeleted)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventRepo.On("Create", ctx, eventsOf(keepID, entities.EventSongUpdated, entities.EventLyricsUpdated)).
+		Return([]entities.EventData{{ID: 2}, {ID: 3}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}, {ID: 2}, {ID: 3}}).Once()
+
+	result, err := useCase.Execute(ctx, entities.MergeSongsData{KeepID: keepID, MergeID: mergeID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, keepID, result.ID)
+	assert.Equal(t, "Yesterday", result.Song)
+	assert.Equal(t, releaseDate, result.ReleaseDate)
+	assert.Equal(t, "https://example.com/keep", result.Link)
+
+	mockSongRepo.AssertExpectations(t)
+	mockLyricsRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockEventBroker.AssertExpectations(t)
+}
+
+func TestMergeSongsUseCase_Execute_SameSong(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewMergeSongsUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	result, err := useCase.Execute(contextWithRole(entities.RoleAdmin), entities.MergeSongsData{KeepID: 1, MergeID: 1})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+	assert.Nil(t, result)
+	mockTM.AssertNotCalled(t, "Do")
+}
+
+func TestMergeSongsUseCase_Execute_DuplicateNotFound(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewMergeSongsUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+	keepID, mergeID := 1, 2
+
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(errs.ErrNotFound)
+	mockSongRepo.On("GetList", ctx, entities.SongFilterData{ID: &keepID}).Return([]entities.SongData{{ID: keepID}}, nil)
+	mockLyricsRepo.On("Get", ctx, keepID).Return(entities.LyricsData{SongID: keepID, Content: "Text"}, nil)
+	mockSongRepo.On("GetList", ctx, entities.SongFilterData{ID: &mergeID}).Return(nil, errs.ErrNotFound)
+
+	result, err := useCase.Execute(ctx, entities.MergeSongsData{KeepID: keepID, MergeID: mergeID})
+
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.Nil(t, result)
+	mockSongRepo.AssertNotCalled(t, "Delete")
+}
+
+// Слияние удаляет дубликат, поэтому требует роли admin
+func TestMergeSongsUseCase_Execute_EditorForbidden(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewMergeSongsUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	result, err := useCase.Execute(contextWithRole(entities.RoleEditor), entities.MergeSongsData{KeepID: 1, MergeID: 2})
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	assert.Nil(t, result)
+	mockTM.AssertNotCalled(t, "Do")
+	mockSongRepo.AssertNotCalled(t, "Delete")
+}
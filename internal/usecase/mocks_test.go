@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"em-library/internal/entities"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -67,6 +68,14 @@ func (m *MockLyricsRepo) Get(ctx context.Context, songID int) (entities.LyricsDa
 	return args.Get(0).(entities.LyricsData), args.Error(1)
 }
 
+func (m *MockLyricsRepo) GetList(ctx context.Context, songIDs []int) ([]entities.LyricsData, error) {
+	args := m.Called(ctx, songIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.LyricsData), args.Error(1)
+}
+
 func (m *MockLyricsRepo) Update(ctx context.Context, songID int, data entities.UpdateSongData) error {
 	args := m.Called(ctx, songID, data)
 	return args.Error(0)
@@ -77,6 +86,16 @@ func (m *MockLyricsRepo) Delete(ctx context.Context, songID int) error {
 	return args.Error(0)
 }
 
+func (m *MockLyricsRepo) GetRevision(ctx context.Context, songID, revision int) (entities.LyricsRevisionData, error) {
+	args := m.Called(ctx, songID, revision)
+
+	if args.Get(0) == nil {
+		return entities.LyricsRevisionData{}, args.Error(1)
+	}
+
+	return args.Get(0).(entities.LyricsRevisionData), args.Error(1)
+}
+
 type MockSongInfoService struct {
 	mock.Mock
 }
@@ -88,3 +107,332 @@ func (m *MockSongInfoService) GetInfo(ctx context.Context, group, song string) (
 	}
 	return args.Get(0).(*entities.SongDetail), args.Error(1)
 }
+
+type MockContentFilter struct {
+	mock.Mock
+}
+
+func (m *MockContentFilter) IsExplicit(text string) bool {
+	args := m.Called(text)
+	return args.Bool(0)
+}
+
+func (m *MockContentFilter) Censor(text string) string {
+	args := m.Called(text)
+	return args.String(0)
+}
+
+type MockSongDraftRepo struct {
+	mock.Mock
+}
+
+func (m *MockSongDraftRepo) Save(ctx context.Context, data entities.SongDraftData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockSongDraftRepo) GetList(ctx context.Context, filter entities.DraftFilterData) ([]entities.SongDraftData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.SongDraftData), args.Error(1)
+}
+
+func (m *MockSongDraftRepo) Delete(ctx context.Context, songID int) error {
+	args := m.Called(ctx, songID)
+	return args.Error(0)
+}
+
+type MockRefreshSongUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRefreshSongUseCase) Execute(ctx context.Context, songID int, policy entities.RefreshPolicy) (*entities.RefreshResultData, error) {
+	args := m.Called(ctx, songID, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RefreshResultData), args.Error(1)
+}
+
+type MockUpdateSongUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUpdateSongUseCase) Execute(ctx context.Context, songID int, data entities.UpdateSongData) error {
+	args := m.Called(ctx, songID, data)
+	return args.Error(0)
+}
+
+type MockDuplicateRepo struct {
+	mock.Mock
+}
+
+func (m *MockDuplicateRepo) GetSongsWithLyrics(ctx context.Context) ([]entities.SongWithLyricsData, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.SongWithLyricsData), args.Error(1)
+}
+
+func (m *MockDuplicateRepo) Replace(ctx context.Context, duplicates []entities.NewDuplicateData) error {
+	args := m.Called(ctx, duplicates)
+	return args.Error(0)
+}
+
+func (m *MockDuplicateRepo) GetList(ctx context.Context, filter entities.DuplicateFilterData) ([]entities.DuplicateData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.DuplicateData), args.Error(1)
+}
+
+type MockAPIKeyRepo struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepo) Create(ctx context.Context, data entities.NewAPIKeyData) (entities.APIKeyData, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(entities.APIKeyData), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) MarkUsed(ctx context.Context, hash string) (entities.APIKeyData, error) {
+	args := m.Called(ctx, hash)
+	return args.Get(0).(entities.APIKeyData), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) GetList(ctx context.Context) ([]entities.APIKeyData, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.APIKeyData), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) Revoke(ctx context.Context, keyID int) error {
+	args := m.Called(ctx, keyID)
+	return args.Error(0)
+}
+
+type MockIdempotencyRepo struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepo) Acquire(
+	ctx context.Context,
+	key, fingerprint string,
+	ttl, lockTimeout time.Duration,
+) (*entities.IdempotencyKeyData, error) {
+	args := m.Called(ctx, key, fingerprint, ttl, lockTimeout)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.IdempotencyKeyData), args.Error(1)
+}
+
+func (m *MockIdempotencyRepo) SaveResponse(ctx context.Context, key string, response entities.IdempotentResponse) error {
+	args := m.Called(ctx, key, response)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepo) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+type MockTokenVerifier struct {
+	mock.Mock
+}
+
+func (m *MockTokenVerifier) Verify(token string) (*entities.Principal, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Principal), args.Error(1)
+}
+
+// Контекст с клиентом заданной роли: юзкейсы проверяют права по нему
+func contextWithRole(role entities.Role) context.Context {
+	return entities.ContextWithPrincipal(context.Background(), &entities.Principal{Subject: "test", Role: role})
+}
+
+type MockAuditRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepo) Create(ctx context.Context, data entities.NewAuditRecordData) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepo) GetList(ctx context.Context, filter entities.AuditFilterData) ([]entities.AuditRecordData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.AuditRecordData), args.Error(1)
+}
+
+// Состояние песни до изменения, которое юзкейс читает для журнала аудита
+func expectSongAuditState(ctx context.Context, sr *MockSongRepo, lr *MockLyricsRepo, songID int) {
+	sr.On("GetList", ctx, entities.SongFilterData{ID: &songID}).
+		Return([]entities.SongData{{ID: songID, Band: "Test Group", Song: "Test Song"}}, nil)
+	lr.On("Get", ctx, songID).Return(entities.LyricsData{SongID: songID, Content: "Test lyrics"}, nil)
+}
+
+func auditRecordWith(action entities.AuditAction, songID int) any {
+	return mock.MatchedBy(func(data entities.NewAuditRecordData) bool {
+		return data.Action == action && data.Entity == entities.AuditEntitySong && data.EntityID == songID
+	})
+}
+
+type MockRateLimiter struct {
+	mock.Mock
+}
+
+func (m *MockRateLimiter) Take(ctx context.Context, key string, rule entities.RateLimitRule) (entities.RateLimitResult, error) {
+	args := m.Called(ctx, key, rule)
+	return args.Get(0).(entities.RateLimitResult), args.Error(1)
+}
+
+type MockHealthChecker struct {
+	mock.Mock
+}
+
+func (m *MockHealthChecker) CheckHealth(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockConfigSource struct {
+	mock.Mock
+}
+
+func (m *MockConfigSource) ConfigState() entities.ConfigState {
+	args := m.Called()
+	return args.Get(0).(entities.ConfigState)
+}
+
+type MockEventRepo struct {
+	mock.Mock
+}
+
+func (m *MockEventRepo) Create(ctx context.Context, events []entities.NewEventData) ([]entities.EventData, error) {
+	args := m.Called(ctx, events)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.EventData), args.Error(1)
+}
+
+func (m *MockEventRepo) GetList(ctx context.Context, filter entities.EventFilterData) ([]entities.EventData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.EventData), args.Error(1)
+}
+
+func (m *MockEventRepo) GetLastID(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockEventBroker struct {
+	mock.Mock
+}
+
+func (m *MockEventBroker) Publish(events []entities.EventData) {
+	m.Called(events)
+}
+
+func (m *MockEventBroker) Subscribe() (<-chan entities.EventData, func()) {
+	args := m.Called()
+	return args.Get(0).(chan entities.EventData), args.Get(1).(func())
+}
+
+// События для песни songID ровно указанных типов в указанном порядке
+func eventsOf(songID int, types ...entities.EventType) any {
+	return mock.MatchedBy(func(events []entities.NewEventData) bool {
+		if len(events) != len(types) {
+			return false
+		}
+		for i, e := range events {
+			if e.SongID != songID || e.Type != types[i] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+type MockWebhookRepo struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepo) Create(ctx context.Context, data entities.NewWebhookData) (entities.WebhookData, error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(entities.WebhookData), args.Error(1)
+}
+
+func (m *MockWebhookRepo) Get(ctx context.Context, webhookID int) (entities.WebhookData, error) {
+	args := m.Called(ctx, webhookID)
+	return args.Get(0).(entities.WebhookData), args.Error(1)
+}
+
+func (m *MockWebhookRepo) GetList(ctx context.Context) ([]entities.WebhookData, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.WebhookData), args.Error(1)
+}
+
+func (m *MockWebhookRepo) Update(ctx context.Context, webhookID int, data entities.UpdateWebhookData) (entities.WebhookData, error) {
+	args := m.Called(ctx, webhookID, data)
+	return args.Get(0).(entities.WebhookData), args.Error(1)
+}
+
+func (m *MockWebhookRepo) Delete(ctx context.Context, webhookID int) error {
+	args := m.Called(ctx, webhookID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) GetDeliveries(ctx context.Context, filter entities.WebhookDeliveryFilterData) ([]entities.WebhookDeliveryData, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.WebhookDeliveryData), args.Error(1)
+}
+
+func (m *MockWebhookRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entities.PendingWebhookDeliveryData, error) {
+	args := m.Called(ctx, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.PendingWebhookDeliveryData), args.Error(1)
+}
+
+func (m *MockWebhookRepo) SaveAttempt(ctx context.Context, deliveryID int64, data entities.WebhookAttemptData) error {
+	args := m.Called(ctx, deliveryID, data)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) Redeliver(ctx context.Context, deliveryID int64) error {
+	args := m.Called(ctx, deliveryID)
+	return args.Error(0)
+}
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, delivery entities.PendingWebhookDeliveryData) (int, error) {
+	args := m.Called(ctx, delivery)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"errors"
+	"time"
+)
+
+type RefreshSongUseCase interface {
+	Execute(
+		ctx context.Context,
+		songID int,
+		policy entities.RefreshPolicy,
+	) (*entities.RefreshResultData, error)
+}
+
+type refreshSongUseCase struct {
+	transactionManager TransactionManager
+	songRepo           SongRepo
+	lyricsRepo         LyricsRepo
+	draftRepo          SongDraftRepo
+	auditRepo          AuditRepo
+	eventRepo          EventRepo
+	eventPublisher     EventPublisher
+	songInfoService    SongInfoService
+	contentFilter      ContentFilter
+	defaultPolicy      entities.RefreshPolicy
+}
+
+func NewRefreshSongUseCase(
+	tm TransactionManager,
+	sr SongRepo,
+	lr LyricsRepo,
+	dr SongDraftRepo,
+	ar AuditRepo,
+	er EventRepo,
+	ep EventPublisher,
+	s SongInfoService,
+	cf ContentFilter,
+	defaultPolicy entities.RefreshPolicy,
+) RefreshSongUseCase {
+	return &refreshSongUseCase{
+		transactionManager: tm,
+		songRepo:           sr,
+		lyricsRepo:         lr,
+		draftRepo:          dr,
+		auditRepo:          ar,
+		eventRepo:          er,
+		eventPublisher:     ep,
+		songInfoService:    s,
+		contentFilter:      cf,
+		defaultPolicy:      defaultPolicy,
+	}
+}
+
+func (u *refreshSongUseCase) Execute(
+	ctx context.Context,
+	songID int,
+	policy entities.RefreshPolicy,
+) (*entities.RefreshResultData, error) {
+
+	if err := authorize(ctx, entities.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	if policy == "" {
+		policy = u.defaultPolicy
+	}
+
+	songs, err := u.songRepo.GetList(ctx, entities.SongFilterData{ID: &songID})
+	if err != nil {
+		return nil, err
+	}
+	song := songs[0]
+
+	// у песни может не быть текста, тогда при обновлении его нужно создать
+	hasLyrics := true
+	lyrics, err := u.lyricsRepo.Get(ctx, songID)
+	if err != nil {
+		if !errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+		hasLyrics = false
+	}
+
+	refreshedAt := time.Now()
+
+	info, err := u.songInfoService.GetInfo(ctx, song.Band, song.Song)
+	if err != nil {
+		// неудачная попытка тоже откладывает песню до следующего срока,
+		// иначе фоновое обновление раз за разом выбирало бы одни и те же песни
+		if stampErr := u.songRepo.Update(ctx, songID, entities.UpdateSongData{RefreshedAt: &refreshedAt}); stampErr != nil {
+			return nil, errors.Join(err, stampErr)
+		}
+		return nil, err
+	}
+
+	update, changes := mergeSongDetail(song, lyrics.Content, info, policy)
+	var events []entities.EventData
+
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		if policy == entities.RefreshDraft {
+			if len(changes) > 0 {
+				err := u.draftRepo.Save(ctx, entities.SongDraftData{
+					SongID:      songID,
+					ReleaseDate: update.ReleaseDate,
+					Link:        update.Link,
+					Lyrics:      update.Lyrics,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			return u.songRepo.Update(ctx, songID, entities.UpdateSongData{RefreshedAt: &refreshedAt})
+		}
+
+		if update.Lyrics != nil {
+			explicit := u.contentFilter.IsExplicit(*update.Lyrics)
+			update.Explicit = &explicit
+		}
+		update.RefreshedAt = &refreshedAt
+
+		if err := u.songRepo.Update(ctx, songID, update); err != nil {
+			return err
+		}
+
+		if update.Lyrics != nil {
+			if err := u.saveLyrics(ctx, songID, hasLyrics, update); err != nil {
+				return err
+			}
+		}
+
+		if len(changes) == 0 {
+			return nil
+		}
+
+		before := songAuditData(song, lyrics.Content)
+		after := applySongUpdate(before, update)
+		if err := writeAudit(ctx, u.auditRepo, entities.AuditRefresh, songID, before, after); err != nil {
+			return err
+		}
+
+		events, err = writeEvents(ctx, u.eventRepo, songID, &before, &after)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	u.eventPublisher.Publish(events)
+
+	return &entities.RefreshResultData{
+		SongID:  songID,
+		Policy:  policy,
+		Applied: policy != entities.RefreshDraft && len(changes) > 0,
+		Changes: changes,
+	}, nil
+}
+
+func (u *refreshSongUseCase) saveLyrics(
+	ctx context.Context,
+	songID int,
+	hasLyrics bool,
+	update entities.UpdateSongData,
+) error {
+	if hasLyrics {
+		return u.lyricsRepo.Update(ctx, songID, update)
+	}
+
+	return u.lyricsRepo.Create(ctx, entities.NewLyricsData{
+		SongID:  songID,
+		Content: *update.Lyrics,
+	})
+}
+
+// Сравнивает сохранённые данные с полученными из внешнего сервиса и по политике
+// решает, какие поля менять. Для черновика берутся все отличающиеся поля.
+func mergeSongDetail(
+	song entities.SongData,
+	lyrics string,
+	info *entities.SongDetail,
+	policy entities.RefreshPolicy,
+) (entities.UpdateSongData, []entities.FieldChangeData) {
+
+	var update entities.UpdateSongData
+	changes := []entities.FieldChangeData{}
+
+	shouldChange := func(current, upstream string) bool {
+		if upstream == "" || current == upstream {
+			return false
+		}
+		return policy != entities.RefreshFillEmpty || current == ""
+	}
+
+	currentDate := formatDate(song.ReleaseDate)
+	upstreamDate := formatDate(info.ReleaseDate)
+	if shouldChange(currentDate, upstreamDate) {
+		releaseDate := info.ReleaseDate
+		update.ReleaseDate = &releaseDate
+		changes = append(changes, entities.FieldChangeData{Field: "release_date", Old: currentDate, New: upstreamDate})
+	}
+
+	if shouldChange(song.Link, info.Link) {
+		link := info.Link
+		update.Link = &link
+		changes = append(changes, entities.FieldChangeData{Field: "link", Old: song.Link, New: info.Link})
+	}
+
+	if shouldChange(lyrics, info.Lyrics) {
+		content := info.Lyrics
+		update.Lyrics = &content
+		changes = append(changes, entities.FieldChangeData{Field: "lyrics", Old: lyrics, New: info.Lyrics})
+	}
+
+	return update, changes
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
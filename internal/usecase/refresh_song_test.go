@@ -0,0 +1,203 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type refreshMocks struct {
+	tm            *MockTransactionManager
+	songRepo      *MockSongRepo
+	lyricsRepo    *MockLyricsRepo
+	draftRepo     *MockSongDraftRepo
+	auditRepo     *MockAuditRepo
+	eventRepo     *MockEventRepo
+	eventBroker   *MockEventBroker
+	infoService   *MockSongInfoService
+	contentFilter *MockContentFilter
+}
+
+func setupRefreshSong(defaultPolicy entities.RefreshPolicy) (usecase.RefreshSongUseCase, refreshMocks) {
+	m := refreshMocks{
+		tm:            new(MockTransactionManager),
+		songRepo:      new(MockSongRepo),
+		lyricsRepo:    new(MockLyricsRepo),
+		draftRepo:     new(MockSongDraftRepo),
+		auditRepo:     new(MockAuditRepo),
+		eventRepo:     new(MockEventRepo),
+		eventBroker:   new(MockEventBroker),
+		infoService:   new(MockSongInfoService),
+		contentFilter: new(MockContentFilter),
+	}
+	useCase := usecase.NewRefreshSongUseCase(m.tm, m.songRepo, m.lyricsRepo, m.draftRepo, m.auditRepo, m.eventRepo, m.eventBroker, m.infoService, m.contentFilter, defaultPolicy)
+	return useCase, m
+}
+
+var (
+	refreshSongID   = 123
+	storedSong      = entities.SongData{ID: 123, Band: "Test Group", Song: "Test Song", Link: "https://old.example.com"}
+	upstreamRelease = time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	upstreamDetail  = &entities.SongDetail{
+		ReleaseDate: upstreamRelease,
+		Link:        "https://new.example.com",
+		Lyrics:      "New lyrics",
+	}
+)
+
+func TestRefreshSongUseCase_Execute_Overwrite(t *testing.T) {
+	useCase, m := setupRefreshSong(entities.RefreshFillEmpty)
+	ctx := contextWithRole(entities.RoleEditor)
+
+	m.songRepo.On("GetList", ctx, entities.SongFilterData{ID: &refreshSongID}).Return([]entities.SongData{storedSong}, nil)
+	m.lyricsRepo.On("Get", ctx, refreshSongID).Return(entities.LyricsData{SongID: refreshSongID, Content: "Old lyrics"}, nil)
+	m.infoService.On("GetInfo", ctx, "Test Group", "Test Song").Return(upstreamDetail, nil)
+	m.contentFilter.On("IsExplicit", "New lyrics").Return(false)
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	m.songRepo.On("Update", ctx, refreshSongID, mock.MatchedBy(func(data entities.UpdateSongData) bool {
+		return data.ReleaseDate.Equal(upstreamRelease) &&
+			*data.Link == "https://new.example.com" &&
+			*data.Lyrics == "New lyrics" &&
+			data.RefreshedAt != nil
+	})).Return(nil)
+	m.lyricsRepo.On("Update", ctx, refreshSongID, mock.Anything).Return(nil)
+	m.auditRepo.On("Create", ctx, auditRecordWith(entities.AuditRefresh, refreshSongID)).Return(nil)
+	m.eventRepo.On("Create", ctx, eventsOf(refreshSongID, entities.EventSongUpdated, entities.EventLyricsUpdated)).Return([]entities.EventData{{ID: 1}, {ID: 2}}, nil)
+	m.eventBroker.On("Publish", []entities.EventData{{ID: 1}, {ID: 2}}).Once()
+
+	result, err := useCase.Execute(ctx, refreshSongID, entities.RefreshOverwrite)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Applied)
+	assert.Equal(t, entities.RefreshOverwrite, result.Policy)
+	assert.Equal(t, []entities.FieldChangeData{
+		{Field: "release_date", Old: "", New: "2006-07-16"},
+		{Field: "link", Old: "https://old.example.com", New: "https://new.example.com"},
+		{Field: "lyrics", Old: "Old lyrics", New: "New lyrics"},
+	}, result.Changes)
+
+	m.songRepo.AssertExpectations(t)
+	m.lyricsRepo.AssertExpectations(t)
+	m.auditRepo.AssertExpectations(t)
+	m.eventRepo.AssertExpectations(t)
+	m.eventBroker.AssertExpectations(t)
+	m.draftRepo.AssertNotCalled(t, "Save")
+}
+
+func TestRefreshSongUseCase_Execute_FillEmptyByDefault(t *testing.T) {
+	useCase, m := setupRefreshSong(entities.RefreshFillEmpty)
+	ctx := contextWithRole(entities.RoleEditor)
+
+	m.songRepo.On("GetList", ctx, entities.SongFilterData{ID: &refreshSongID}).Return([]entities.SongData{storedSong}, nil)
+	m.lyricsRepo.On("Get", ctx, refreshSongID).Return(nil, errs.ErrNotFound)
+	m.infoService.On("GetInfo", ctx, "Test Group", "Test Song").Return(upstreamDetail, nil)
+	m.contentFilter.On("IsExplicit", "New lyrics").Return(false)
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	m.songRepo.On("Update", ctx, refreshSongID, mock.MatchedBy(func(data entities.UpdateSongData) bool {
+		// ссылка уже заполнена и не должна меняться
+		return data.Link == nil && data.ReleaseDate != nil && data.Lyrics != nil
+	})).Return(nil)
+	m.lyricsRepo.On("Create", ctx, entities.NewLyricsData{SongID: refreshSongID, Content: "New lyrics"}).Return(nil)
+	m.auditRepo.On("Create", ctx, auditRecordWith(entities.AuditRefresh, refreshSongID)).Return(nil)
+	m.eventRepo.On("Create", ctx, eventsOf(refreshSongID, entities.EventSongUpdated, entities.EventLyricsUpdated)).Return([]entities.EventData{{ID: 1}, {ID: 2}}, nil)
+	m.eventBroker.On("Publish", []entities.EventData{{ID: 1}, {ID: 2}}).Once()
+
+	result, err := useCase.Execute(ctx, refreshSongID, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.RefreshFillEmpty, result.Policy)
+	assert.Len(t, result.Changes, 2)
+	assert.Equal(t, "release_date", result.Changes[0].Field)
+	assert.Equal(t, "lyrics", result.Changes[1].Field)
+
+	m.songRepo.AssertExpectations(t)
+	m.lyricsRepo.AssertExpectations(t)
+	m.lyricsRepo.AssertNotCalled(t, "Update")
+}
+
+func TestRefreshSongUseCase_Execute_Draft(t *testing.T) {
+	useCase, m := setupRefreshSong(entities.RefreshFillEmpty)
+	ctx := contextWithRole(entities.RoleEditor)
+
+	m.songRepo.On("GetList", ctx, entities.SongFilterData{ID: &refreshSongID}).Return([]entities.SongData{storedSong}, nil)
+	m.lyricsRepo.On("Get", ctx, refreshSongID).Return(entities.LyricsData{SongID: refreshSongID, Content: "New lyrics"}, nil)
+	m.infoService.On("GetInfo", ctx, "Test Group", "Test Song").Return(upstreamDetail, nil)
+	m.tm.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	m.draftRepo.On("Save", ctx, mock.MatchedBy(func(data entities.SongDraftData) bool {
+		return data.SongID == refreshSongID && *data.Link == "https://new.example.com" && data.Lyrics == nil
+	})).Return(nil)
+	m.songRepo.On("Update", ctx, refreshSongID, mock.MatchedBy(func(data entities.UpdateSongData) bool {
+		return data.RefreshedAt != nil && data.Link == nil && data.ReleaseDate == nil
+	})).Return(nil)
+	m.eventBroker.On("Publish", []entities.EventData(nil)).Once()
+
+	result, err := useCase.Execute(ctx, refreshSongID, entities.RefreshDraft)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Applied)
+	assert.Len(t, result.Changes, 2)
+
+	m.draftRepo.AssertExpectations(t)
+	m.songRepo.AssertExpectations(t)
+	m.lyricsRepo.AssertNotCalled(t, "Update")
+	m.auditRepo.AssertNotCalled(t, "Create")
+	m.eventRepo.AssertNotCalled(t, "Create")
+	m.contentFilter.AssertNotCalled(t, "IsExplicit")
+}
+
+func TestRefreshSongUseCase_Execute_InfoServiceError(t *testing.T) {
+	useCase, m := setupRefreshSong(entities.RefreshOverwrite)
+	ctx := contextWithRole(entities.RoleEditor)
+
+	expectedError := errs.ErrServiceProblem{Err: errors.New("timeout")}
+
+	m.songRepo.On("GetList", ctx, entities.SongFilterData{ID: &refreshSongID}).Return([]entities.SongData{storedSong}, nil)
+	m.lyricsRepo.On("Get", ctx, refreshSongID).Return(entities.LyricsData{SongID: refreshSongID, Content: "Old lyrics"}, nil)
+	m.infoService.On("GetInfo", ctx, "Test Group", "Test Song").Return(nil, expectedError)
+	m.songRepo.On("Update", ctx, refreshSongID, mock.MatchedBy(func(data entities.UpdateSongData) bool {
+		return data.RefreshedAt != nil && data.Band == nil && data.Link == nil && data.ReleaseDate == nil
+	})).Return(nil).Once()
+
+	result, err := useCase.Execute(ctx, refreshSongID, "")
+
+	assert.ErrorIs(t, err, errs.ErrServiceProblem{})
+	assert.Nil(t, result)
+	m.tm.AssertNotCalled(t, "Do")
+	// песня откладывается до следующего срока, чтобы фоновое обновление не застревало на ней
+	m.songRepo.AssertExpectations(t)
+}
+
+func TestRefreshSongsUseCase_Execute_CollectsErrors(t *testing.T) {
+	mockSongRepo := new(MockSongRepo)
+	mockRefreshSong := new(MockRefreshSongUseCase)
+	useCase := usecase.NewRefreshSongsUseCase(mockSongRepo, mockRefreshSong)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	limit := 50
+	offset := 0
+
+	mockSongRepo.On("GetList", ctx, entities.SongFilterData{Limit: &limit, Offset: &offset}).Return([]entities.SongData{
+		{ID: 1}, {ID: 2},
+	}, nil)
+	mockRefreshSong.On("Execute", ctx, 1, entities.RefreshOverwrite).Return(&entities.RefreshResultData{
+		SongID: 1, Policy: entities.RefreshOverwrite, Changes: []entities.FieldChangeData{},
+	}, nil)
+	mockRefreshSong.On("Execute", ctx, 2, entities.RefreshOverwrite).Return(nil, errors.New("refresh failed"))
+
+	results, err := useCase.Execute(ctx, entities.SongFilterData{}, entities.RefreshOverwrite)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, 2, results[1].SongID)
+	assert.Equal(t, "refresh failed", results[1].Error)
+
+	mockSongRepo.AssertExpectations(t)
+	mockRefreshSong.AssertExpectations(t)
+}
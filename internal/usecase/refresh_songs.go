@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+)
+
+type RefreshSongsUseCase interface {
+	Execute(
+		ctx context.Context,
+		filter entities.SongFilterData,
+		policy entities.RefreshPolicy,
+	) ([]entities.RefreshResultData, error)
+}
+
+type refreshSongsUseCase struct {
+	songRepo    SongRepo
+	refreshSong RefreshSongUseCase
+}
+
+func NewRefreshSongsUseCase(sr SongRepo, rs RefreshSongUseCase) RefreshSongsUseCase {
+	return &refreshSongsUseCase{
+		songRepo:    sr,
+		refreshSong: rs,
+	}
+}
+
+// Обновляет все песни, подходящие под фильтр. Ошибка обновления одной песни
+// не прерывает обработку остальных и попадает в её результат.
+func (u *refreshSongsUseCase) Execute(
+	ctx context.Context,
+	filter entities.SongFilterData,
+	policy entities.RefreshPolicy,
+) ([]entities.RefreshResultData, error) {
+
+	if err := authorize(ctx, entities.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == nil {
+		limit := 50
+		filter.Limit = &limit
+	}
+
+	if filter.Offset == nil {
+		offset := 0
+		filter.Offset = &offset
+	}
+
+	songs, err := u.songRepo.GetList(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]entities.RefreshResultData, 0, len(songs))
+	for _, song := range songs {
+		result, err := u.refreshSong.Execute(ctx, song.ID, policy)
+		if err != nil {
+			results = append(results, entities.RefreshResultData{
+				SongID:  song.ID,
+				Policy:  policy,
+				Changes: []entities.FieldChangeData{},
+				Error:   err.Error(),
+			})
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+)
+
+type ReindexSongsUseCase interface {
+	Execute(ctx context.Context) (int, error)
+}
+
+type reindexSongsUseCase struct {
+	duplicateRepo DuplicateRepo
+	contentFilter ContentFilter
+	updateSong    UpdateSongUseCase
+}
+
+func NewReindexSongsUseCase(dr DuplicateRepo, cf ContentFilter, us UpdateSongUseCase) ReindexSongsUseCase {
+	return &reindexSongsUseCase{
+		duplicateRepo: dr,
+		contentFilter: cf,
+		updateSong:    us,
+	}
+}
+
+// Заново проверяет тексты всех песен по спискам нецензурных слов, например после
+// их изменения, и исправляет флаг explicit. Заполняет его и у песен, сохранённых до
+// появления проверки. Каждое исправление попадает в журнал аудита.
+// Возвращает количество исправленных песен.
+func (u *reindexSongsUseCase) Execute(ctx context.Context) (int, error) {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return 0, err
+	}
+
+	songs, err := u.duplicateRepo.GetSongsWithLyrics(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var updated int
+	for _, song := range songs {
+		explicit := u.contentFilter.IsExplicit(song.Lyrics)
+		if song.Explicit != nil && explicit == *song.Explicit {
+			continue
+		}
+
+		if err := u.updateSong.Execute(ctx, song.ID, entities.UpdateSongData{Explicit: &explicit}); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,69 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReindexSongsUseCase_Execute_FixesExplicit(t *testing.T) {
+	mockDuplicateRepo := new(MockDuplicateRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockUpdateSong := new(MockUpdateSongUseCase)
+	useCase := usecase.NewReindexSongsUseCase(mockDuplicateRepo, mockContentFilter, mockUpdateSong)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+	explicit, clean := true, false
+
+	mockDuplicateRepo.On("GetSongsWithLyrics", ctx).Return([]entities.SongWithLyricsData{
+		{SongData: entities.SongData{ID: 1, Explicit: &clean}, Lyrics: "clean"},
+		{SongData: entities.SongData{ID: 2, Explicit: &clean}, Lyrics: "dirty"},
+	}, nil)
+	mockContentFilter.On("IsExplicit", "clean").Return(false)
+	mockContentFilter.On("IsExplicit", "dirty").Return(true)
+	mockUpdateSong.On("Execute", ctx, 2, entities.UpdateSongData{Explicit: &explicit}).Return(nil)
+
+	updated, err := useCase.Execute(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	mockUpdateSong.AssertNumberOfCalls(t, "Execute", 1)
+	mockUpdateSong.AssertExpectations(t)
+}
+
+// У песен, сохранённых до появления проверки, флаг заполняется, даже если текст чистый
+func TestReindexSongsUseCase_Execute_FillsUnchecked(t *testing.T) {
+	mockDuplicateRepo := new(MockDuplicateRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockUpdateSong := new(MockUpdateSongUseCase)
+	useCase := usecase.NewReindexSongsUseCase(mockDuplicateRepo, mockContentFilter, mockUpdateSong)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+	clean := false
+
+	mockDuplicateRepo.On("GetSongsWithLyrics", ctx).Return([]entities.SongWithLyricsData{
+		{SongData: entities.SongData{ID: 1}, Lyrics: "clean"},
+	}, nil)
+	mockContentFilter.On("IsExplicit", "clean").Return(false)
+	mockUpdateSong.On("Execute", ctx, 1, entities.UpdateSongData{Explicit: &clean}).Return(nil)
+
+	updated, err := useCase.Execute(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	mockUpdateSong.AssertExpectations(t)
+}
+
+func TestReindexSongsUseCase_Execute_Forbidden(t *testing.T) {
+	mockDuplicateRepo := new(MockDuplicateRepo)
+	useCase := usecase.NewReindexSongsUseCase(mockDuplicateRepo, new(MockContentFilter), new(MockUpdateSongUseCase))
+
+	updated, err := useCase.Execute(contextWithRole(entities.RoleEditor))
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	assert.Zero(t, updated)
+	mockDuplicateRepo.AssertNotCalled(t, "GetSongsWithLyrics")
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+)
+
+// сколько событий читать из outbox за раз, догоняя поток
+const streamCatchUpLimit = 500
+
+type StreamEventsUseCase interface {
+	Execute(ctx context.Context, filter entities.EventStreamFilterData) (*entities.EventStream, error)
+}
+
+type streamEventsUseCase struct {
+	eventRepo   EventRepo
+	eventBroker EventBroker
+}
+
+func NewStreamEventsUseCase(er EventRepo, eb EventBroker) StreamEventsUseCase {
+	return &streamEventsUseCase{
+		eventRepo:   er,
+		eventBroker: eb,
+	}
+}
+
+// Поток событий, подходящих под фильтр, в порядке номеров. С LastEventID поток сначала
+// догоняет пропущенные события из outbox, затем передаёт новые по мере публикации.
+// Поток завершается с ошибкой errs.ErrLagged, если клиент не успевает забирать события,
+// тогда клиент может переподключиться с номером последнего полученного события.
+func (u *streamEventsUseCase) Execute(ctx context.Context, filter entities.EventStreamFilterData) (*entities.EventStream, error) {
+	if err := authorize(ctx, entities.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	// подписываемся до чтения outbox, чтобы не потерять события, опубликованные между ними
+	live, unsubscribe := u.eventBroker.Subscribe()
+
+	var last int64
+	if filter.LastEventID != nil {
+		last = *filter.LastEventID
+	} else {
+		id, err := u.eventRepo.GetLastID(ctx)
+		if err != nil {
+			unsubscribe()
+			return nil, err
+		}
+		last = id
+	}
+
+	events := make(chan entities.EventData)
+
+	var streamErr error
+	stream := &entities.EventStream{
+		Events: events,
+		Err:    func() error { return streamErr },
+	}
+
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+
+		streamErr = u.stream(ctx, filter, last, live, events)
+		if ctx.Err() != nil {
+			streamErr = nil
+		}
+	}()
+
+	return stream, nil
+}
+
+func (u *streamEventsUseCase) stream(
+	ctx context.Context,
+	filter entities.EventStreamFilterData,
+	last int64,
+	live <-chan entities.EventData,
+	out chan<- entities.EventData,
+) error {
+	send := func(e entities.EventData) error {
+		last = e.ID
+		if !filter.Match(e) {
+			return nil
+		}
+
+		select {
+		case out <- e:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	catchUp := func() error {
+		for {
+			events, err := u.eventRepo.GetList(ctx, entities.EventFilterData{Since: last, Limit: streamCatchUpLimit})
+			if err != nil {
+				return err
+			}
+
+			for _, e := range events {
+				if err := send(e); err != nil {
+					return err
+				}
+			}
+
+			if len(events) < streamCatchUpLimit {
+				return nil
+			}
+		}
+	}
+
+	if err := catchUp(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-live:
+			if !ok {
+				return errs.ErrLagged
+			}
+
+			var err error
+			switch {
+			case e.ID <= last:
+				// уже отправлено, пока поток догонял outbox
+			case e.ID == last+1:
+				err = send(e)
+			default:
+				// события других транзакций публикуются конкурентно и могут прийти не по порядку,
+				// а номера откаченных транзакций пропадают. Порядок восстанавливаем по outbox.
+				err = catchUp()
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"context"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func songEvent(id int64, songID int, band string) entities.EventData {
+	data, _ := json.Marshal(entities.SongAuditData{ID: songID, Band: band})
+	return entities.EventData{ID: id, Type: entities.EventSongUpdated, SongID: songID, Data: data}
+}
+
+func receive(t *testing.T, stream *entities.EventStream) entities.EventData {
+	t.Helper()
+	e, ok := <-stream.Events
+	if !ok {
+		t.Fatal("stream closed")
+	}
+	return e
+}
+
+// с Last-Event-ID поток сначала отдаёт пропущенные события, затем новые, и всё по фильтру
+func TestStreamEventsUseCase_Execute_ResumesAndFilters(t *testing.T) {
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewStreamEventsUseCase(mockEventRepo, mockEventBroker)
+
+	ctx, cancel := context.WithCancel(contextWithRole(entities.RoleViewer))
+	defer cancel()
+
+	live := make(chan entities.EventData, 1)
+	unsubscribed := false
+	mockEventBroker.On("Subscribe").Return(live, func() { unsubscribed = true })
+	mockEventRepo.On("GetList", ctx, entities.EventFilterData{Since: 5, Limit: 500}).Return([]entities.EventData{
+		songEvent(6, 1, "Queen"),
+		songEvent(7, 2, "Muse"),
+	}, nil).Once()
+
+	band := "Queen"
+	lastEventID := int64(5)
+	stream, err := useCase.Execute(ctx, entities.EventStreamFilterData{Band: &band, LastEventID: &lastEventID})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(6), receive(t, stream).ID)
+
+	live <- songEvent(7, 2, "Muse")
+	live <- songEvent(8, 3, "Queen")
+	assert.Equal(t, int64(8), receive(t, stream).ID)
+
+	cancel()
+	_, ok := <-stream.Events
+	assert.False(t, ok)
+	assert.NoError(t, stream.Err())
+	assert.True(t, unsubscribed)
+	mockEventRepo.AssertExpectations(t)
+}
+
+// событие, пришедшее раньше предыдущего по номеру, поток дочитывает из outbox по порядку
+func TestStreamEventsUseCase_Execute_FillsGap(t *testing.T) {
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewStreamEventsUseCase(mockEventRepo, mockEventBroker)
+
+	ctx, cancel := context.WithCancel(contextWithRole(entities.RoleViewer))
+	defer cancel()
+
+	live := make(chan entities.EventData, 2)
+	mockEventBroker.On("Subscribe").Return(live, func() {})
+	mockEventRepo.On("GetLastID", ctx).Return(int64(10), nil)
+	mockEventRepo.On("GetList", ctx, entities.EventFilterData{Since: 10, Limit: 500}).Return([]entities.EventData{}, nil).Once()
+	mockEventRepo.On("GetList", ctx, entities.EventFilterData{Since: 10, Limit: 500}).Return([]entities.EventData{
+		songEvent(11, 1, "Queen"),
+		songEvent(12, 2, "Muse"),
+	}, nil).Once()
+
+	stream, err := useCase.Execute(ctx, entities.EventStreamFilterData{})
+	assert.NoError(t, err)
+
+	live <- songEvent(12, 2, "Muse")
+	live <- songEvent(11, 1, "Queen")
+
+	assert.Equal(t, int64(11), receive(t, stream).ID)
+	assert.Equal(t, int64(12), receive(t, stream).ID)
+	mockEventRepo.AssertExpectations(t)
+}
+
+// брокер закрывает канал отстающего подписчика, и поток завершается с ErrLagged
+func TestStreamEventsUseCase_Execute_Lagged(t *testing.T) {
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewStreamEventsUseCase(mockEventRepo, mockEventBroker)
+
+	ctx := contextWithRole(entities.RoleViewer)
+
+	live := make(chan entities.EventData)
+	close(live)
+	mockEventBroker.On("Subscribe").Return(live, func() {})
+	mockEventRepo.On("GetLastID", ctx).Return(int64(0), nil)
+	mockEventRepo.On("GetList", ctx, entities.EventFilterData{Since: 0, Limit: 500}).Return([]entities.EventData{}, nil)
+
+	stream, err := useCase.Execute(ctx, entities.EventStreamFilterData{})
+	assert.NoError(t, err)
+
+	_, ok := <-stream.Events
+	assert.False(t, ok)
+	assert.ErrorIs(t, stream.Err(), errs.ErrLagged)
+}
+
+func TestStreamEventsUseCase_Execute_Unauthenticated(t *testing.T) {
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewStreamEventsUseCase(mockEventRepo, mockEventBroker)
+
+	stream, err := useCase.Execute(context.Background(), entities.EventStreamFilterData{})
+
+	assert.ErrorIs(t, err, errs.ErrUnauthorized)
+	assert.Nil(t, stream)
+	mockEventBroker.AssertNotCalled(t, "Subscribe")
+}
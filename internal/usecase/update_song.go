@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"errors"
 )
 
 type UpdateSongUseCase interface {
@@ -13,37 +15,91 @@ type updateSongUseCase struct {
 	transactionManager TransactionManager
 	songRepo           SongRepo
 	lyricsRepo         LyricsRepo
+	auditRepo          AuditRepo
+	eventRepo          EventRepo
+	eventPublisher     EventPublisher
+	contentFilter      ContentFilter
 }
 
 func NewUpdateSongUseCase(
 	tm TransactionManager,
 	sr SongRepo,
 	lr LyricsRepo,
+	ar AuditRepo,
+	er EventRepo,
+	ep EventPublisher,
+	cf ContentFilter,
 ) UpdateSongUseCase {
 	return &updateSongUseCase{
 		transactionManager: tm,
 		songRepo:           sr,
 		lyricsRepo:         lr,
+		auditRepo:          ar,
+		eventRepo:          er,
+		eventPublisher:     ep,
+		contentFilter:      cf,
 	}
 }
 
 func (u *updateSongUseCase) Execute(ctx context.Context, songID int, data entities.UpdateSongData) error {
 
+	if err := authorize(ctx, entities.RoleEditor); err != nil {
+		return err
+	}
+
+	// рейтинг пересчитываем только при изменении текста
+	if data.Lyrics != nil {
+		explicit := u.contentFilter.IsExplicit(*data.Lyrics)
+		data.Explicit = &explicit
+	}
+
+	var events []entities.EventData
+
 	err := u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		before, err := loadSongAuditData(ctx, u.songRepo, u.lyricsRepo, songID)
+		if err != nil {
+			return err
+		}
+
 		if err := u.songRepo.Update(ctx, songID, data); err != nil {
 			return err
 		}
 
-		if err := u.lyricsRepo.Update(ctx, songID, data); err != nil {
+		if err := updateLyrics(ctx, u.lyricsRepo, songID, data); err != nil {
 			return err
 		}
 
-		return nil
+		after := applySongUpdate(before, data)
+		if err := writeAudit(ctx, u.auditRepo, entities.AuditUpdate, songID, before, after); err != nil {
+			return err
+		}
+
+		events, err = writeEvents(ctx, u.eventRepo, songID, &before, &after)
+		return err
 	})
 
 	if err != nil {
 		return err
 	}
 
+	u.eventPublisher.Publish(events)
+
 	return nil
 }
+
+// У песни может не быть текста, тогда он создаётся при первом непустом изменении
+func updateLyrics(ctx context.Context, lr LyricsRepo, songID int, data entities.UpdateSongData) error {
+	err := lr.Update(ctx, songID, data)
+	if data.Lyrics == nil || !errors.Is(err, errs.ErrNotFound) {
+		return err
+	}
+
+	if *data.Lyrics == "" {
+		return nil
+	}
+
+	return lr.Create(ctx, entities.NewLyricsData{
+		SongID:  songID,
+		Content: *data.Lyrics,
+	})
+}
@@ -1,8 +1,8 @@
 package usecase_test
 
 import (
-	"context"
 	"em-library/internal/entities"
+	"em-library/internal/errs"
 	"em-library/internal/usecase"
 	"errors"
 	"testing"
@@ -15,10 +15,15 @@ func TestUpdateSongUseCase_Execute_Success(t *testing.T) {
 	mockTM := new(MockTransactionManager)
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleEditor)
 	songID := 123
+	expectSongAuditState(ctx, mockSongRepo, mockLyricsRepo, songID)
 	band := "Updated Band"
 	song := "Updated Song"
 	link := "https://updated.example.com/song"
@@ -31,9 +36,17 @@ func TestUpdateSongUseCase_Execute_Success(t *testing.T) {
 		Lyrics:      &lyrics,
 	}
 
+	explicit := false
+	expectedData := updateData
+	expectedData.Explicit = &explicit
+
+	mockContentFilter.On("IsExplicit", lyrics).Return(explicit)
 	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
-	mockSongRepo.On("Update", ctx, songID, updateData).Return(nil)
-	mockLyricsRepo.On("Update", ctx, songID, updateData).Return(nil)
+	mockSongRepo.On("Update", ctx, songID, expectedData).Return(nil)
+	mockLyricsRepo.On("Update", ctx, songID, expectedData).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditUpdate, songID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(songID, entities.EventSongUpdated, entities.EventLyricsUpdated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
 
 	err := useCase.Execute(ctx, songID, updateData)
 
@@ -41,16 +54,60 @@ func TestUpdateSongUseCase_Execute_Success(t *testing.T) {
 	mockTM.AssertExpectations(t)
 	mockSongRepo.AssertExpectations(t)
 	mockLyricsRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockEventBroker.AssertExpectations(t)
+}
+
+// У песни без текста он создаётся при первом изменении
+func TestUpdateSongUseCase_Execute_SongWithoutLyrics(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	songID := 123
+	lyrics := "First lyrics"
+	explicit := false
+	updateData := entities.UpdateSongData{Lyrics: &lyrics}
+	expectedData := entities.UpdateSongData{Lyrics: &lyrics, Explicit: &explicit}
+
+	mockSongRepo.On("GetList", ctx, entities.SongFilterData{ID: &songID}).
+		Return([]entities.SongData{{ID: songID, Band: "Test Group", Song: "Test Song"}}, nil)
+	mockLyricsRepo.On("Get", ctx, songID).Return(entities.LyricsData{}, errs.ErrNotFound)
+	mockContentFilter.On("IsExplicit", lyrics).Return(explicit)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Update", ctx, songID, expectedData).Return(nil)
+	mockLyricsRepo.On("Update", ctx, songID, expectedData).Return(errs.ErrNotFound)
+	mockLyricsRepo.On("Create", ctx, entities.NewLyricsData{SongID: songID, Content: lyrics}).Return(nil).Once()
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditUpdate, songID)).Return(nil)
+	mockEventRepo.On("Create", ctx, mock.Anything).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	err := useCase.Execute(ctx, songID, updateData)
+
+	assert.NoError(t, err)
+	mockLyricsRepo.AssertExpectations(t)
 }
 
 func TestUpdateSongUseCase_Execute_SongRepoError(t *testing.T) {
 	mockTM := new(MockTransactionManager)
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleEditor)
 	songID := 123
+	expectSongAuditState(ctx, mockSongRepo, mockLyricsRepo, songID)
 	band := "Updated Band"
 	song := "Updated Song"
 	lyrics := "Updated lyrics"
@@ -62,8 +119,13 @@ func TestUpdateSongUseCase_Execute_SongRepoError(t *testing.T) {
 
 	expectedError := errors.New("song repository error")
 
+	explicit := false
+	expectedData := updateData
+	expectedData.Explicit = &explicit
+
+	mockContentFilter.On("IsExplicit", lyrics).Return(explicit)
 	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(expectedError)
-	mockSongRepo.On("Update", ctx, songID, updateData).Return(expectedError)
+	mockSongRepo.On("Update", ctx, songID, expectedData).Return(expectedError)
 
 	err := useCase.Execute(ctx, songID, updateData)
 
@@ -78,10 +140,15 @@ func TestUpdateSongUseCase_Execute_LyricsRepoError(t *testing.T) {
 	mockTM := new(MockTransactionManager)
 	mockSongRepo := new(MockSongRepo)
 	mockLyricsRepo := new(MockLyricsRepo)
-	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
 
-	ctx := context.Background()
+	ctx := contextWithRole(entities.RoleEditor)
 	songID := 123
+	expectSongAuditState(ctx, mockSongRepo, mockLyricsRepo, songID)
 	band := "Updated Band"
 	song := "Updated Song"
 	lyrics := "Updated lyrics"
@@ -93,9 +160,14 @@ func TestUpdateSongUseCase_Execute_LyricsRepoError(t *testing.T) {
 
 	expectedError := errors.New("lyrics repository error")
 
+	explicit := false
+	expectedData := updateData
+	expectedData.Explicit = &explicit
+
+	mockContentFilter.On("IsExplicit", lyrics).Return(explicit)
 	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(expectedError)
-	mockSongRepo.On("Update", ctx, songID, updateData).Return(nil)
-	mockLyricsRepo.On("Update", ctx, songID, updateData).Return(expectedError)
+	mockSongRepo.On("Update", ctx, songID, expectedData).Return(nil)
+	mockLyricsRepo.On("Update", ctx, songID, expectedData).Return(expectedError)
 
 	err := useCase.Execute(ctx, songID, updateData)
 
@@ -105,3 +177,130 @@ func TestUpdateSongUseCase_Execute_LyricsRepoError(t *testing.T) {
 	mockSongRepo.AssertExpectations(t)
 	mockLyricsRepo.AssertExpectations(t)
 }
+
+func TestUpdateSongUseCase_Execute_WithoutLyricsKeepsRating(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	songID := 123
+	expectSongAuditState(ctx, mockSongRepo, mockLyricsRepo, songID)
+	band := "Updated Band"
+	updateData := entities.UpdateSongData{
+		Band: &band,
+	}
+
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Update", ctx, songID, updateData).Return(nil)
+	mockLyricsRepo.On("Update", ctx, songID, updateData).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditUpdate, songID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(songID, entities.EventSongUpdated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	err := useCase.Execute(ctx, songID, updateData)
+
+	assert.NoError(t, err)
+	mockSongRepo.AssertExpectations(t)
+	mockContentFilter.AssertNotCalled(t, "IsExplicit")
+}
+
+// viewer может только читать
+func TestUpdateSongUseCase_Execute_Forbidden(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	band := "Updated Band"
+	err := useCase.Execute(contextWithRole(entities.RoleViewer), 123, entities.UpdateSongData{Band: &band})
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	mockTM.AssertNotCalled(t, "Do")
+	mockSongRepo.AssertNotCalled(t, "Update")
+}
+
+func TestUpdateSongUseCase_Execute_WritesAuditRecord(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	ctx := entities.ContextWithRequestID(contextWithRole(entities.RoleEditor), "req-1")
+	songID := 123
+	expectSongAuditState(ctx, mockSongRepo, mockLyricsRepo, songID)
+	link := "https://updated.example.com/song"
+	updateData := entities.UpdateSongData{Link: &link}
+
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Update", ctx, songID, updateData).Return(nil)
+	mockLyricsRepo.On("Update", ctx, songID, updateData).Return(nil)
+	mockAuditRepo.On("Create", ctx, entities.NewAuditRecordData{
+		Actor:    "test",
+		Action:   entities.AuditUpdate,
+		Entity:   entities.AuditEntitySong,
+		EntityID: songID,
+		Before: entities.SongAuditData{
+			ID: songID, Band: "Test Group", Song: "Test Song", Lyrics: "Test lyrics",
+		},
+		After: entities.SongAuditData{
+			ID: songID, Band: "Test Group", Song: "Test Song", Link: link, Lyrics: "Test lyrics",
+		},
+		RequestID: "req-1",
+	}).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(songID, entities.EventSongUpdated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	err := useCase.Execute(ctx, songID, updateData)
+
+	assert.NoError(t, err)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+// Новый текст без изменения рейтинга публикуется только событием lyrics.updated
+func TestUpdateSongUseCase_Execute_LyricsOnly(t *testing.T) {
+	mockTM := new(MockTransactionManager)
+	mockSongRepo := new(MockSongRepo)
+	mockLyricsRepo := new(MockLyricsRepo)
+	mockContentFilter := new(MockContentFilter)
+	mockAuditRepo := new(MockAuditRepo)
+	mockEventRepo := new(MockEventRepo)
+	mockEventBroker := new(MockEventBroker)
+	useCase := usecase.NewUpdateSongUseCase(mockTM, mockSongRepo, mockLyricsRepo, mockAuditRepo, mockEventRepo, mockEventBroker, mockContentFilter)
+
+	ctx := contextWithRole(entities.RoleEditor)
+	songID := 123
+	stored, explicit := false, false
+	lyrics := "Updated lyrics"
+	updateData := entities.UpdateSongData{Lyrics: &lyrics}
+	expectedData := entities.UpdateSongData{Lyrics: &lyrics, Explicit: &explicit}
+
+	mockSongRepo.On("GetList", ctx, entities.SongFilterData{ID: &songID}).
+		Return([]entities.SongData{{ID: songID, Band: "Test Group", Song: "Test Song", Explicit: &stored}}, nil)
+	mockLyricsRepo.On("Get", ctx, songID).Return(entities.LyricsData{SongID: songID, Content: "Test lyrics"}, nil)
+	mockContentFilter.On("IsExplicit", lyrics).Return(explicit)
+	mockTM.On("Do", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+	mockSongRepo.On("Update", ctx, songID, expectedData).Return(nil)
+	mockLyricsRepo.On("Update", ctx, songID, expectedData).Return(nil)
+	mockAuditRepo.On("Create", ctx, auditRecordWith(entities.AuditUpdate, songID)).Return(nil)
+	mockEventRepo.On("Create", ctx, eventsOf(songID, entities.EventLyricsUpdated)).Return([]entities.EventData{{ID: 1}}, nil)
+	mockEventBroker.On("Publish", []entities.EventData{{ID: 1}}).Once()
+
+	err := useCase.Execute(ctx, songID, updateData)
+
+	assert.NoError(t, err)
+	mockEventRepo.AssertExpectations(t)
+}
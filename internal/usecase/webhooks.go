@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+const webhookSecretPrefix = "whsec_"
+
+type CreateWebhookUseCase interface {
+	Execute(ctx context.Context, data entities.NewWebhookData) (*entities.CreatedWebhookData, error)
+}
+
+type createWebhookUseCase struct {
+	webhookRepo WebhookRepo
+}
+
+func NewCreateWebhookUseCase(wr WebhookRepo) CreateWebhookUseCase {
+	return &createWebhookUseCase{
+		webhookRepo: wr,
+	}
+}
+
+// Создаёт подписку со случайным секретом. Секретом подписываются тела запросов,
+// он возвращается только в ответе на создание.
+func (u *createWebhookUseCase) Execute(
+	ctx context.Context,
+	data entities.NewWebhookData,
+) (*entities.CreatedWebhookData, error) {
+
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookURL(data.URL); err != nil {
+		return nil, err
+	}
+
+	events, err := validateWebhookEvents(data.Events)
+	if err != nil {
+		return nil, err
+	}
+	data.Events = events
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	data.Secret = webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	webhook, err := u.webhookRepo.Create(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.CreatedWebhookData{
+		WebhookData: webhook,
+		Secret:      data.Secret,
+	}, nil
+}
+
+type GetWebhooksUseCase interface {
+	Execute(ctx context.Context) ([]entities.WebhookData, error)
+}
+
+type getWebhooksUseCase struct {
+	webhookRepo WebhookRepo
+}
+
+func NewGetWebhooksUseCase(wr WebhookRepo) GetWebhooksUseCase {
+	return &getWebhooksUseCase{
+		webhookRepo: wr,
+	}
+}
+
+func (u *getWebhooksUseCase) Execute(ctx context.Context) ([]entities.WebhookData, error) {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return u.webhookRepo.GetList(ctx)
+}
+
+type GetWebhookUseCase interface {
+	Execute(ctx context.Context, webhookID int) (*entities.WebhookData, error)
+}
+
+type getWebhookUseCase struct {
+	webhookRepo WebhookRepo
+}
+
+func NewGetWebhookUseCase(wr WebhookRepo) GetWebhookUseCase {
+	return &getWebhookUseCase{
+		webhookRepo: wr,
+	}
+}
+
+func (u *getWebhookUseCase) Execute(ctx context.Context, webhookID int) (*entities.WebhookData, error) {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	webhook, err := u.webhookRepo.Get(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+type UpdateWebhookUseCase interface {
+	Execute(ctx context.Context, webhookID int, data entities.UpdateWebhookData) (*entities.WebhookData, error)
+}
+
+type updateWebhookUseCase struct {
+	webhookRepo WebhookRepo
+}
+
+func NewUpdateWebhookUseCase(wr WebhookRepo) UpdateWebhookUseCase {
+	return &updateWebhookUseCase{
+		webhookRepo: wr,
+	}
+}
+
+func (u *updateWebhookUseCase) Execute(
+	ctx context.Context,
+	webhookID int,
+	data entities.UpdateWebhookData,
+) (*entities.WebhookData, error) {
+
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if data.URL != nil {
+		if err := validateWebhookURL(*data.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	if data.Events != nil {
+		events, err := validateWebhookEvents(data.Events)
+		if err != nil {
+			return nil, err
+		}
+		data.Events = events
+	}
+
+	webhook, err := u.webhookRepo.Update(ctx, webhookID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+type DeleteWebhookUseCase interface {
+	Execute(ctx context.Context, webhookID int) error
+}
+
+type deleteWebhookUseCase struct {
+	webhookRepo WebhookRepo
+}
+
+func NewDeleteWebhookUseCase(wr WebhookRepo) DeleteWebhookUseCase {
+	return &deleteWebhookUseCase{
+		webhookRepo: wr,
+	}
+}
+
+func (u *deleteWebhookUseCase) Execute(ctx context.Context, webhookID int) error {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return err
+	}
+
+	return u.webhookRepo.Delete(ctx, webhookID)
+}
+
+type GetWebhookDeliveriesUseCase interface {
+	Execute(ctx context.Context, filter entities.WebhookDeliveryFilterData) ([]entities.WebhookDeliveryData, error)
+}
+
+type getWebhookDeliveriesUseCase struct {
+	webhookRepo WebhookRepo
+}
+
+func NewGetWebhookDeliveriesUseCase(wr WebhookRepo) GetWebhookDeliveriesUseCase {
+	return &getWebhookDeliveriesUseCase{
+		webhookRepo: wr,
+	}
+}
+
+func (u *getWebhookDeliveriesUseCase) Execute(
+	ctx context.Context,
+	filter entities.WebhookDeliveryFilterData,
+) ([]entities.WebhookDeliveryData, error) {
+
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return u.webhookRepo.GetDeliveries(ctx, filter)
+}
+
+type RedeliverWebhookUseCase interface {
+	Execute(ctx context.Context, deliveryID int64) error
+}
+
+type redeliverWebhookUseCase struct {
+	webhookRepo WebhookRepo
+}
+
+func NewRedeliverWebhookUseCase(wr WebhookRepo) RedeliverWebhookUseCase {
+	return &redeliverWebhookUseCase{
+		webhookRepo: wr,
+	}
+}
+
+// Возвращает доставку из dead-letter в очередь рассылки
+func (u *redeliverWebhookUseCase) Execute(ctx context.Context, deliveryID int64) error {
+	if err := authorize(ctx, entities.RoleAdmin); err != nil {
+		return err
+	}
+
+	return u.webhookRepo.Redeliver(ctx, deliveryID)
+}
+
+func validateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w webhook url must be an absolute http or https url", errs.ErrInvalidInput)
+	}
+	return nil
+}
+
+// Проверяет типы событий и убирает повторы
+func validateWebhookEvents(events []entities.EventType) ([]entities.EventType, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w webhook must subscribe to at least one event", errs.ErrInvalidInput)
+	}
+
+	unique := make([]entities.EventType, 0, len(events))
+	for _, e := range events {
+		if !e.Valid() {
+			return nil, fmt.Errorf("%w unknown event type %q", errs.ErrInvalidInput, e)
+		}
+		if !slices.Contains(unique, e) {
+			unique = append(unique, e)
+		}
+	}
+	return unique, nil
+}
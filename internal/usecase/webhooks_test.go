@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"em-library/internal/entities"
+	"em-library/internal/errs"
+	"em-library/internal/usecase"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Повторы событий убираются, секрет генерируется и возвращается один раз
+func TestCreateWebhookUseCase_Execute_Success(t *testing.T) {
+	mockWebhookRepo := new(MockWebhookRepo)
+	useCase := usecase.NewCreateWebhookUseCase(mockWebhookRepo)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+
+	var saved entities.NewWebhookData
+	mockWebhookRepo.On("Create", ctx, mock.AnythingOfType("entities.NewWebhookData")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(entities.NewWebhookData) }).
+		Return(entities.WebhookData{ID: 1, URL: "https://example.com/hook"}, nil)
+
+	created, err := useCase.Execute(ctx, entities.NewWebhookData{
+		URL:    "https://example.com/hook",
+		Events: []entities.EventType{entities.EventSongCreated, entities.EventLyricsUpdated, entities.EventSongCreated},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.EventType{entities.EventSongCreated, entities.EventLyricsUpdated}, saved.Events)
+	assert.True(t, strings.HasPrefix(created.Secret, "whsec_"))
+	assert.Equal(t, saved.Secret, created.Secret)
+	assert.Equal(t, 1, created.ID)
+}
+
+func TestCreateWebhookUseCase_Execute_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		data entities.NewWebhookData
+	}{
+		{"relative url", entities.NewWebhookData{URL: "/hook", Events: []entities.EventType{entities.EventSongCreated}}},
+		{"unsupported scheme", entities.NewWebhookData{URL: "ftp://example.com", Events: []entities.EventType{entities.EventSongCreated}}},
+		{"no events", entities.NewWebhookData{URL: "https://example.com/hook"}},
+		{"unknown event", entities.NewWebhookData{URL: "https://example.com/hook", Events: []entities.EventType{"song.played"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookRepo := new(MockWebhookRepo)
+			useCase := usecase.NewCreateWebhookUseCase(mockWebhookRepo)
+
+			created, err := useCase.Execute(contextWithRole(entities.RoleAdmin), tt.data)
+
+			assert.ErrorIs(t, err, errs.ErrInvalidInput)
+			assert.Nil(t, created)
+			mockWebhookRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+// подписками управляют только администраторы
+func TestCreateWebhookUseCase_Execute_Forbidden(t *testing.T) {
+	mockWebhookRepo := new(MockWebhookRepo)
+	useCase := usecase.NewCreateWebhookUseCase(mockWebhookRepo)
+
+	_, err := useCase.Execute(contextWithRole(entities.RoleEditor), entities.NewWebhookData{
+		URL:    "https://example.com/hook",
+		Events: []entities.EventType{entities.EventSongCreated},
+	})
+
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	mockWebhookRepo.AssertNotCalled(t, "Create")
+}
+
+// события не передаются, поэтому не меняются
+func TestUpdateWebhookUseCase_Execute_Deactivate(t *testing.T) {
+	mockWebhookRepo := new(MockWebhookRepo)
+	useCase := usecase.NewUpdateWebhookUseCase(mockWebhookRepo)
+
+	ctx := contextWithRole(entities.RoleAdmin)
+	active := false
+
+	mockWebhookRepo.On("Update", ctx, 1, entities.UpdateWebhookData{Active: &active}).
+		Return(entities.WebhookData{ID: 1, Active: false}, nil)
+
+	webhook, err := useCase.Execute(ctx, 1, entities.UpdateWebhookData{Active: &active})
+
+	assert.NoError(t, err)
+	assert.False(t, webhook.Active)
+	mockWebhookRepo.AssertExpectations(t)
+}
+
+func TestUpdateWebhookUseCase_Execute_EmptyEvents(t *testing.T) {
+	mockWebhookRepo := new(MockWebhookRepo)
+	useCase := usecase.NewUpdateWebhookUseCase(mockWebhookRepo)
+
+	_, err := useCase.Execute(contextWithRole(entities.RoleAdmin), 1, entities.UpdateWebhookData{Events: []entities.EventType{}})
+
+	assert.ErrorIs(t, err, errs.ErrInvalidInput)
+	mockWebhookRepo.AssertNotCalled(t, "Update")
+}
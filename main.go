@@ -1,39 +1,202 @@
 package main
 
 import (
+	"context"
 	"em-library/config"
+	grpcapi "em-library/internal/api/grpc"
 	"em-library/internal/app"
+	"em-library/internal/cli"
+	"em-library/internal/repository"
+	"em-library/migrations"
 	"em-library/pkg/database"
 	"em-library/pkg/server"
+	"em-library/pkg/tracing"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API-ключ, выпущенный командой main apikey create
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT (HS256 или RS256) или API-ключ в формате "Bearer <token>"
 func main() {
-	cfg := config.Load()
+	configFile := flag.String("config", "", "файл конфигурации в формате YAML или TOML, по умолчанию EMLIB_CONFIG_FILE")
+	printConfig := flag.Bool("print-config", false, "вывести итоговую конфигурацию без секретов и выйти")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
 
-	cfg.Logger.Info("Running migrations")
-	mg := database.NewMigrator(cfg.DB)
-	if err := mg.RunMigrations(); err != nil {
-		cfg.Logger.Error("Failed to run migrations", "errror", err)
+	if *printConfig {
+		for _, s := range cfg.Settings() {
+			fmt.Printf("%s=%s # %s\n", s.Key, s.Value, s.Source)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid configuration:")
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-	cfg.Logger.Info("Migrations run successfully")
+	if *printConfig {
+		return
+	}
 
-	cfg.Logger.Info("Connecting to database")
-	db := database.NewDatabase(cfg.DB, cfg.Logger)
-	if db == nil {
-		cfg.Logger.Error("Failed connect to database", "error")
+	if err := run(cfg, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+}
+
+const usage = `usage: main [flags] [command] [args]
+
+commands:
+  serve                 запустить HTTP-сервер (по умолчанию)
+  migrate up|down|redo|status
+                        управление миграциями
+  import, export, refresh, reindex
+                        обслуживание библиотеки, подробнее: main import
+  apikey create|list|revoke
+                        управление API-ключами
+  create-api-key -name <name> [-role viewer|editor|admin]
+                        то же, что apikey create
+
+flags:`
+
+func run(cfg *config.Config, args []string) error {
+	command := "serve"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, cfg.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			cfg.Logger.Error("Failed to flush traces", "error", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	switch command {
+	case "serve":
+		return serve(cfg)
+	case "migrate":
+		// миграциям не нужны пул соединений и юзкейсы
+		return cli.NewMigrate(os.Stdout, database.NewMigrator(cfg.DB, migrations.FS)).Run(ctx, args)
+	case "apikey", "create-api-key", "import", "export", "refresh", "reindex":
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+
+	db, err := connect(cfg)
+	if err != nil {
+		return err
+	}
 	defer db.Close()
-	cfg.Logger.Info("Connected to database successfully")
 
 	app := app.New(cfg, db)
 
+	switch command {
+	case "apikey":
+		return cli.NewAPIKeys(os.Stdout, app.UseCases).Run(ctx, args)
+	case "create-api-key":
+		return cli.NewAPIKeys(os.Stdout, app.UseCases).Run(ctx, append([]string{"create"}, args...))
+	default:
+		return cli.NewSongs(os.Stdout, app.UseCases).Run(ctx, append([]string{command}, args...))
+	}
+}
+
+// Миграции при старте применяются, только если это явно включено в EMLIB_RUN_MIGRATIONS,
+// иначе их применяют отдельно командой migrate up. Если схема базы новее бинарника,
+// сервер не запускается: старый код может испортить данные в новой схеме.
+func serve(cfg *config.Config) error {
+	mg := database.NewMigrator(cfg.DB, migrations.FS)
+
+	if cfg.DB.RunMigrations {
+		cfg.Logger.Info("Running migrations")
+		if _, err := mg.Up(context.Background()); err != nil {
+			return err
+		}
+		cfg.Logger.Info("Migrations run successfully")
+	}
+
+	if err := mg.CheckCompatible(context.Background()); err != nil {
+		return fmt.Errorf("refusing to serve: %w", err)
+	}
+
+	db, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	app := app.New(cfg, db)
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go app.RefreshStale.Run(jobsCtx)
+	go app.DetectDuplicates.Run(jobsCtx)
+	go app.DispatchWebhooks.Run(jobsCtx)
+	go app.ConfigReloader.Run(jobsCtx)
+
+	if cfg.Metrics.Enabled {
+		prometheus.MustRegister(
+			database.NewPoolCollector(db),
+			repository.NewPGStatsCollector(db, cfg.Logger),
+		)
+	}
+
 	cfg.Logger.Info("launched song library service", "config", cfg.Server)
 
+	// gRPC-сервер останавливается после HTTP-сервера, когда тот получит сигнал завершения.
+	// Порт занимается до запуска HTTP-сервера: если он занят, сервис не запускается.
+	if cfg.GRPC.Enabled {
+		grpcServer := grpcapi.NewServer(cfg, app.UseCases)
+		listener, err := grpcServer.Listen()
+		if err != nil {
+			return fmt.Errorf("failed to launch gRPC server: %w", err)
+		}
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				cfg.Logger.Error("gRPC server stopped", "error", err)
+			}
+		}()
+		defer grpcServer.Shutdown(5 * time.Second)
+	}
+
 	srv := server.New(cfg, app.Handlers)
 	if err := srv.Run(); err != nil {
-		cfg.Logger.Error("failed to launch server", "Error", err)
+		return fmt.Errorf("failed to launch server: %w", err)
+	}
+
+	return nil
+}
+
+func connect(cfg *config.Config) (*database.Database, error) {
+	cfg.Logger.Info("Connecting to database")
+	db := database.NewDatabase(cfg.DB, cfg.Logger)
+	if db == nil {
+		return nil, errors.New("failed to connect to database")
 	}
+	cfg.Logger.Info("Connected to database successfully")
+
+	return db, nil
 }
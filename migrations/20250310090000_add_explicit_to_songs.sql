@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- у уже сохранённых песен текст ещё не проверен, поэтому explicit остаётся NULL,
+-- пока его не заполнит ./main reindex. Фильтр explicit=false такие песни не находит.
+ALTER TABLE songs ADD COLUMN explicit BOOLEAN;
+
+CREATE INDEX idx_songs_explicit ON songs (explicit);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_songs_explicit;
+
+ALTER TABLE songs
+DROP COLUMN explicit;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS lyrics_revisions (
+  song_id INTEGER NOT NULL,
+  revision INTEGER NOT NULL,
+  content TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW (),
+  PRIMARY KEY (song_id, revision),
+  FOREIGN KEY (song_id) REFERENCES songs (id) ON DELETE CASCADE
+);
+
+-- текущие тексты становятся первой ревизией
+INSERT INTO lyrics_revisions (song_id, revision, content, created_at)
+SELECT song_id, 1, content, updated_at FROM lyrics;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE lyrics_revisions;
+
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE songs ADD COLUMN refreshed_at TIMESTAMP NOT NULL DEFAULT NOW ();
+
+UPDATE songs SET refreshed_at = created_at WHERE created_at IS NOT NULL;
+
+CREATE INDEX idx_songs_refreshed_at ON songs (refreshed_at);
+
+CREATE TABLE IF NOT EXISTS song_drafts (
+  song_id INTEGER PRIMARY KEY,
+  release_date date,
+  link VARCHAR(200),
+  lyrics TEXT,
+  created_at TIMESTAMP DEFAULT NOW (),
+  FOREIGN KEY (song_id) REFERENCES songs (id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE song_drafts;
+
+DROP INDEX idx_songs_refreshed_at;
+
+ALTER TABLE songs
+DROP COLUMN refreshed_at;
+
+-- +goose StatementEnd
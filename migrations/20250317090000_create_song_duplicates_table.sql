@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS song_duplicates (
+  song_id INTEGER NOT NULL,
+  duplicate_id INTEGER NOT NULL,
+  title_score REAL NOT NULL,
+  lyrics_score REAL,
+  score REAL NOT NULL,
+  detected_at TIMESTAMP DEFAULT NOW (),
+  PRIMARY KEY (song_id, duplicate_id),
+  FOREIGN KEY (song_id) REFERENCES songs (id) ON DELETE CASCADE,
+  FOREIGN KEY (duplicate_id) REFERENCES songs (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_song_duplicates_score ON song_duplicates (score);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE song_duplicates;
+
+-- +goose StatementEnd
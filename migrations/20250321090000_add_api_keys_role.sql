@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ключи, выпущенные до появления ролей, имели полный доступ
+ALTER TABLE api_keys ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'admin';
+
+ALTER TABLE api_keys ALTER COLUMN role SET DEFAULT 'viewer';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE api_keys DROP COLUMN role;
+
+-- +goose StatementEnd
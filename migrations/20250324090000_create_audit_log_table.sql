@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_log (
+  id BIGSERIAL PRIMARY KEY,
+  actor VARCHAR(255) NOT NULL,
+  action VARCHAR(16) NOT NULL,
+  entity VARCHAR(32) NOT NULL,
+  entity_id INTEGER NOT NULL,
+  before JSONB,
+  after JSONB,
+  request_id VARCHAR(64) NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT NOW ()
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log (entity, entity_id);
+
+CREATE INDEX idx_audit_log_actor ON audit_log (actor);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_log;
+
+-- +goose StatementEnd
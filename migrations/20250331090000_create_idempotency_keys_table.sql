@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  key VARCHAR(64) PRIMARY KEY,
+  fingerprint VARCHAR(64) NOT NULL,
+  response_status INTEGER,
+  response_content_type VARCHAR(255),
+  response_body BYTEA,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW (),
+  expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE idempotency_keys;
+
+-- +goose StatementEnd
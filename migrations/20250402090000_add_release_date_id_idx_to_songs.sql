@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- список песен упорядочен по дате релиза и ID, по этой же паре читаются страницы потока ListSongs
+CREATE INDEX idx_songs_release_date_id ON songs (release_date, id);
+
+DROP INDEX idx_songs_release_date;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+CREATE INDEX idx_songs_release_date ON songs (release_date);
+
+DROP INDEX idx_songs_release_date_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- неизвестная дата релиза сохранялась как 0001-01-01, теперь для неё NULL
+UPDATE songs SET release_date = NULL WHERE release_date = '0001-01-01';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+-- прежний код не умеет читать NULL в дате релиза
+UPDATE songs SET release_date = '0001-01-01' WHERE release_date IS NULL;
+
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+// Миграции встраиваются в бинарник, поэтому он не зависит от каталога, из которого запущен.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,332 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: songlibrary/v1/song_library.proto
+
+// Библиотека песен для внутренних сервисов. Методы выполняются теми же юзкейсами,
+// что и REST API, поэтому права доступа, проверки и транзакции у них общие.
+
+package songlibraryv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SongLibrary_CreateSong_FullMethodName = "/songlibrary.v1.SongLibrary/CreateSong"
+	SongLibrary_GetSong_FullMethodName    = "/songlibrary.v1.SongLibrary/GetSong"
+	SongLibrary_ListSongs_FullMethodName  = "/songlibrary.v1.SongLibrary/ListSongs"
+	SongLibrary_UpdateSong_FullMethodName = "/songlibrary.v1.SongLibrary/UpdateSong"
+	SongLibrary_DeleteSong_FullMethodName = "/songlibrary.v1.SongLibrary/DeleteSong"
+	SongLibrary_GetLyrics_FullMethodName  = "/songlibrary.v1.SongLibrary/GetLyrics"
+)
+
+// SongLibraryClient is the client API for SongLibrary service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SongLibraryClient interface {
+	// Создание песни, недостающие данные запрашиваются во внешнем сервисе по режиму enrich
+	CreateSong(ctx context.Context, in *CreateSongRequest, opts ...grpc.CallOption) (*CreateSongResponse, error)
+	// Песня по ID, NOT_FOUND, если её нет
+	GetSong(ctx context.Context, in *GetSongRequest, opts ...grpc.CallOption) (*GetSongResponse, error)
+	// Все песни по фильтру в порядке даты релиза, песни с одной датой — в порядке ID.
+	// Песни читаются из базы страницами по page_size, каждая начинается после последней отправленной песни.
+	ListSongs(ctx context.Context, in *ListSongsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Song], error)
+	// Изменение песни, незаданные поля не меняются
+	UpdateSong(ctx context.Context, in *UpdateSongRequest, opts ...grpc.CallOption) (*UpdateSongResponse, error)
+	// Удаление песни вместе с текстом
+	DeleteSong(ctx context.Context, in *DeleteSongRequest, opts ...grpc.CallOption) (*DeleteSongResponse, error)
+	// Куплеты текста песни
+	GetLyrics(ctx context.Context, in *GetLyricsRequest, opts ...grpc.CallOption) (*GetLyricsResponse, error)
+}
+
+type songLibraryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSongLibraryClient(cc grpc.ClientConnInterface) SongLibraryClient {
+	return &songLibraryClient{cc}
+}
+
+func (c *songLibraryClient) CreateSong(ctx context.Context, in *CreateSongRequest, opts ...grpc.CallOption) (*CreateSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSongResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_CreateSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) GetSong(ctx context.Context, in *GetSongRequest, opts ...grpc.CallOption) (*GetSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSongResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_GetSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) ListSongs(ctx context.Context, in *ListSongsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Song], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SongLibrary_ServiceDesc.Streams[0], SongLibrary_ListSongs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListSongsRequest, Song]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SongLibrary_ListSongsClient = grpc.ServerStreamingClient[Song]
+
+func (c *songLibraryClient) UpdateSong(ctx context.Context, in *UpdateSongRequest, opts ...grpc.CallOption) (*UpdateSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSongResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_UpdateSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) DeleteSong(ctx context.Context, in *DeleteSongRequest, opts ...grpc.CallOption) (*DeleteSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSongResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_DeleteSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) GetLyrics(ctx context.Context, in *GetLyricsRequest, opts ...grpc.CallOption) (*GetLyricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLyricsResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_GetLyrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SongLibraryServer is the server API for SongLibrary service.
+// All implementations must embed UnimplementedSongLibraryServer
+// for forward compatibility.
+type SongLibraryServer interface {
+	// Создание песни, недостающие данные запрашиваются во внешнем сервисе по режиму enrich
+	CreateSong(context.Context, *CreateSongRequest) (*CreateSongResponse, error)
+	// Песня по ID, NOT_FOUND, если её нет
+	GetSong(context.Context, *GetSongRequest) (*GetSongResponse, error)
+	// Все песни по фильтру в порядке даты релиза, песни с одной датой — в порядке ID.
+	// Песни читаются из базы страницами по page_size, каждая начинается после последней отправленной песни.
+	ListSongs(*ListSongsRequest, grpc.ServerStreamingServer[Song]) error
+	// Изменение песни, незаданные поля не меняются
+	UpdateSong(context.Context, *UpdateSongRequest) (*UpdateSongResponse, error)
+	// Удаление песни вместе с текстом
+	DeleteSong(context.Context, *DeleteSongRequest) (*DeleteSongResponse, error)
+	// Куплеты текста песни
+	GetLyrics(context.Context, *GetLyricsRequest) (*GetLyricsResponse, error)
+	mustEmbedUnimplementedSongLibraryServer()
+}
+
+// UnimplementedSongLibraryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSongLibraryServer struct{}
+
+func (UnimplementedSongLibraryServer) CreateSong(context.Context, *CreateSongRequest) (*CreateSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSong not implemented")
+}
+func (UnimplementedSongLibraryServer) GetSong(context.Context, *GetSongRequest) (*GetSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSong not implemented")
+}
+func (UnimplementedSongLibraryServer) ListSongs(*ListSongsRequest, grpc.ServerStreamingServer[Song]) error {
+	return status.Errorf(codes.Unimplemented, "method ListSongs not implemented")
+}
+func (UnimplementedSongLibraryServer) UpdateSong(context.Context, *UpdateSongRequest) (*UpdateSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSong not implemented")
+}
+func (UnimplementedSongLibraryServer) DeleteSong(context.Context, *DeleteSongRequest) (*DeleteSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSong not implemented")
+}
+func (UnimplementedSongLibraryServer) GetLyrics(context.Context, *GetLyricsRequest) (*GetLyricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLyrics not implemented")
+}
+func (UnimplementedSongLibraryServer) mustEmbedUnimplementedSongLibraryServer() {}
+func (UnimplementedSongLibraryServer) testEmbeddedByValue()                     {}
+
+// UnsafeSongLibraryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SongLibraryServer will
+// result in compilation errors.
+type UnsafeSongLibraryServer interface {
+	mustEmbedUnimplementedSongLibraryServer()
+}
+
+func RegisterSongLibraryServer(s grpc.ServiceRegistrar, srv SongLibraryServer) {
+	// If the following call pancis, it indicates UnimplementedSongLibraryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SongLibrary_ServiceDesc, srv)
+}
+
+func _SongLibrary_CreateSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).CreateSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_CreateSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).CreateSong(ctx, req.(*CreateSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_GetSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).GetSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_GetSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).GetSong(ctx, req.(*GetSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_ListSongs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSongsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SongLibraryServer).ListSongs(m, &grpc.GenericServerStream[ListSongsRequest, Song]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SongLibrary_ListSongsServer = grpc.ServerStreamingServer[Song]
+
+func _SongLibrary_UpdateSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).UpdateSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_UpdateSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).UpdateSong(ctx, req.(*UpdateSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_DeleteSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).DeleteSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_DeleteSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).DeleteSong(ctx, req.(*DeleteSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_GetLyrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLyricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).GetLyrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_GetLyrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).GetLyrics(ctx, req.(*GetLyricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SongLibrary_ServiceDesc is the grpc.ServiceDesc for SongLibrary service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SongLibrary_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "songlibrary.v1.SongLibrary",
+	HandlerType: (*SongLibraryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSong",
+			Handler:    _SongLibrary_CreateSong_Handler,
+		},
+		{
+			MethodName: "GetSong",
+			Handler:    _SongLibrary_GetSong_Handler,
+		},
+		{
+			MethodName: "UpdateSong",
+			Handler:    _SongLibrary_UpdateSong_Handler,
+		},
+		{
+			MethodName: "DeleteSong",
+			Handler:    _SongLibrary_DeleteSong_Handler,
+		},
+		{
+			MethodName: "GetLyrics",
+			Handler:    _SongLibrary_GetLyrics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSongs",
+			Handler:       _SongLibrary_ListSongs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "songlibrary/v1/song_library.proto",
+}
@@ -27,10 +27,11 @@ func NewDatabase(cfg config.DBConfig, logger config.Logger) *Database {
 		return nil
 	}
 
-	config.MaxConns = 50
-	config.MinConns = 10
-	config.MaxConnLifetime = 5 * time.Minute
-	config.MaxConnIdleTime = 5 * time.Minute
+	config.MaxConns = int32(cfg.MaxConns)
+	config.MinConns = int32(cfg.MinConns)
+	config.MaxConnLifetime = time.Duration(cfg.MaxConnLifetime) * time.Second
+	config.MaxConnIdleTime = time.Duration(cfg.MaxConnIdleTime) * time.Second
+	config.ConnConfig.Tracer = queryTracer{}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -66,6 +67,10 @@ func (d *Database) Conn(ctx context.Context) trmpgx.Tr {
 	return d.getter.DefaultTrOrDB(ctx, d.pool)
 }
 
+func (d *Database) CheckHealth(ctx context.Context) error {
+	return d.pool.Ping(ctx)
+}
+
 func (d *Database) Close() {
 	d.pool.Close()
 }
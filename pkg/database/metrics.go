@@ -0,0 +1,65 @@
+package database
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Отдаёт статистику пула соединений pgxpool в момент сбора метрик
+type PoolCollector struct {
+	db *Database
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+}
+
+func NewPoolCollector(db *Database) *PoolCollector {
+	return &PoolCollector{
+		db: db,
+		acquiredConns: prometheus.NewDesc("emlib_db_pool_acquired_conns",
+			"Соединения, занятые запросами.", nil, nil),
+		idleConns: prometheus.NewDesc("emlib_db_pool_idle_conns",
+			"Свободные соединения.", nil, nil),
+		totalConns: prometheus.NewDesc("emlib_db_pool_total_conns",
+			"Все открытые соединения.", nil, nil),
+		maxConns: prometheus.NewDesc("emlib_db_pool_max_conns",
+			"Максимальный размер пула.", nil, nil),
+		acquireCount: prometheus.NewDesc("emlib_db_pool_acquire_total",
+			"Сколько раз соединение бралось из пула.", nil, nil),
+		emptyAcquireCount: prometheus.NewDesc("emlib_db_pool_empty_acquire_total",
+			"Сколько раз пришлось ждать соединение, потому что свободных не было.", nil, nil),
+		canceledAcquireCount: prometheus.NewDesc("emlib_db_pool_canceled_acquire_total",
+			"Сколько раз ожидание соединения прервалось отменой контекста.", nil, nil),
+		acquireDuration: prometheus.NewDesc("emlib_db_pool_acquire_duration_seconds_total",
+			"Суммарное время ожидания соединений.", nil, nil),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquireCount
+	ch <- c.acquireDuration
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.db.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
@@ -2,47 +2,308 @@ package database
 
 // Goose использует sql.Open интерфейс pgx.
 import (
+	"bufio"
+	"context"
 	"database/sql"
 	"em-library/config"
+	"errors"
 	"fmt"
-	"path/filepath"
-	"runtime"
+	"io/fs"
+	"strings"
+	"sync"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
 )
 
+// В базе применены миграции новее тех, что есть в бинарнике: скорее всего,
+// запущена старая версия сервиса после выкатки новой.
+var ErrSchemaAhead = errors.New("database schema is ahead of the binary")
+
 type Migrator struct {
 	config config.DBConfig
+	fsys   fs.FS
 }
 
-func NewMigrator(cfg config.DBConfig) *Migrator {
+func NewMigrator(cfg config.DBConfig, fsys fs.FS) *Migrator {
 	return &Migrator{
 		config: cfg,
+		fsys:   fsys,
+	}
+}
+
+// Миграция, которая будет применена или откачена, с её SQL
+type PendingMigration struct {
+	Version int64
+	Name    string
+	SQL     string
+}
+
+// Применяет все новые миграции. Несколько экземпляров сервиса, запущенных одновременно,
+// применяют миграции по очереди под advisory lock в Postgres.
+func (mg *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	var results []*goose.MigrationResult
+	err := mg.withProvider(true, func(p *goose.Provider) error {
+		var err error
+		results, err = p.Up(ctx)
+		return err
+	})
+	if err != nil {
+		return results, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return results, nil
+}
+
+// Откатывает миграции до версии version не включительно, при нулевой версии — только последнюю
+func (mg *Migrator) Down(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	var results []*goose.MigrationResult
+	err := mg.withProvider(true, func(p *goose.Provider) error {
+		if version > 0 {
+			var err error
+			results, err = p.DownTo(ctx, version)
+			return err
+		}
+
+		result, err := p.Down(ctx)
+		if result != nil {
+			results = append(results, result)
+		}
+		return err
+	})
+	if err != nil {
+		return results, fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+
+	return results, nil
+}
+
+// Откатывает и заново применяет последнюю миграцию
+func (mg *Migrator) Redo(ctx context.Context) ([]*goose.MigrationResult, error) {
+	var results []*goose.MigrationResult
+	err := mg.withProvider(true, func(p *goose.Provider) error {
+		down, err := p.Down(ctx)
+		if err != nil {
+			return err
+		}
+		results = append(results, down)
+
+		up, err := p.UpByOne(ctx)
+		if err != nil {
+			return err
+		}
+		results = append(results, up)
+
+		return nil
+	})
+	if err != nil {
+		return results, fmt.Errorf("failed to redo migration: %w", err)
+	}
+
+	return results, nil
+}
+
+func (mg *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	var statuses []*goose.MigrationStatus
+	err := mg.withProvider(false, func(p *goose.Provider) error {
+		var err error
+		statuses, err = p.Status(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration status: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// Миграции, которые применит Up, с SQL секции Up. Ничего не меняет в базе.
+func (mg *Migrator) PlanUp(ctx context.Context) ([]PendingMigration, error) {
+	statuses, err := mg.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []PendingMigration
+	for _, s := range statuses {
+		if s.State != goose.StatePending {
+			continue
+		}
+		m, err := mg.pendingMigration(s.Source, "Up")
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, m)
+	}
+
+	return plan, nil
+}
+
+// Миграции, которые откатит Down с той же версией, от последней к первой, с SQL секции Down.
+// Ничего не меняет в базе.
+func (mg *Migrator) PlanDown(ctx context.Context, version int64) ([]PendingMigration, error) {
+	statuses, err := mg.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []PendingMigration
+	for i := len(statuses) - 1; i >= 0; i-- {
+		s := statuses[i]
+		if s.State != goose.StateApplied || s.Source.Version <= version {
+			continue
+		}
+		m, err := mg.pendingMigration(s.Source, "Down")
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, m)
+		if version == 0 {
+			break
+		}
+	}
+
+	return plan, nil
+}
+
+// Возвращает ErrSchemaAhead, если в базе применены миграции, которых нет в бинарнике
+func (mg *Migrator) CheckCompatible(ctx context.Context) error {
+	return mg.withProvider(false, func(p *goose.Provider) error {
+		return checkCompatible(ctx, p)
+	})
+}
+
+// Проверка миграций для readiness. Проверки идут каждые несколько секунд, поэтому провайдер
+// создаётся один раз поверх пула приложения, а не открывает свои соединения в обход его лимитов.
+type MigrationsHealthCheck struct {
+	db   *sql.DB
+	fsys fs.FS
+
+	once     sync.Once
+	provider *goose.Provider
+	err      error
+}
+
+func NewMigrationsHealthCheck(db *Database, fsys fs.FS) *MigrationsHealthCheck {
+	return &MigrationsHealthCheck{
+		db:   stdlib.OpenDBFromPool(db.pool),
+		fsys: fsys,
 	}
 }
 
-func (mg *Migrator) RunMigrations() error {
-	_, filename, _, _ := runtime.Caller(0)
-	currentDir := filepath.Dir(filename)
-	migrationsPath := filepath.Join(currentDir, "..", "..", "migrations")
+// Возвращает ошибку, если в базе применены не все миграции из бинарника или применены лишние
+func (h *MigrationsHealthCheck) CheckHealth(ctx context.Context) error {
+	h.once.Do(func() {
+		h.provider, h.err = goose.NewProvider(goose.DialectPostgres, h.db, h.fsys)
+	})
+	if h.err != nil {
+		return fmt.Errorf("failed to read migrations: %w", h.err)
+	}
 
-	absPath, err := filepath.Abs(migrationsPath)
+	if err := checkCompatible(ctx, h.provider); err != nil {
+		return err
+	}
+
+	pending, err := h.provider.HasPending(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path: %w", err)
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+	if pending {
+		return fmt.Errorf("database has pending migrations")
+	}
+
+	return nil
+}
+
+func checkCompatible(ctx context.Context, p *goose.Provider) error {
+	dbVersion, err := p.GetDBVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database version: %w", err)
+	}
+
+	var latest int64
+	if sources := p.ListSources(); len(sources) > 0 {
+		latest = sources[len(sources)-1].Version
+	}
+
+	if dbVersion > latest {
+		return fmt.Errorf("%w: database version %d, latest known migration %d", ErrSchemaAhead, dbVersion, latest)
 	}
 
+	return nil
+}
+
+// Блокировка нужна только для изменения схемы: статус и проверки здоровья
+// не должны ждать, пока другой экземпляр применяет миграции.
+func (mg *Migrator) withProvider(locked bool, f func(p *goose.Provider) error) error {
 	db, err := sql.Open("pgx", mg.config.GetConnectionString())
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 	defer db.Close()
 
-	goose.SetLogger(goose.NopLogger())
+	var options []goose.ProviderOption
+	if locked {
+		locker, err := lock.NewPostgresSessionLocker()
+		if err != nil {
+			return err
+		}
+		options = append(options, goose.WithSessionLocker(locker))
+	}
 
-	if err := goose.Up(db, absPath); err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, mg.fsys, options...)
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
 	}
 
-	return nil
+	return f(provider)
+}
+
+func (mg *Migrator) pendingMigration(source *goose.Source, direction string) (PendingMigration, error) {
+	sql, err := readMigrationSection(mg.fsys, source.Path, direction)
+	if err != nil {
+		return PendingMigration{}, err
+	}
+
+	return PendingMigration{
+		Version: source.Version,
+		Name:    source.Path,
+		SQL:     sql,
+	}, nil
+}
+
+// Вырезает из файла миграции секцию между "-- +goose Up" и "-- +goose Down" или после "-- +goose Down"
+func readMigrationSection(fsys fs.FS, path, direction string) (string, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read migration %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var section strings.Builder
+	var inSection bool
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if annotation, ok := strings.CutPrefix(strings.TrimSpace(line), "-- +goose "); ok {
+			switch annotation {
+			case "Up", "Down":
+				inSection = annotation == direction
+				continue
+			case "StatementBegin", "StatementEnd":
+				continue
+			}
+		}
+		if inSection {
+			section.WriteString(line)
+			section.WriteString("\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read migration %s: %w", path, err)
+	}
+
+	return strings.TrimSpace(section.String()), nil
 }
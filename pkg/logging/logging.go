@@ -1,30 +1,44 @@
 package logging
 
 import (
+	"io"
 	"log/slog"
 	"os"
 )
 
 var Logger *slog.Logger
 
-func NewLogger(logLevel string) *slog.Logger {
+// Уровень общий для всех логгеров и меняется на лету при перезагрузке конфигурации
+var level = new(slog.LevelVar)
 
-	var slogLevel slog.Level
+func NewLogger(logLevel, format string) *slog.Logger {
+	SetLevel(logLevel)
+
+	Logger = slog.New(contextHandler{newHandler(os.Stdout, format, level)})
+	return Logger
+}
+
+func SetLevel(logLevel string) {
 	switch logLevel {
 	case "debug":
-		slogLevel = slog.LevelDebug
+		level.Set(slog.LevelDebug)
 	case "info":
-		slogLevel = slog.LevelInfo
+		level.Set(slog.LevelInfo)
 	case "warning":
-		slogLevel = slog.LevelWarn
+		level.Set(slog.LevelWarn)
 	case "error":
-		slogLevel = slog.LevelError
+		level.Set(slog.LevelError)
 	}
+}
 
-	handler := slog.NewTextHandler(
-		os.Stdout,
-		&slog.HandlerOptions{Level: slogLevel},
-	)
-	Logger = slog.New(handler)
-	return Logger
+func newHandler(w io.Writer, format string, level slog.Leveler) slog.Handler {
+	options := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}
+
+	if format == "json" {
+		return slog.NewJSONHandler(w, options)
+	}
+	return slog.NewTextHandler(w, options)
 }
@@ -0,0 +1,26 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Ключи, значения которых никогда не выводятся в лог
+var sensitiveKeys = map[string]struct{}{
+	"password":      {},
+	"secret":        {},
+	"token":         {},
+	"api_key":       {},
+	"key_hash":      {},
+	"authorization": {},
+	"x-api-key":     {},
+}
+
+func redact(_ []string, a slog.Attr) slog.Attr {
+	if _, ok := sensitiveKeys[strings.ToLower(a.Key)]; ok {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
@@ -2,9 +2,14 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
 	"em-library/config"
 	"em-library/internal/api/handlers"
+	"em-library/internal/entities"
+	"em-library/pkg/logging"
+	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,10 +17,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type Server struct {
 	httpServer *http.Server
+	handlers   *handlers.Handlers
 	cfg        *config.Config
 }
 
@@ -26,17 +33,30 @@ func New(cfg *config.Config, handlers *handlers.Handlers) *Server {
 
 	router := gin.New()
 
+	router.Use(RequestIDMiddleware())
+	router.Use(TracingMiddleware())
 	router.Use(GinLoggerMiddleware(cfg.Logger))
+	if cfg.Metrics.Enabled {
+		router.Use(MetricsMiddleware())
+	}
 	router.Use(gin.Recovery())
 
+	if cfg.Metrics.Enabled {
+		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	}
+
 	handlers.RegisterRoutes(router)
 
 	return &Server{
 		httpServer: &http.Server{
-			Addr:    ":" + cfg.Server.Port,
-			Handler: router,
+			Addr:         ":" + cfg.Server.Port,
+			Handler:      router,
+			ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+			WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+			IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
 		},
-		cfg: cfg,
+		handlers: handlers,
+		cfg:      cfg,
 	}
 }
 
@@ -55,6 +75,14 @@ func (s *Server) Run() error {
 }
 
 func (s *Server) Shutdown() error {
+	// сначала readiness начинает отвечать 503, и балансировщик убирает сервер из ротации,
+	// а текущие и успевшие прийти запросы обрабатываются как обычно
+	s.handlers.Health.SetDraining()
+	if delay := time.Duration(s.cfg.Health.ShutdownDelay) * time.Second; delay > 0 {
+		s.cfg.Logger.Info("Waiting before shutdown", "delay", delay.String())
+		time.Sleep(delay)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -80,12 +108,48 @@ func GinLoggerMiddleware(logger config.Logger) gin.HandlerFunc {
 
 		duration := time.Since(start)
 
-		logger.Info("HTTP Request",
+		var subject string
+		if principal, ok := entities.PrincipalFromContext(c.Request.Context()); ok {
+			subject = principal.Subject
+		}
+
+		logger.InfoContext(c.Request.Context(), "HTTP Request",
 			"method", c.Request.Method,
 			"path", c.Request.URL.Path,
 			"status", c.Writer.Status(),
 			"latency", duration.String(),
 			"client_ip", c.ClientIP(),
+			"principal", subject,
 		)
 	}
 }
+
+const requestIDHeader = "X-Request-ID"
+
+// Берёт ID запроса из X-Request-ID или генерирует новый, возвращает его
+// в ответе и кладёт в контекст, чтобы он попал в логи и журнал аудита.
+// Вместе с ним в контекст логгера кладётся шаблон маршрута.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = newRequestID()
+		}
+
+		c.Header(requestIDHeader, requestID)
+
+		ctx := entities.ContextWithRequestID(c.Request.Context(), requestID)
+		ctx = logging.ContextWithAttrs(ctx,
+			slog.String("request_id", requestID),
+			slog.String("route", c.FullPath()),
+		)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
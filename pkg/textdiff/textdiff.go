@@ -0,0 +1,158 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type OpKind int
+
+const (
+	Equal OpKind = iota
+	Delete
+	Insert
+)
+
+// Одна операция редактирования. AIndex и BIndex — позиции элемента в исходной
+// и целевой последовательностях, для Insert AIndex и для Delete BIndex равны -1.
+type Op struct {
+	Kind   OpKind
+	AIndex int
+	BIndex int
+	Text   string
+}
+
+// Строит кратчайший сценарий редактирования a в b через наибольшую общую подпоследовательность.
+// Тексты песен небольшие, поэтому квадратичной по памяти таблицы достаточно.
+func Diff(a, b []string) []Op {
+	n, m := len(a), len(b)
+
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]Op, 0, max(n, m))
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, Op{Kind: Equal, AIndex: i, BIndex: j, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, Op{Kind: Delete, AIndex: i, BIndex: -1, Text: a[i]})
+			i++
+		default:
+			ops = append(ops, Op{Kind: Insert, AIndex: -1, BIndex: j, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, Op{Kind: Delete, AIndex: i, BIndex: -1, Text: a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, Op{Kind: Insert, AIndex: -1, BIndex: j, Text: b[j]})
+	}
+
+	return ops
+}
+
+// Формирует unified diff в формате diff -u с context строками контекста вокруг изменений.
+// Если последовательности совпадают, возвращает пустую строку.
+func Unified(fromName, toName string, a, b []string, context int) string {
+	ops := Diff(a, b)
+
+	var changes []int
+	for idx, op := range ops {
+		if op.Kind != Equal {
+			changes = append(changes, idx)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	for c := 0; c < len(changes); {
+		start := max(changes[c]-context, 0)
+		end := min(changes[c]+context+1, len(ops))
+
+		// соседние изменения, контекст которых пересекается, попадают в один блок
+		c++
+		for c < len(changes) && changes[c]-context <= end {
+			end = min(changes[c]+context+1, len(ops))
+			c++
+		}
+
+		writeHunk(&sb, ops, start, end)
+	}
+
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, ops []Op, start, end int) {
+	// позиции в unified diff считаются с единицы
+	aStart, bStart := positionBefore(ops, start)
+	var aLen, bLen int
+	for _, op := range ops[start:end] {
+		if op.Kind != Insert {
+			aLen++
+		}
+		if op.Kind != Delete {
+			bLen++
+		}
+	}
+	if aLen > 0 {
+		aStart++
+	}
+	if bLen > 0 {
+		bStart++
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+	for _, op := range ops[start:end] {
+		switch op.Kind {
+		case Equal:
+			sb.WriteString(" ")
+		case Delete:
+			sb.WriteString("-")
+		case Insert:
+			sb.WriteString("+")
+		}
+		sb.WriteString(op.Text)
+		sb.WriteString("\n")
+	}
+}
+
+// Количество строк исходной и целевой последовательности перед операцией с индексом idx.
+func positionBefore(ops []Op, idx int) (int, int) {
+	var a, b int
+	for _, op := range ops[:idx] {
+		if op.Kind != Insert {
+			a++
+		}
+		if op.Kind != Delete {
+			b++
+		}
+	}
+	return a, b
+}
+
+func hunkRange(start, length int) string {
+	if length == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, length)
+}
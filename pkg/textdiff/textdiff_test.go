@@ -0,0 +1,116 @@
+package textdiff_test
+
+import (
+	"em-library/pkg/textdiff"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	ops := textdiff.Diff([]string{"a", "b", "c"}, []string{"a", "x", "c"})
+
+	assert.Equal(t, []textdiff.Op{
+		{Kind: textdiff.Equal, AIndex: 0, BIndex: 0, Text: "a"},
+		{Kind: textdiff.Delete, AIndex: 1, BIndex: -1, Text: "b"},
+		{Kind: textdiff.Insert, AIndex: -1, BIndex: 1, Text: "x"},
+		{Kind: textdiff.Equal, AIndex: 2, BIndex: 2, Text: "c"},
+	}, ops)
+}
+
+func TestUnified(t *testing.T) {
+	lines := []string{"l1", "l2", "l3", "l4", "l5", "l6", "l7", "l8", "l9"}
+
+	tests := []struct {
+		name     string
+		a        []string
+		b        []string
+		context  int
+		expected string
+	}{
+		{
+			name:     "identical",
+			a:        []string{"l1", "l2"},
+			b:        []string{"l1", "l2"},
+			context:  3,
+			expected: "",
+		},
+		{
+			name:     "both empty",
+			context:  3,
+			expected: "",
+		},
+		{
+			name:    "insert into empty",
+			b:       []string{"l1", "l2"},
+			context: 3,
+			expected: "--- a\n+++ b\n" +
+				"@@ -0,0 +1,2 @@\n+l1\n+l2\n",
+		},
+		{
+			name:    "delete everything",
+			a:       []string{"l1"},
+			context: 3,
+			expected: "--- a\n+++ b\n" +
+				"@@ -1 +0,0 @@\n-l1\n",
+		},
+		{
+			name:    "pure insert",
+			a:       []string{"l1", "l2", "l3"},
+			b:       []string{"l1", "l2", "x", "l3"},
+			context: 1,
+			expected: "--- a\n+++ b\n" +
+				"@@ -2,2 +2,3 @@\n l2\n+x\n l3\n",
+		},
+		{
+			name:    "pure delete",
+			a:       []string{"l1", "l2", "l3"},
+			b:       []string{"l1", "l3"},
+			context: 1,
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,3 +1,2 @@\n l1\n-l2\n l3\n",
+		},
+		{
+			name:    "single line replaced",
+			a:       []string{"l1"},
+			b:       []string{"x"},
+			context: 3,
+			expected: "--- a\n+++ b\n" +
+				"@@ -1 +1 @@\n-l1\n+x\n",
+		},
+		{
+			// между изменениями одна строка, её контекст общий для обоих
+			name:    "adjacent hunks merge",
+			a:       lines[:5],
+			b:       []string{"l1", "x2", "l3", "x4", "l5"},
+			context: 1,
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,5 +1,5 @@\n l1\n-l2\n+x2\n l3\n-l4\n+x4\n l5\n",
+		},
+		{
+			name:    "distant hunks stay split",
+			a:       lines,
+			b:       []string{"l1", "x2", "l3", "l4", "l5", "l6", "l7", "x8", "l9"},
+			context: 1,
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,3 +1,3 @@\n l1\n-l2\n+x2\n l3\n" +
+				"@@ -7,3 +7,3 @@\n l7\n-l8\n+x8\n l9\n",
+		},
+		{
+			// номера строк второго блока сдвигаются на строки, добавленные в первом
+			name:    "line numbers after insert",
+			a:       lines,
+			b:       []string{"l1", "x", "y", "l2", "l3", "l4", "l5", "l6", "l7", "l8"},
+			context: 1,
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,2 +1,4 @@\n l1\n+x\n+y\n l2\n" +
+				"@@ -8,2 +10 @@\n l8\n-l9\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, textdiff.Unified("a", "b", tt.a, tt.b, tt.context))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package textsim
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Пометки изданий, которые не меняют саму песню: ремастеры, живые версии и т.п.
+var editionMarkers = `remaster(ed)?|live|mono|stereo|version|edit|mix|remix|demo|acoustic|deluxe|bonus|single|album|radio|explicit|clean|mtv|unplugged|\d{4}`
+
+var (
+	// "Yesterday (Remastered 2009)", "Song [Live at Wembley]"
+	bracketedEdition = regexp.MustCompile(`(?i)\s*[(\[][^)\]]*\b(` + editionMarkers + `)\b[^)\]]*[)\]]`)
+	// "Yesterday - Remastered 2009", "Song - Live"
+	dashedEdition = regexp.MustCompile(`(?i)\s+[-–—]\s+[^-–—]*\b(` + editionMarkers + `)\b.*$`)
+)
+
+// Приводит название к виду для сравнения: нижний регистр, без пометок изданий и пунктуации.
+func NormalizeTitle(title string) string {
+	title = bracketedEdition.ReplaceAllString(title, "")
+	title = dashedEdition.ReplaceAllString(title, "")
+	return strings.Join(Words(title), " ")
+}
+
+var wordsReplacer = strings.NewReplacer(`\n`, " ", "'", "", "’", "")
+
+// Разбивает текст на слова в нижнем регистре. Экранированные переводы строк (\n) считаются
+// разделителями, апострофы выбрасываются, чтобы "don't" и "dont" совпадали.
+func Words(text string) []string {
+	text = wordsReplacer.Replace(text)
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Похожесть строк от 0 до 1 на основе расстояния Левенштейна.
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// Похожесть текстов от 0 до 1 как коэффициент Жаккара для множеств слов.
+func WordSetSimilarity(a, b string) float64 {
+	setA := wordSet(a)
+	setB := wordSet(b)
+	if len(setA) == 0 && len(setB) == 0 {
+		return 1
+	}
+
+	var common int
+	for word := range setA {
+		if _, ok := setB[word]; ok {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(setA)+len(setB)-common)
+}
+
+func wordSet(text string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range Words(text) {
+		set[word] = struct{}{}
+	}
+	return set
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
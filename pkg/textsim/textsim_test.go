@@ -0,0 +1,102 @@
+package textsim_test
+
+import (
+	"em-library/pkg/textsim"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Порог по умолчанию, с которым фоновая задача сохраняет пару как дубликат (EMLIB_DUPLICATES_MIN_SCORE)
+const duplicatesMinScore = 0.85
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"Bohemian Rhapsody", "bohemian rhapsody"},
+		{"  Bohemian   RHAPSODY\t", "bohemian rhapsody"},
+		{"Yesterday (Remastered 2009)", "yesterday"},
+		{"Song 2 [Live at Wembley]", "song 2"},
+		{"Yesterday - Remastered 2009", "yesterday"},
+		{"Don't Stop Me Now", "dont stop me now"},
+		// скобки без пометки издания — часть названия
+		{"(I Can't Get No) Satisfaction", "i cant get no satisfaction"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, textsim.NormalizeTitle(tt.title))
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []string{"dont", "stop", "me", "now"}, textsim.Words(`Don’t stop,\nme NOW!`))
+	assert.Empty(t, textsim.Words("  \t "))
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected float64
+	}{
+		{"identical", "uprising", "uprising", 1},
+		{"both empty", "", "", 1},
+		{"one empty", "uprising", "", 0},
+		{"nothing in common", "abc", "xyz", 0},
+		{"one edit", "abcd", "abce", 0.75},
+		{"runes, not bytes", "ёлка", "елка", 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, textsim.Similarity(tt.a, tt.b), 1e-9)
+			assert.InDelta(t, tt.expected, textsim.Similarity(tt.b, tt.a), 1e-9)
+		})
+	}
+}
+
+// Регистр, пробелы и пометки изданий не влияют на оценку после нормализации
+func TestSimilarity_NormalizedTitles(t *testing.T) {
+	score := textsim.Similarity(
+		textsim.NormalizeTitle("BOHEMIAN  rhapsody (Remastered 2011)"),
+		textsim.NormalizeTitle("Bohemian Rhapsody"),
+	)
+
+	assert.Equal(t, 1.0, score)
+}
+
+// Название из 20 символов остаётся дубликатом при трёх отличиях и перестаёт им быть при четырёх
+func TestSimilarity_DuplicatesThreshold(t *testing.T) {
+	title := "abcdefghijklmnopqrst"
+
+	assert.GreaterOrEqual(t, textsim.Similarity(title, "abcdefghijklmnopqxyz"), duplicatesMinScore)
+	assert.Less(t, textsim.Similarity(title, "abcdefghijklmnopwxyz"), duplicatesMinScore)
+}
+
+func TestWordSetSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected float64
+	}{
+		{"identical", "Is this the real life", "Is this the real life", 1},
+		{"both empty", "", "", 1},
+		{"one empty", "Is this the real life", "", 0},
+		{"case, punctuation and order", "Is this the real life?", "LIFE, real the this is", 1},
+		{"repeated words count once", "na na na hey", "na hey", 1},
+		{"half in common", "a b c", "a b d", 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, textsim.WordSetSimilarity(tt.a, tt.b), 1e-9)
+		})
+	}
+}